@@ -0,0 +1,258 @@
+package admin_server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/Nuffle-Labs/nffl/aggregator"
+	"github.com/Nuffle-Labs/nffl/aggregator/blsagg"
+	"github.com/Nuffle-Labs/nffl/aggregator/types"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const (
+	DEFAULT_AUDIT_LOG_LIMIT = 100
+	MAX_AUDIT_LOG_LIMIT     = 1000
+)
+
+var (
+	InvalidMessageKeyLengthError = errors.New("Invalid message key length")
+	InvalidLimitError            = errors.New("Invalid limit")
+
+	errorToCode = map[error]int{
+		aggregator.UnsupportedMessageTypeError: http.StatusBadRequest,
+		aggregator.MessageNotFoundError:        http.StatusNotFound,
+		aggregator.OperatorSetNotFoundError:    http.StatusNotFound,
+		aggregator.OperatorAggNotFoundError:    http.StatusNotFound,
+	}
+)
+
+type CheckpointTasksResponse struct {
+	Paused bool
+}
+
+type GetInFlightAggregationsResponse struct {
+	Aggregations []blsagg.ActiveMessage
+}
+
+type LogLevelResponse struct {
+	Level string
+}
+
+type GetAuditLogResponse struct {
+	Entries []types.AuditLogEntry
+}
+
+// AdminServer exposes runtime controls over the aggregator. Every request
+// must carry the configured API token as a bearer token, and every
+// authenticated request is recorded in the audit log.
+type AdminServer struct {
+	serverIpPortAddr string
+	apiToken         string
+	app              aggregator.AdminAggregatorer
+	logLevel         zap.AtomicLevel
+
+	logger logging.Logger
+}
+
+func NewAdminServer(serverIpPortAddr, apiToken string, app aggregator.AdminAggregatorer, logLevel zap.AtomicLevel, logger logging.Logger) *AdminServer {
+	return &AdminServer{
+		serverIpPortAddr: serverIpPortAddr,
+		apiToken:         apiToken,
+		app:              app,
+		logLevel:         logLevel,
+		logger:           logger,
+	}
+}
+
+func (s *AdminServer) Start() error {
+	s.logger.Info("Starting aggregator admin API.")
+
+	err := http.ListenAndServe(s.serverIpPortAddr, s.router())
+	if err != nil {
+		s.logger.Fatal("ListenAndServe", "err", err)
+	}
+
+	return nil
+}
+
+func (s *AdminServer) router() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(s.authenticate)
+
+	router.HandleFunc("/admin/checkpoint/pause", s.audited("pause_checkpoint_tasks", s.handlePauseCheckpointTasks)).Methods("POST")
+	router.HandleFunc("/admin/checkpoint/resume", s.audited("resume_checkpoint_tasks", s.handleResumeCheckpointTasks)).Methods("POST")
+	router.HandleFunc("/admin/checkpoint/trigger", s.audited("trigger_checkpoint_task", s.handleTriggerCheckpointTask)).Methods("POST")
+	router.HandleFunc("/admin/rollup/operator-set/resync", s.audited("resync_rollup_operator_sets", s.handleResyncRollupOperatorSets)).Methods("POST")
+	router.HandleFunc("/admin/aggregations", s.audited("get_in_flight_aggregations", s.handleGetInFlightAggregations)).Methods("GET")
+	router.HandleFunc("/admin/aggregations", s.audited("drop_message", s.handleDropMessage)).Methods("DELETE")
+	router.HandleFunc("/admin/log-level", s.audited("set_log_level", s.handleSetLogLevel)).Methods("PUT")
+	router.HandleFunc("/admin/audit-log", s.audited("get_audit_log", s.handleGetAuditLog)).Methods("GET")
+
+	return router
+}
+
+func (s *AdminServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.apiToken)) != 1 {
+			s.logger.Warn("Unauthorized admin request", "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Records the request and its outcome in the audit log after handling it
+func (s *AdminServer) audited(action string, requestCallback func(w http.ResponseWriter, r *http.Request) error) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := requestCallback(w, r)
+
+		entry := types.AuditLogEntry{
+			Action:     action,
+			Params:     r.URL.RawQuery,
+			RemoteAddr: r.RemoteAddr,
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		if err := s.app.StoreAuditLogEntry(entry); err != nil {
+			s.logger.Error("Failed to store audit log entry", "action", action, "err", err)
+		}
+	}
+}
+
+func (s *AdminServer) handlePauseCheckpointTasks(w http.ResponseWriter, r *http.Request) error {
+	s.app.PauseCheckpointTasks()
+
+	return writeJson(w, CheckpointTasksResponse{Paused: s.app.CheckpointTasksPaused()})
+}
+
+func (s *AdminServer) handleResumeCheckpointTasks(w http.ResponseWriter, r *http.Request) error {
+	s.app.ResumeCheckpointTasks()
+
+	return writeJson(w, CheckpointTasksResponse{Paused: s.app.CheckpointTasksPaused()})
+}
+
+func (s *AdminServer) handleTriggerCheckpointTask(w http.ResponseWriter, r *http.Request) error {
+	err := s.app.TriggerCheckpointTask()
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *AdminServer) handleResyncRollupOperatorSets(w http.ResponseWriter, r *http.Request) error {
+	err := s.app.ResyncRollupOperatorSets(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *AdminServer) handleGetInFlightAggregations(w http.ResponseWriter, r *http.Request) error {
+	return writeJson(w, GetInFlightAggregationsResponse{Aggregations: s.app.GetInFlightAggregations()})
+}
+
+func (s *AdminServer) handleDropMessage(w http.ResponseWriter, r *http.Request) error {
+	params := r.URL.Query()
+
+	messageKey, err := parseMessageKey(params.Get("messageKey"))
+	if err != nil {
+		http.Error(w, "Invalid messageKey", http.StatusBadRequest)
+		return err
+	}
+
+	err = s.app.DropMessage(messages.MessageType(params.Get("messageType")), messageKey)
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *AdminServer) handleSetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	level, err := zapcore.ParseLevel(r.URL.Query().Get("level"))
+	if err != nil {
+		http.Error(w, "Invalid level", http.StatusBadRequest)
+		return err
+	}
+
+	s.logger.Info("Changing log level", "from", s.logLevel.Level().String(), "to", level.String())
+	s.logLevel.SetLevel(level)
+
+	return writeJson(w, LogLevelResponse{Level: s.logLevel.Level().String()})
+}
+
+func (s *AdminServer) handleGetAuditLog(w http.ResponseWriter, r *http.Request) error {
+	limit := DEFAULT_AUDIT_LOG_LIMIT
+
+	params := r.URL.Query()
+	if params.Has("limit") {
+		parsedLimit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil || parsedLimit <= 0 || parsedLimit > MAX_AUDIT_LOG_LIMIT {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return InvalidLimitError
+		}
+
+		limit = parsedLimit
+	}
+
+	entries, err := s.app.GetAuditLog(limit)
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	return writeJson(w, GetAuditLogResponse{Entries: entries})
+}
+
+func writeJson(w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+func parseMessageKey(param string) (coretypes.MessageKey, error) {
+	messageKeyBytes, err := hexutil.Decode(param)
+	if err != nil {
+		return coretypes.MessageKey{}, err
+	}
+
+	if len(messageKeyBytes) != len(coretypes.MessageKey{}) {
+		return coretypes.MessageKey{}, InvalidMessageKeyLengthError
+	}
+
+	return coretypes.MessageKey(messageKeyBytes), nil
+}
+
+func mapErrorToCode(err error) int {
+	status, ok := errorToCode[err]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	return status
+}
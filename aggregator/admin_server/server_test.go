@@ -0,0 +1,128 @@
+package admin_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/Nuffle-Labs/nffl/aggregator"
+	"github.com/Nuffle-Labs/nffl/aggregator/mocks"
+	"github.com/Nuffle-Labs/nffl/aggregator/types"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const TEST_API_TOKEN = "token"
+
+func newTestRequest(t *testing.T, method, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	assert.Nil(t, err)
+
+	req.Header.Set("Authorization", "Bearer "+TEST_API_TOKEN)
+	req.RemoteAddr = "127.0.0.1:1234"
+
+	return req
+}
+
+func TestUnauthorizedRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	app := mocks.NewMockAdminAggregatorer(mockCtrl)
+	adminServer := NewAdminServer("", TEST_API_TOKEN, app, zap.NewAtomicLevel(), sdklogging.NewNoopLogger())
+
+	req := newTestRequest(t, "POST", "/admin/checkpoint/pause")
+	req.Header.Set("Authorization", "Bearer wrong")
+
+	recorder := httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	req.Header.Del("Authorization")
+
+	recorder = httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestPauseCheckpointTasks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	app := mocks.NewMockAdminAggregatorer(mockCtrl)
+	adminServer := NewAdminServer("", TEST_API_TOKEN, app, zap.NewAtomicLevel(), sdklogging.NewNoopLogger())
+
+	app.EXPECT().PauseCheckpointTasks()
+	app.EXPECT().CheckpointTasksPaused().Return(true)
+	app.EXPECT().StoreAuditLogEntry(types.AuditLogEntry{
+		Action:     "pause_checkpoint_tasks",
+		RemoteAddr: "127.0.0.1:1234",
+	})
+
+	recorder := httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, newTestRequest(t, "POST", "/admin/checkpoint/pause"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var body CheckpointTasksResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &body)
+	assert.Nil(t, err)
+	assert.True(t, body.Paused)
+}
+
+func TestDropMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	app := mocks.NewMockAdminAggregatorer(mockCtrl)
+	adminServer := NewAdminServer("", TEST_API_TOKEN, app, zap.NewAtomicLevel(), sdklogging.NewNoopLogger())
+
+	messageKey := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}.Key()
+	query := fmt.Sprintf("messageType=%s&messageKey=0x%x", messages.StateRootUpdateMessageType, messageKey)
+
+	app.EXPECT().DropMessage(messages.StateRootUpdateMessageType, messageKey).Return(aggregator.MessageNotFoundError)
+	app.EXPECT().StoreAuditLogEntry(types.AuditLogEntry{
+		Action:     "drop_message",
+		Params:     query,
+		RemoteAddr: "127.0.0.1:1234",
+		Error:      aggregator.MessageNotFoundError.Error(),
+	})
+
+	recorder := httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, newTestRequest(t, "DELETE", "/admin/aggregations?"+query))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// Invalid keys are rejected before reaching the aggregator
+	app.EXPECT().StoreAuditLogEntry(gomock.Any())
+
+	recorder = httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, newTestRequest(t, "DELETE", "/admin/aggregations?messageType=state_root_update&messageKey=0x01"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	app := mocks.NewMockAdminAggregatorer(mockCtrl)
+	logLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	adminServer := NewAdminServer("", TEST_API_TOKEN, app, logLevel, sdklogging.NewNoopLogger())
+
+	app.EXPECT().StoreAuditLogEntry(gomock.Any()).Times(2)
+
+	recorder := httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, newTestRequest(t, "PUT", "/admin/log-level?level=debug"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, zapcore.DebugLevel, logLevel.Level())
+
+	recorder = httptest.NewRecorder()
+	adminServer.router().ServeHTTP(recorder, newTestRequest(t, "PUT", "/admin/log-level?level=verbose"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, zapcore.DebugLevel, logLevel.Level())
+}
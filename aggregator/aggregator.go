@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
@@ -16,11 +17,14 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
 	"github.com/Layr-Labs/eigensdk-go/signerv2"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Nuffle-Labs/nffl/aggregator/blsagg"
 	"github.com/Nuffle-Labs/nffl/aggregator/database"
 	"github.com/Nuffle-Labs/nffl/aggregator/types"
+	opsetupdatereg "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLOperatorSetUpdateRegistry"
+	registryrollup "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLRegistryRollup"
 	taskmanager "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLTaskManager"
 	"github.com/Nuffle-Labs/nffl/core"
 	"github.com/Nuffle-Labs/nffl/core/chainio"
@@ -41,6 +45,13 @@ const (
 	avsName                           = "super-fast-finality-layer"
 )
 
+const (
+	// Bounds of the delay before resubscribing to operator set updates, which
+	// doubles on each failed attempt
+	OPERATOR_SET_UPDATE_RESUBSCRIBE_MIN_BACKOFF = 500 * time.Millisecond
+	OPERATOR_SET_UPDATE_RESUBSCRIBE_MAX_BACKOFF = time.Minute
+)
+
 var (
 	// RPC errors
 	DigestError                    = errors.New("Failed to get message digest")
@@ -50,6 +61,10 @@ var (
 	InvalidSignatureError          = errors.New("Invalid signature")
 	UnsupportedMessageTypeError    = errors.New("Unsupported message type")
 	MessageTimeoutError            = errors.New("Message timeout")
+	UnknownRollupError             = errors.New("Unknown rollup")
+	RollupHeadUnknownError         = errors.New("Rollup head not known yet")
+	RollupHeadStaleError           = errors.New("Rollup head not polled recently")
+	RateLimitedError               = errors.New("Too many requests")
 
 	// REST errors
 	StateRootUpdateNotFoundError = errors.New("StateRootUpdate not found")
@@ -57,21 +72,42 @@ var (
 	OperatorSetNotFoundError     = errors.New("OperatorSetUpdate not found")
 	OperatorAggNotFoundError     = errors.New("OperatorSetUpdate aggregation not found")
 	CheckpointNotFoundError      = errors.New("CheckpointMessages not found")
+	EquivocationEvidenceError    = errors.New("Failed to fetch equivocation evidence")
+	OperatorParticipationError   = errors.New("Failed to fetch operator participation")
+
+	// Admin errors
+	MessageNotFoundError = errors.New("Message not being aggregated")
+	AuditLogError        = errors.New("Failed to fetch audit log")
 )
 
 type RpcAggregatorer interface {
-	ProcessSignedCheckpointTaskResponse(signedCheckpointTaskResponse *messages.SignedCheckpointTaskResponse) error
-	ProcessSignedStateRootUpdateMessage(signedStateRootUpdateMessage *messages.SignedStateRootUpdateMessage) error
-	ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUpdateMessage *messages.SignedOperatorSetUpdateMessage) error
+	ProcessSignedCheckpointTaskResponse(ctx context.Context, signedCheckpointTaskResponse *messages.SignedCheckpointTaskResponse) error
+	ProcessSignedStateRootUpdateMessage(ctx context.Context, signedStateRootUpdateMessage *messages.SignedStateRootUpdateMessage) error
+	ProcessSignedOperatorSetUpdateMessage(ctx context.Context, signedOperatorSetUpdateMessage *messages.SignedOperatorSetUpdateMessage) error
 	GetAggregatedCheckpointMessages(fromTimestamp, toTimestamp uint64) (*messages.CheckpointMessages, error)
 	GetRegistryCoordinatorAddress(reply *string) error
 	GetOperatorInfoById(ctx context.Context, operatorId eigentypes.OperatorId) (eigentypes.OperatorInfo, bool)
+	GetRollupHead(rollupId uint32) (uint64, error)
 }
 
 type RestAggregatorer interface {
 	GetStateRootUpdateAggregation(rollupId uint32, blockHeight uint64) (*types.GetStateRootUpdateAggregationResponse, error)
 	GetOperatorSetUpdateAggregation(id uint64) (*types.GetOperatorSetUpdateAggregationResponse, error)
 	GetCheckpointMessages(fromTimestamp, toTimestamp uint64) (*types.GetCheckpointMessagesResponse, error)
+	GetEquivocationEvidence(operatorId *eigentypes.OperatorId) (*types.GetEquivocationEvidenceResponse, error)
+	GetOperatorMissedMessages(operatorId eigentypes.OperatorId, cursor uint64, limit int) (*types.GetOperatorMissedMessagesResponse, error)
+}
+
+type AdminAggregatorer interface {
+	PauseCheckpointTasks()
+	ResumeCheckpointTasks()
+	CheckpointTasksPaused() bool
+	TriggerCheckpointTask() error
+	ResyncRollupOperatorSets(ctx context.Context) error
+	GetInFlightAggregations() []blsagg.ActiveMessage
+	DropMessage(messageType messages.MessageType, messageKey coretypes.MessageKey) error
+	StoreAuditLogEntry(entry types.AuditLogEntry) error
+	GetAuditLog(limit int) ([]types.AuditLogEntry, error)
 }
 
 // Aggregator sends checkpoint tasks onchain, then listens for operator signed TaskResponses.
@@ -126,17 +162,26 @@ type Aggregator struct {
 	aggregatorListener AggregatorEventListener
 
 	operatorRegistrationsService           OperatorRegistrationsService
+	rollupHeadTracker                      *RollupHeadTracker
+	rateLimiter                            *operatorRateLimiter
+	operatorStateCache                     *blsagg.OperatorStateCache
+	signatureVerifier                      *blsagg.SignatureVerifier
 	taskBlsAggregationService              blsagg.MessageBlsAggregationService
 	stateRootUpdateBlsAggregationService   blsagg.MessageBlsAggregationService
 	operatorSetUpdateBlsAggregationService blsagg.MessageBlsAggregationService
+	participationTracker                   *ParticipationTracker
+	checkpointTaskTracker                  *CheckpointTaskTracker
 	tasks                                  map[coretypes.TaskIndex]taskmanager.CheckpointTask
 	tasksLock                              sync.RWMutex
+	checkpointTasksPaused                  atomic.Bool
+	checkpointSchedulingPolicy             CheckpointSchedulingPolicy
 	msgDb                                  database.Databaser
 }
 
 var _ core.Metricable = (*Aggregator)(nil)
 var _ RpcAggregatorer = (*Aggregator)(nil)
 var _ RestAggregatorer = (*Aggregator)(nil)
+var _ AdminAggregatorer = (*Aggregator)(nil)
 
 // NewAggregator creates a new Aggregator with the provided config.
 func NewAggregator(
@@ -152,7 +197,24 @@ func NewAggregator(
 		return nil, err
 	}
 
-	ethWsClient, err := core.CreateEthClientWithCollector(AggregatorNamespace, config.EthWsRpcUrl, config.EnableMetrics, registry, logger)
+	msgDb, err := database.NewDatabase(config.AggregatorDatabasePath)
+	if err != nil {
+		logger.Error("Cannot create database", "err", err)
+		return nil, err
+	}
+
+	ethWsClientOpts := []safeclient.SafeEthClientOption{safeclient.WithLogConfirmations(config.EthLogConfirmations)}
+	if config.AggregatorEventCursorEnabled {
+		ethWsClientOpts = append(ethWsClientOpts, safeclient.WithCursorStore(msgDb))
+		if config.AggregatorEventCursorMaxReplayBlocks != 0 {
+			ethWsClientOpts = append(ethWsClientOpts, safeclient.WithCursorMaxReplayRange(config.AggregatorEventCursorMaxReplayBlocks))
+		}
+	}
+
+	ethWsClient, err := core.CreateEthClientWithCollector(
+		AggregatorNamespace, config.EthWsRpcUrl, config.EnableMetrics, registry, logger,
+		ethWsClientOpts...,
+	)
 	if err != nil {
 		logger.Error("Cannot create ws ethclient", "err", err)
 		return nil, err
@@ -196,12 +258,6 @@ func NewAggregator(
 		return nil, err
 	}
 
-	msgDb, err := database.NewDatabase(config.AggregatorDatabasePath)
-	if err != nil {
-		logger.Error("Cannot create database", "err", err)
-		return nil, err
-	}
-
 	rollupBroadcaster, err := NewRollupBroadcaster(ctx, avsReader, avsSubscriber, config.RollupsInfo, signerConfig, config.AggregatorAddress, logger)
 	if err != nil {
 		logger.Error("Cannot create rollup broadcaster", "err", err)
@@ -213,10 +269,23 @@ func NewAggregator(
 		return nil, err
 	}
 
+	rollupHeadTracker := NewRollupHeadTracker(ctx, config.RollupsInfo, logger)
+
 	avsRegistryService := avsregistry.NewAvsRegistryServiceChainCaller(avsReader, operatorRegistrationsService, logger)
-	taskBlsAggregationService := blsagg.NewMessageBlsAggregatorService(avsRegistryService, ethHttpClient, logger)
-	stateRootUpdateBlsAggregationService := blsagg.NewMessageBlsAggregatorService(avsRegistryService, ethHttpClient, logger)
-	operatorSetUpdateBlsAggregationService := blsagg.NewMessageBlsAggregatorService(avsRegistryService, ethHttpClient, logger)
+	operatorStateCache := blsagg.NewOperatorStateCache(avsRegistryService, logger)
+	aggregationLimits := blsagg.AggregationLimits{
+		MaxActiveMessages:    config.AggregatorMaxActiveMessages,
+		SignedMessageWorkers: config.AggregatorSignedMessageWorkers,
+		ResponseShards:       config.AggregatorResponseShards,
+	}
+	taskBlsAggregationService := blsagg.NewMessageBlsAggregatorService(messages.CheckpointTaskResponseMessageType, operatorStateCache, ethHttpClient, logger).
+		WithAggregationLimits(aggregationLimits)
+	stateRootUpdateBlsAggregationService := blsagg.NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, operatorStateCache, ethHttpClient, logger).
+		WithAggregationLimits(aggregationLimits).
+		WithLateSignatureGracePeriod(types.MESSAGE_LATE_SIGNATURE_GRACE_PERIOD)
+	operatorSetUpdateBlsAggregationService := blsagg.NewMessageBlsAggregatorService(messages.OperatorSetUpdateMessageType, operatorStateCache, ethHttpClient, logger).
+		WithAggregationLimits(aggregationLimits).
+		WithLateSignatureGracePeriod(types.MESSAGE_LATE_SIGNATURE_GRACE_PERIOD)
 
 	agg := &Aggregator{
 		config:                                 config,
@@ -224,17 +293,24 @@ func NewAggregator(
 		serverIpPortAddr:                       config.AggregatorServerIpPortAddr,
 		restServerIpPortAddr:                   config.AggregatorRestServerIpPortAddr,
 		checkpointInterval:                     config.AggregatorCheckpointInterval,
+		checkpointSchedulingPolicy:             NewCheckpointSchedulingPolicyFromConfig(config),
 		avsWriter:                              avsWriter,
 		avsReader:                              avsReader,
 		rollupBroadcaster:                      rollupBroadcaster,
 		httpClient:                             ethHttpClient,
 		wsClient:                               ethWsClient,
 		operatorRegistrationsService:           operatorRegistrationsService,
+		rollupHeadTracker:                      rollupHeadTracker,
+		rateLimiter:                            newOperatorRateLimiter(OPERATOR_RATE_LIMIT, OPERATOR_RATE_BURST),
+		operatorStateCache:                     operatorStateCache,
+		signatureVerifier:                      blsagg.NewSignatureVerifier(ctx, logger),
 		clock:                                  core.SystemClock,
 		taskBlsAggregationService:              taskBlsAggregationService,
 		stateRootUpdateBlsAggregationService:   stateRootUpdateBlsAggregationService,
 		operatorSetUpdateBlsAggregationService: operatorSetUpdateBlsAggregationService,
 		msgDb:                                  msgDb,
+		participationTracker:                   NewParticipationTracker(),
+		checkpointTaskTracker:                  NewCheckpointTaskTracker(),
 		tasks:                                  make(map[coretypes.TaskIndex]taskmanager.CheckpointTask),
 		aggregatorListener:                     &SelectiveAggregatorListener{},
 	}
@@ -251,6 +327,8 @@ func NewAggregator(
 
 	agg.aggregatorListener.IncAggregatorInitializations()
 
+	go agg.invalidateOperatorStateOnUpdate(ctx, avsSubscriber)
+
 	return agg, nil
 }
 
@@ -265,6 +343,19 @@ func (agg *Aggregator) EnableMetrics(registry *prometheus.Registry) error {
 		return err
 	}
 
+	if err = agg.operatorStateCache.EnableMetrics(registry); err != nil {
+		return err
+	}
+
+	blsAggListener, err := blsagg.MakeBlsAggMetrics(registry)
+	if err != nil {
+		return err
+	}
+	agg.signatureVerifier.SetListener(blsAggListener)
+	agg.taskBlsAggregationService.SetListener(blsAggListener)
+	agg.stateRootUpdateBlsAggregationService.SetListener(blsAggListener)
+	agg.operatorSetUpdateBlsAggregationService.SetListener(blsAggListener)
+
 	return nil
 }
 
@@ -279,24 +370,43 @@ func (agg *Aggregator) Start(ctx context.Context) error {
 	agg.logger.Info("Aggregator set to send new task", "interval", agg.checkpointInterval.String())
 	defer ticker.Stop()
 
+	agg.startResponseConsumers(ctx, "taskBlsAggregationService", agg.taskBlsAggregationService, func(blsAggServiceResp blsagg.MessageBlsAggregationServiceResponse) {
+		if blsAggServiceResp.Finished {
+			agg.recordParticipation(messages.CheckpointTaskResponseMessageType, 0, blsAggServiceResp)
+			go agg.sendAggregatedResponseToContract(blsAggServiceResp)
+		}
+	})
+	agg.startResponseConsumers(ctx, "stateRootUpdateBlsAggregationService", agg.stateRootUpdateBlsAggregationService, agg.handleStateRootUpdateReachedQuorum)
+	agg.startResponseConsumers(ctx, "operatorSetUpdateBlsAggregationService", agg.operatorSetUpdateBlsAggregationService, func(blsAggServiceResp blsagg.MessageBlsAggregationServiceResponse) {
+		agg.handleOperatorSetUpdateReachedQuorum(ctx, blsAggServiceResp)
+	})
+
+	go agg.reconcileCheckpointTasksLoop(ctx)
+	go agg.pruneOperatorParticipationsLoop(ctx)
+
 	broadcasterErrorChan := agg.rollupBroadcaster.GetErrorChan()
 	for {
 		select {
 		case <-ctx.Done():
 			return agg.Close()
-		case blsAggServiceResp := <-agg.taskBlsAggregationService.GetResponseChannel():
-			agg.logger.Info("Received response from taskBlsAggregationService", "blsAggServiceResp", blsAggServiceResp)
-			if blsAggServiceResp.Finished {
-				go agg.sendAggregatedResponseToContract(blsAggServiceResp)
-			}
-		case blsAggServiceResp := <-agg.stateRootUpdateBlsAggregationService.GetResponseChannel():
-			agg.logger.Info("Received response from stateRootUpdateBlsAggregationService", "blsAggServiceResp", blsAggServiceResp)
-			agg.handleStateRootUpdateReachedQuorum(blsAggServiceResp)
-		case blsAggServiceResp := <-agg.operatorSetUpdateBlsAggregationService.GetResponseChannel():
-			agg.logger.Info("Received response from operatorSetUpdateBlsAggregationService", "blsAggServiceResp", blsAggServiceResp)
-			agg.handleOperatorSetUpdateReachedQuorum(ctx, blsAggServiceResp)
+		case evidence := <-agg.taskBlsAggregationService.GetEquivocationChannel():
+			agg.handleEquivocationEvidence(evidence)
+		case evidence := <-agg.stateRootUpdateBlsAggregationService.GetEquivocationChannel():
+			agg.handleEquivocationEvidence(evidence)
+		case evidence := <-agg.operatorSetUpdateBlsAggregationService.GetEquivocationChannel():
+			agg.handleEquivocationEvidence(evidence)
 		case <-ticker.C:
-			go agg.sendNewCheckpointTask()
+			if agg.checkpointTasksPaused.Load() {
+				agg.logger.Info("Checkpoint tasks paused, skipping")
+				continue
+			}
+
+			go func() {
+				if err := agg.scheduleCheckpointTask(); err != nil {
+					agg.logger.Error("Failed to schedule checkpoint task", "err", err)
+					agg.aggregatorListener.IncCheckpointDecisions(false, CHECKPOINT_DECISION_ERROR)
+				}
+			}()
 		case err := <-broadcasterErrorChan:
 			// TODO: proper error handling in all class
 			agg.logger.Error("Received error from broadcaster", "err", err)
@@ -304,6 +414,89 @@ func (agg *Aggregator) Start(ctx context.Context) error {
 	}
 }
 
+// Handles each response shard of a BLS aggregation service in its own
+// goroutine, so a slow handler only holds back the messages in its shard
+func (agg *Aggregator) startResponseConsumers(
+	ctx context.Context,
+	serviceName string,
+	service blsagg.MessageBlsAggregationService,
+	handleResponse func(blsagg.MessageBlsAggregationServiceResponse),
+) {
+	for _, responseC := range service.GetResponseChannels() {
+		go func(responseC <-chan blsagg.MessageBlsAggregationServiceResponse) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case blsAggServiceResp := <-responseC:
+					agg.logger.Info("Received response from "+serviceName, "blsAggServiceResp", blsAggServiceResp)
+					handleResponse(blsAggServiceResp)
+				}
+			}
+		}(responseC)
+	}
+}
+
+// Drops the cached operator state snapshots from each operator set update's
+// block on, reorged updates included. Resubscribes with backoff on failure,
+// then invalidates past the last seen update, as some may have been missed.
+func (agg *Aggregator) invalidateOperatorStateOnUpdate(ctx context.Context, avsSubscriber chainio.AvsSubscriberer) {
+	backoff := OPERATOR_SET_UPDATE_RESUBSCRIBE_MIN_BACKOFF
+	var lastEventBlock uint64
+	resubscribing := false
+
+	for {
+		operatorSetUpdatedChan := make(chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock)
+
+		operatorSetUpdateSub, err := avsSubscriber.SubscribeToOperatorSetUpdates(safeclient.WithCursorName(ctx, "operator_state_cache_operator_set_updates"), operatorSetUpdatedChan)
+		if err == nil {
+			if resubscribing {
+				agg.logger.Info("Resubscribed to operator set updates, invalidating operator state cache", "fromBlock", lastEventBlock+1)
+				agg.operatorStateCache.InvalidateFromBlock(uint32(lastEventBlock + 1))
+			}
+
+			subscribedAt := time.Now()
+			err = agg.watchOperatorSetUpdates(ctx, operatorSetUpdateSub, operatorSetUpdatedChan, &lastEventBlock)
+			operatorSetUpdateSub.Unsubscribe()
+
+			// Only a subscription that held up resets the backoff
+			if time.Since(subscribedAt) > OPERATOR_SET_UPDATE_RESUBSCRIBE_MAX_BACKOFF {
+				backoff = OPERATOR_SET_UPDATE_RESUBSCRIBE_MIN_BACKOFF
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		agg.logger.Error("Operator set update subscription error, resubscribing", "err", err, "backoff", backoff)
+		resubscribing = true
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, OPERATOR_SET_UPDATE_RESUBSCRIBE_MAX_BACKOFF)
+	}
+}
+
+// Returns once the subscription fails or the context is done
+func (agg *Aggregator) watchOperatorSetUpdates(ctx context.Context, operatorSetUpdateSub event.Subscription, operatorSetUpdatedChan chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock, lastEventBlock *uint64) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-operatorSetUpdateSub.Err():
+			return err
+		case update := <-operatorSetUpdatedChan:
+			agg.logger.Info("Invalidating operator state cache", "id", update.Id, "block", update.Raw.BlockNumber, "removed", update.Raw.Removed)
+			agg.operatorStateCache.InvalidateFromBlock(uint32(update.Raw.BlockNumber))
+			*lastEventBlock = max(*lastEventBlock, update.Raw.BlockNumber)
+		}
+	}
+}
+
 func (agg *Aggregator) Close() error {
 	if err := agg.msgDb.Close(); err != nil {
 		return err
@@ -360,6 +553,8 @@ func (agg *Aggregator) sendAggregatedResponseToContract(blsAggServiceResp blsagg
 		time.Sleep(20 * time.Second)
 	}
 
+	agg.checkpointTaskTracker.RecordSubmission(taskResponse, blsAggServiceResp.MessageBlsAggregation, agg.clock.Now())
+
 	_, err = agg.avsWriter.SendAggregatedResponse(context.Background(), task, taskResponse, blsAggServiceResp.MessageBlsAggregation)
 	if err != nil {
 		agg.logger.Error("Aggregator failed to respond to task", "err", err)
@@ -369,37 +564,64 @@ func (agg *Aggregator) sendAggregatedResponseToContract(blsAggServiceResp blsagg
 
 // sendNewCheckpointTask sends a new task to the task manager contract, and updates the Task dict struct
 // with the information of operators opted into quorum 0 at the block of task creation.
-func (agg *Aggregator) sendNewCheckpointTask() {
-	blockNumber, err := agg.httpClient.BlockNumber(context.Background())
+// Sends a new checkpoint task regardless of the scheduling policy
+func (agg *Aggregator) sendNewCheckpointTask() error {
+	fromTimestamp, toTimestamp, err := agg.getNextCheckpointRange(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return agg.sendCheckpointTask(fromTimestamp, toTimestamp)
+}
+
+// Returns the range following the last checkpoint up to the latest timestamp
+// whose messages are done being aggregated. Before the first checkpoint, the
+// range starts at the oldest pending message, so that ranges skipped by the
+// scheduling policy are still covered.
+func (agg *Aggregator) getNextCheckpointRange(ctx context.Context) (uint64, uint64, error) {
+	blockNumber, err := agg.httpClient.BlockNumber(ctx)
 	if err != nil {
 		agg.logger.Error("Failed to get block number", "err", err)
-		return
+		return 0, 0, err
 	}
 
-	block, err := agg.httpClient.BlockByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	block, err := agg.httpClient.BlockByNumber(ctx, big.NewInt(0).SetUint64(blockNumber))
 	if err != nil {
 		agg.logger.Error("Failed to get block", "err", err)
-		return
+		return 0, 0, err
 	}
 
-	lastCheckpointToTimestamp, err := agg.avsReader.GetLastCheckpointToTimestamp(context.Background())
+	lastCheckpointToTimestamp, err := agg.avsReader.GetLastCheckpointToTimestamp(ctx)
 	if err != nil {
 		agg.logger.Error("Failed to get last checkpoint toTimestamp", "err", err)
-		return
+		return 0, 0, err
 	}
 
 	toTimestamp := block.Time() - uint64(types.MESSAGE_SUBMISSION_TIMEOUT.Seconds()) - uint64(types.MESSAGE_BLS_AGGREGATION_TIMEOUT.Seconds())
 	fromTimestamp := lastCheckpointToTimestamp + 1
 	if lastCheckpointToTimestamp == 0 {
+		oldestTimestamp, ok, err := agg.msgDb.FetchOldestCheckpointMessageTimestamp(0, toTimestamp)
+		if err != nil {
+			agg.logger.Error("Failed to fetch oldest pending checkpoint message", "err", err)
+			return 0, 0, err
+		}
+
 		fromTimestamp = toTimestamp - uint64(agg.checkpointInterval.Seconds())
+		if ok {
+			fromTimestamp = oldestTimestamp
+		}
 	}
 
+	return fromTimestamp, toTimestamp, nil
+}
+
+func (agg *Aggregator) sendCheckpointTask(fromTimestamp, toTimestamp uint64) error {
 	agg.logger.Info("Aggregator sending new task", "fromTimestamp", fromTimestamp, "toTimestamp", toTimestamp)
 	// Send checkpoint to the task manager contract
 	newTask, taskIndex, err := agg.avsWriter.SendNewCheckpointTask(context.Background(), fromTimestamp, toTimestamp, types.TASK_QUORUM_THRESHOLD, coretypes.QUORUM_NUMBERS)
 	if err != nil {
 		agg.logger.Error("Aggregator failed to send checkpoint", "err", err)
-		return
+		return err
 	}
 
 	agg.aggregatorListener.ObserveLastCheckpointReferenceSent(taskIndex)
@@ -408,6 +630,8 @@ func (agg *Aggregator) sendNewCheckpointTask() {
 	agg.tasks[taskIndex] = newTask
 	agg.tasksLock.Unlock()
 
+	agg.checkpointTaskTracker.Track(taskIndex, newTask, agg.clock.Now())
+
 	quorumThresholds := make([]eigentypes.QuorumThresholdPercentage, len(newTask.QuorumNumbers))
 	for i, _ := range newTask.QuorumNumbers {
 		quorumThresholds[i] = types.TASK_AGGREGATION_QUORUM_THRESHOLD
@@ -424,8 +648,10 @@ func (agg *Aggregator) sendNewCheckpointTask() {
 	)
 	if err != nil {
 		agg.logger.Error("Failed to initialize new task", "err", err)
-		return
+		return err
 	}
+
+	return nil
 }
 
 func (agg *Aggregator) handleStateRootUpdateReachedQuorum(blsAggServiceResp blsagg.MessageBlsAggregationServiceResponse) {
@@ -433,6 +659,12 @@ func (agg *Aggregator) handleStateRootUpdateReachedQuorum(blsAggServiceResp blsa
 		agg.aggregatorListener.IncErroredSubmissions()
 		if errors.Is(blsAggServiceResp.Err, blsagg.MessageExpiredError) {
 			agg.aggregatorListener.IncExpiredMessages()
+
+			var rollupId uint32
+			if msg, ok := blsAggServiceResp.Message.(messages.StateRootUpdateMessage); ok {
+				rollupId = msg.RollupId
+			}
+			agg.recordParticipation(messages.StateRootUpdateMessageType, rollupId, blsAggServiceResp)
 		}
 
 		agg.logger.Error("Aggregator BLS service returned error", "err", blsAggServiceResp.Err)
@@ -450,7 +682,15 @@ func (agg *Aggregator) handleStateRootUpdateReachedQuorum(blsAggServiceResp blsa
 		return
 	}
 
-	agg.aggregatorListener.ObserveLastStateRootUpdateAggregated(msg.RollupId, msg.BlockHeight)
+	if blsAggServiceResp.Late {
+		agg.handleLateSignature(blsAggServiceResp)
+	} else {
+		agg.aggregatorListener.ObserveLastStateRootUpdateAggregated(msg.RollupId, msg.BlockHeight)
+	}
+
+	if blsAggServiceResp.Finished {
+		agg.recordParticipation(messages.StateRootUpdateMessageType, msg.RollupId, blsAggServiceResp)
+	}
 
 	agg.logger.Info("Storing state root update", "digest", blsAggServiceResp.MessageDigest, "status", blsAggServiceResp.Status)
 
@@ -471,6 +711,7 @@ func (agg *Aggregator) handleOperatorSetUpdateReachedQuorum(ctx context.Context,
 		agg.aggregatorListener.IncErroredSubmissions()
 		if errors.Is(blsAggServiceResp.Err, blsagg.MessageExpiredError) {
 			agg.aggregatorListener.IncExpiredMessages()
+			agg.recordParticipation(messages.OperatorSetUpdateMessageType, 0, blsAggServiceResp)
 		}
 
 		agg.logger.Error("Aggregator BLS service returned error", "err", blsAggServiceResp.Err)
@@ -488,14 +729,23 @@ func (agg *Aggregator) handleOperatorSetUpdateReachedQuorum(ctx context.Context,
 		return
 	}
 
-	if blsAggServiceResp.Finished {
-		defer func() {
-			signatureInfo := blsAggServiceResp.ExtractBindingRollup()
-			agg.rollupBroadcaster.BroadcastOperatorSetUpdate(ctx, msg, signatureInfo)
-		}()
+	// Late aggregations are only stored, as the update was already broadcast
+	if blsAggServiceResp.Late {
+		agg.handleLateSignature(blsAggServiceResp)
+	} else {
+		if blsAggServiceResp.Finished {
+			defer func() {
+				signatureInfo := blsAggServiceResp.ExtractBindingRollup()
+				agg.rollupBroadcaster.BroadcastOperatorSetUpdate(ctx, msg, signatureInfo)
+			}()
+		}
+
+		agg.aggregatorListener.ObserveLastOperatorSetUpdateAggregated(msg.Id)
 	}
 
-	agg.aggregatorListener.ObserveLastOperatorSetUpdateAggregated(msg.Id)
+	if blsAggServiceResp.Finished {
+		agg.recordParticipation(messages.OperatorSetUpdateMessageType, 0, blsAggServiceResp)
+	}
 
 	agg.logger.Info("Storing operator set update", "digest", blsAggServiceResp.MessageDigest, "status", blsAggServiceResp.Status)
 
@@ -511,8 +761,86 @@ func (agg *Aggregator) handleOperatorSetUpdateReachedQuorum(ctx context.Context,
 	}
 }
 
-func (agg *Aggregator) ProcessSignedCheckpointTaskResponse(signedCheckpointTaskResponse *messages.SignedCheckpointTaskResponse) error {
-	err := agg.verifySignature(signedCheckpointTaskResponse)
+// Records which operators signed a finished message and updates their
+// liveness scores. Late responses overwrite the previous records. Expired
+// messages are recorded as missed by the operators that didn't sign them,
+// while dropped ones aren't recorded.
+func (agg *Aggregator) recordParticipation(messageType messages.MessageType, rollupId uint32, blsAggServiceResp blsagg.MessageBlsAggregationServiceResponse) {
+	if blsAggServiceResp.Err != nil && !errors.Is(blsAggServiceResp.Err, blsagg.MessageExpiredError) {
+		return
+	}
+
+	participations := make([]messages.OperatorParticipation, 0, len(blsAggServiceResp.SignersOperatorIds)+len(blsAggServiceResp.NonSignersOperatorIds))
+	for _, operatorIds := range []struct {
+		ids    []eigentypes.OperatorId
+		signed bool
+	}{
+		{blsAggServiceResp.SignersOperatorIds, true},
+		{blsAggServiceResp.NonSignersOperatorIds, false},
+	} {
+		for _, operatorId := range operatorIds.ids {
+			participations = append(participations, messages.OperatorParticipation{
+				MessageKey:     blsAggServiceResp.MessageKey,
+				MessageType:    messageType,
+				RollupId:       rollupId,
+				OperatorId:     operatorId,
+				EthBlockNumber: blsAggServiceResp.EthBlockNumber,
+				Signed:         operatorIds.signed,
+			})
+		}
+	}
+
+	if len(participations) == 0 {
+		return
+	}
+
+	for operatorId, score := range agg.participationTracker.Record(participations) {
+		agg.aggregatorListener.ObserveOperatorLiveness(operatorId, score)
+	}
+
+	err := agg.msgDb.StoreOperatorParticipations(participations)
+	if err != nil {
+		agg.logger.Error("Aggregator could not store operator participations", "err", err)
+	}
+}
+
+// Credits the participation of an operator whose signature arrived after the
+// message was finished
+func (agg *Aggregator) handleLateSignature(blsAggServiceResp blsagg.MessageBlsAggregationServiceResponse) {
+	agg.aggregatorListener.IncLateSignatures(blsAggServiceResp.LateSignerOperatorId)
+
+	agg.logger.Info("Aggregated late signature",
+		"operatorId", blsAggServiceResp.LateSignerOperatorId,
+		"key", blsAggServiceResp.MessageKey,
+		"nonSigners", len(blsAggServiceResp.NonSignersPubkeysG1),
+	)
+}
+
+// Evidence is verified by the BLS aggregation service before being reported
+func (agg *Aggregator) handleEquivocationEvidence(evidence messages.EquivocationEvidence) {
+	agg.aggregatorListener.IncOperatorEquivocations(evidence.OperatorId)
+
+	agg.logger.Error("Operator equivocation detected",
+		"operatorId", evidence.OperatorId,
+		"key", evidence.MessageKey,
+		"firstDigest", evidence.FirstDigest,
+		"secondDigest", evidence.SecondDigest,
+	)
+
+	err := agg.msgDb.StoreEquivocationEvidence(evidence)
+	if err != nil {
+		agg.logger.Error("Aggregator could not store equivocation evidence", "err", err)
+		return
+	}
+}
+
+func (agg *Aggregator) ProcessSignedCheckpointTaskResponse(ctx context.Context, signedCheckpointTaskResponse *messages.SignedCheckpointTaskResponse) error {
+	err := agg.verifySignature(ctx, signedCheckpointTaskResponse)
+	if err != nil {
+		return err
+	}
+
+	err = agg.admitOperator(signedCheckpointTaskResponse.OperatorId)
 	if err != nil {
 		return err
 	}
@@ -520,7 +848,7 @@ func (agg *Aggregator) ProcessSignedCheckpointTaskResponse(signedCheckpointTaskR
 	agg.aggregatorListener.ObserveLastCheckpointTaskReferenceReceived(signedCheckpointTaskResponse.TaskResponse.ReferenceTaskIndex)
 
 	err = agg.taskBlsAggregationService.ProcessNewSignature(
-		context.Background(), signedCheckpointTaskResponse.TaskResponse,
+		ctx, signedCheckpointTaskResponse.TaskResponse,
 		&signedCheckpointTaskResponse.BlsSignature, signedCheckpointTaskResponse.OperatorId,
 	)
 	if err != nil {
@@ -531,7 +859,7 @@ func (agg *Aggregator) ProcessSignedCheckpointTaskResponse(signedCheckpointTaskR
 }
 
 // Rpc request handlers
-func (agg *Aggregator) ProcessSignedStateRootUpdateMessage(signedStateRootUpdateMessage *messages.SignedStateRootUpdateMessage) error {
+func (agg *Aggregator) ProcessSignedStateRootUpdateMessage(ctx context.Context, signedStateRootUpdateMessage *messages.SignedStateRootUpdateMessage) error {
 	timestamp := signedStateRootUpdateMessage.Message.Timestamp
 	err := agg.validateMessageTimestamp(timestamp)
 	if err != nil {
@@ -539,7 +867,12 @@ func (agg *Aggregator) ProcessSignedStateRootUpdateMessage(signedStateRootUpdate
 		return err
 	}
 
-	err = agg.verifySignature(signedStateRootUpdateMessage)
+	err = agg.verifySignature(ctx, signedStateRootUpdateMessage)
+	if err != nil {
+		return err
+	}
+
+	err = agg.admitOperator(signedStateRootUpdateMessage.OperatorId)
 	if err != nil {
 		return err
 	}
@@ -559,13 +892,13 @@ func (agg *Aggregator) ProcessSignedStateRootUpdateMessage(signedStateRootUpdate
 	}
 
 	err = agg.stateRootUpdateBlsAggregationService.ProcessNewSignature(
-		context.Background(), signedStateRootUpdateMessage.Message,
+		ctx, signedStateRootUpdateMessage.Message,
 		&signedStateRootUpdateMessage.BlsSignature, signedStateRootUpdateMessage.OperatorId,
 	)
 	return err
 }
 
-func (agg *Aggregator) ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUpdateMessage *messages.SignedOperatorSetUpdateMessage) error {
+func (agg *Aggregator) ProcessSignedOperatorSetUpdateMessage(ctx context.Context, signedOperatorSetUpdateMessage *messages.SignedOperatorSetUpdateMessage) error {
 	timestamp := signedOperatorSetUpdateMessage.Message.Timestamp
 	err := agg.validateMessageTimestamp(timestamp)
 	if err != nil {
@@ -573,12 +906,17 @@ func (agg *Aggregator) ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUp
 		return err
 	}
 
-	err = agg.verifySignature(signedOperatorSetUpdateMessage)
+	err = agg.verifySignature(ctx, signedOperatorSetUpdateMessage)
 	if err != nil {
 		return err
 	}
 
-	blockNumber, err := agg.avsReader.GetOperatorSetUpdateBlock(context.Background(), signedOperatorSetUpdateMessage.Message.Id)
+	err = agg.admitOperator(signedOperatorSetUpdateMessage.OperatorId)
+	if err != nil {
+		return err
+	}
+
+	blockNumber, err := agg.avsReader.GetOperatorSetUpdateBlock(ctx, signedOperatorSetUpdateMessage.Message.Id)
 	if err != nil {
 		agg.logger.Error("Failed to get operator set update block", "err", err)
 		return GetOperatorSetUpdateBlockError
@@ -599,7 +937,7 @@ func (agg *Aggregator) ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUp
 	}
 
 	err = agg.operatorSetUpdateBlsAggregationService.ProcessNewSignature(
-		context.Background(), signedOperatorSetUpdateMessage.Message,
+		ctx, signedOperatorSetUpdateMessage.Message,
 		&signedOperatorSetUpdateMessage.BlsSignature, signedOperatorSetUpdateMessage.OperatorId,
 	)
 
@@ -666,12 +1004,138 @@ func (agg *Aggregator) GetCheckpointMessages(fromTimestamp, toTimestamp uint64)
 	}, nil
 }
 
+func (agg *Aggregator) GetEquivocationEvidence(operatorId *eigentypes.OperatorId) (*types.GetEquivocationEvidenceResponse, error) {
+	evidence, err := agg.msgDb.FetchEquivocationEvidence(operatorId)
+	if err != nil {
+		return nil, EquivocationEvidenceError
+	}
+
+	return &types.GetEquivocationEvidenceResponse{
+		Evidence: evidence,
+	}, nil
+}
+
+func (agg *Aggregator) GetOperatorMissedMessages(operatorId eigentypes.OperatorId, cursor uint64, limit int) (*types.GetOperatorMissedMessagesResponse, error) {
+	missedMessages, nextCursor, err := agg.msgDb.FetchMissedMessages(operatorId, cursor, limit)
+	if err != nil {
+		return nil, OperatorParticipationError
+	}
+
+	livenessScore, _ := agg.participationTracker.LivenessScore(operatorId)
+
+	return &types.GetOperatorMissedMessagesResponse{
+		LivenessScore:  livenessScore,
+		MissedMessages: missedMessages,
+		NextCursor:     nextCursor,
+	}, nil
+}
+
 func (agg *Aggregator) GetOperatorInfoById(ctx context.Context, operatorId eigentypes.OperatorId) (eigentypes.OperatorInfo, bool) {
 	operatorInfo, ok := agg.operatorRegistrationsService.GetOperatorInfoById(ctx, operatorId)
 	return operatorInfo, ok
 }
 
-func (agg *Aggregator) verifySignature(signedMessage interface{}) error {
+func (agg *Aggregator) GetRollupHead(rollupId uint32) (uint64, error) {
+	return agg.rollupHeadTracker.GetRollupHead(rollupId)
+}
+
+// Admin request handlers
+func (agg *Aggregator) PauseCheckpointTasks() {
+	agg.logger.Info("Pausing checkpoint tasks")
+	agg.checkpointTasksPaused.Store(true)
+}
+
+func (agg *Aggregator) ResumeCheckpointTasks() {
+	agg.logger.Info("Resuming checkpoint tasks")
+	agg.checkpointTasksPaused.Store(false)
+}
+
+func (agg *Aggregator) CheckpointTasksPaused() bool {
+	return agg.checkpointTasksPaused.Load()
+}
+
+// Sends a checkpoint task right away, even if checkpoint tasks are paused
+func (agg *Aggregator) TriggerCheckpointTask() error {
+	agg.logger.Info("Triggering checkpoint task")
+	return agg.sendNewCheckpointTask()
+}
+
+func (agg *Aggregator) ResyncRollupOperatorSets(ctx context.Context) error {
+	return agg.rollupBroadcaster.ResyncOperatorSets(ctx, agg.fetchOperatorSetUpdate)
+}
+
+func (agg *Aggregator) GetInFlightAggregations() []blsagg.ActiveMessage {
+	var aggregations []blsagg.ActiveMessage
+	aggregations = append(aggregations, agg.taskBlsAggregationService.GetActiveMessages()...)
+	aggregations = append(aggregations, agg.stateRootUpdateBlsAggregationService.GetActiveMessages()...)
+	aggregations = append(aggregations, agg.operatorSetUpdateBlsAggregationService.GetActiveMessages()...)
+
+	return aggregations
+}
+
+func (agg *Aggregator) DropMessage(messageType messages.MessageType, messageKey coretypes.MessageKey) error {
+	var blsAggregationService blsagg.MessageBlsAggregationService
+	switch messageType {
+	case messages.CheckpointTaskResponseMessageType:
+		blsAggregationService = agg.taskBlsAggregationService
+	case messages.StateRootUpdateMessageType:
+		blsAggregationService = agg.stateRootUpdateBlsAggregationService
+	case messages.OperatorSetUpdateMessageType:
+		blsAggregationService = agg.operatorSetUpdateBlsAggregationService
+	default:
+		return UnsupportedMessageTypeError
+	}
+
+	err := blsAggregationService.DropMessage(messageKey)
+	if err != nil {
+		agg.logger.Warn("Failed to drop message", "messageType", messageType, "key", messageKey, "err", err)
+		return MessageNotFoundError
+	}
+
+	return nil
+}
+
+func (agg *Aggregator) StoreAuditLogEntry(entry types.AuditLogEntry) error {
+	agg.logger.Info("Admin action", "action", entry.Action, "params", entry.Params, "remoteAddr", entry.RemoteAddr, "error", entry.Error)
+	return agg.msgDb.StoreAuditLogEntry(entry)
+}
+
+func (agg *Aggregator) GetAuditLog(limit int) ([]types.AuditLogEntry, error) {
+	entries, err := agg.msgDb.FetchAuditLogEntries(limit)
+	if err != nil {
+		agg.logger.Error("Failed to fetch audit log", "err", err)
+		return nil, AuditLogError
+	}
+
+	return entries, nil
+}
+
+func (agg *Aggregator) fetchOperatorSetUpdate(ctx context.Context, id uint64) (messages.OperatorSetUpdateMessage, registryrollup.RollupOperatorsSignatureInfo, error) {
+	message, err := agg.msgDb.FetchOperatorSetUpdate(id)
+	if err != nil {
+		return messages.OperatorSetUpdateMessage{}, registryrollup.RollupOperatorsSignatureInfo{}, OperatorSetNotFoundError
+	}
+
+	aggregation, err := agg.msgDb.FetchOperatorSetUpdateAggregation(id)
+	if err != nil {
+		return messages.OperatorSetUpdateMessage{}, registryrollup.RollupOperatorsSignatureInfo{}, OperatorAggNotFoundError
+	}
+
+	return *message, aggregation.ExtractBindingRollup(), nil
+}
+
+// Charges an operator whose signature was verified for a message, so that
+// concurrent requests can't take more tokens than its bucket holds
+func (agg *Aggregator) admitOperator(operatorId eigentypes.OperatorId) error {
+	if !agg.rateLimiter.Allow(operatorId) {
+		agg.logger.Debug("Operator rate limited", "operatorId", operatorId)
+		return RateLimitedError
+	}
+
+	return nil
+}
+
+func (agg *Aggregator) verifySignature(ctx context.Context, signedMessage interface{}) error {
 	var operatorId eigentypes.OperatorId
 	var signature bls.Signature
 	var digest [32]byte
@@ -703,12 +1167,12 @@ func (agg *Aggregator) verifySignature(signedMessage interface{}) error {
 		return UnsupportedMessageTypeError
 	}
 
-	operatorInfo, ok := agg.GetOperatorInfoById(context.Background(), operatorId)
+	operatorInfo, ok := agg.GetOperatorInfoById(ctx, operatorId)
 	if !ok {
 		return OperatorNotFoundError
 	}
 
-	ok, err = signature.Verify(operatorInfo.Pubkeys.G2Pubkey, digest)
+	ok, err = agg.signatureVerifier.Verify(ctx, operatorInfo.Pubkeys.G2Pubkey, digest, &signature)
 	if err != nil {
 		return InvalidSignatureError
 	}
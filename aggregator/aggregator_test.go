@@ -2,6 +2,8 @@ package aggregator
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -14,15 +16,19 @@ import (
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/Nuffle-Labs/nffl/aggregator/blsagg"
 	dbmocks "github.com/Nuffle-Labs/nffl/aggregator/database/mocks"
 	"github.com/Nuffle-Labs/nffl/aggregator/database/models"
 	aggmocks "github.com/Nuffle-Labs/nffl/aggregator/mocks"
 	"github.com/Nuffle-Labs/nffl/aggregator/types"
+	opsetupdatereg "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLOperatorSetUpdateRegistry"
 	taskmanager "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLTaskManager"
 	"github.com/Nuffle-Labs/nffl/core"
+	"github.com/Nuffle-Labs/nffl/core/chainio"
 	chainiomocks "github.com/Nuffle-Labs/nffl/core/chainio/mocks"
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
 	safeclientmocks "github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
 	coretypes "github.com/Nuffle-Labs/nffl/core/types"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
@@ -159,6 +165,259 @@ func TestHandleOperatorSetUpdateAggregationReachedQuorum(t *testing.T) {
 	aggregator.handleOperatorSetUpdateReachedQuorum(context.Background(), blsAggServiceResp)
 }
 
+func TestHandleEquivocationEvidence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, _, _, _, mockMsgDb, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	var equivocatingOperatorId eigentypes.OperatorId
+	aggregator.aggregatorListener = &SelectiveAggregatorListener{
+		IncOperatorEquivocationsCb: func(operatorId eigentypes.OperatorId) {
+			equivocatingOperatorId = operatorId
+		},
+	}
+
+	firstMsg := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{1}}
+	secondMsg := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{2}}
+	firstDigest, err := firstMsg.Digest()
+	assert.Nil(t, err)
+	secondDigest, err := secondMsg.Digest()
+	assert.Nil(t, err)
+
+	firstMsgJson, err := json.Marshal(firstMsg)
+	assert.Nil(t, err)
+	secondMsgJson, err := json.Marshal(secondMsg)
+	assert.Nil(t, err)
+
+	evidence := messages.EquivocationEvidence{
+		MessageType:     messages.StateRootUpdateMessageType,
+		MessageKey:      firstMsg.Key(),
+		OperatorId:      MOCK_OPERATOR_ID,
+		FirstMessage:    firstMsgJson,
+		FirstDigest:     firstDigest,
+		FirstSignature:  MOCK_OPERATOR_KEYPAIR.SignMessage(firstDigest),
+		SecondMessage:   secondMsgJson,
+		SecondDigest:    secondDigest,
+		SecondSignature: MOCK_OPERATOR_KEYPAIR.SignMessage(secondDigest),
+	}
+	assert.Nil(t, evidence.Verify(MOCK_OPERATOR_G2PUBKEY))
+
+	// Messages must match the signed digests
+	mismatchedEvidence := evidence
+	mismatchedEvidence.SecondMessage = firstMsgJson
+	assert.ErrorIs(t, mismatchedEvidence.Verify(MOCK_OPERATOR_G2PUBKEY), messages.EvidenceMessageMismatchError)
+
+	mockMsgDb.EXPECT().StoreEquivocationEvidence(evidence).Return(nil)
+
+	aggregator.handleEquivocationEvidence(evidence)
+	assert.Equal(t, eigentypes.OperatorId(MOCK_OPERATOR_ID), equivocatingOperatorId)
+}
+
+func TestInvalidateOperatorStateResubscribes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, _, _, _, _, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+	aggregator.operatorStateCache = blsagg.NewOperatorStateCache(nil, aggregator.logger)
+
+	mockAvsSubscriber := chainiomocks.NewMockAvsSubscriberer(mockCtrl)
+	resubscribed := make(chan struct{})
+
+	type updateChan = chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock
+	gomock.InOrder(
+		mockAvsSubscriber.EXPECT().SubscribeToOperatorSetUpdates(gomock.Any(), gomock.Any()).Return(nil, errors.New("dial error")),
+		// Fails after delivering an update
+		mockAvsSubscriber.EXPECT().SubscribeToOperatorSetUpdates(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updates updateChan) (event.Subscription, error) {
+			return event.NewSubscription(func(quit <-chan struct{}) error {
+				updates <- &opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock{Raw: gethtypes.Log{BlockNumber: 10}}
+				return errors.New("connection lost")
+			}), nil
+		}),
+		mockAvsSubscriber.EXPECT().SubscribeToOperatorSetUpdates(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updates updateChan) (event.Subscription, error) {
+			close(resubscribed)
+			return event.NewSubscription(func(quit <-chan struct{}) error {
+				<-quit
+				return nil
+			}), nil
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		aggregator.invalidateOperatorStateOnUpdate(ctx, mockAvsSubscriber)
+		close(done)
+	}()
+
+	select {
+	case <-resubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for resubscription")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestRecordParticipation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, _, _, _, mockMsgDb, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	livenessScores := make(map[eigentypes.OperatorId]float64)
+	aggregator.aggregatorListener = &SelectiveAggregatorListener{
+		ObserveOperatorLivenessCb: func(operatorId eigentypes.OperatorId, score float64) {
+			livenessScores[operatorId] = score
+		},
+	}
+
+	signerOperatorId := eigentypes.OperatorId{1}
+	nonSignerOperatorId := eigentypes.OperatorId{2}
+
+	msg := messages.StateRootUpdateMessage{RollupId: 3, BlockHeight: 4}
+	msgDigest, err := msg.Digest()
+	assert.Nil(t, err)
+
+	blsAggServiceResp := blsagg.MessageBlsAggregationServiceResponse{
+		MessageBlsAggregation: messages.MessageBlsAggregation{
+			MessageDigest:  msgDigest,
+			EthBlockNumber: 5,
+		},
+		Message:               msg,
+		MessageKey:            msg.Key(),
+		Finished:              true,
+		SignersOperatorIds:    []eigentypes.OperatorId{signerOperatorId},
+		NonSignersOperatorIds: []eigentypes.OperatorId{nonSignerOperatorId},
+	}
+
+	model := models.NewStateRootUpdateMessageModel(msg)
+	mockMsgDb.EXPECT().StoreStateRootUpdate(msg).Return(&model, nil)
+	mockMsgDb.EXPECT().StoreStateRootUpdateAggregation(&model, blsAggServiceResp.MessageBlsAggregation)
+	mockMsgDb.EXPECT().StoreOperatorParticipations([]messages.OperatorParticipation{
+		{
+			MessageKey:     msg.Key(),
+			MessageType:    messages.StateRootUpdateMessageType,
+			RollupId:       msg.RollupId,
+			OperatorId:     signerOperatorId,
+			EthBlockNumber: 5,
+			Signed:         true,
+		},
+		{
+			MessageKey:     msg.Key(),
+			MessageType:    messages.StateRootUpdateMessageType,
+			RollupId:       msg.RollupId,
+			OperatorId:     nonSignerOperatorId,
+			EthBlockNumber: 5,
+			Signed:         false,
+		},
+	})
+
+	aggregator.handleStateRootUpdateReachedQuorum(blsAggServiceResp)
+	assert.Equal(t, map[eigentypes.OperatorId]float64{signerOperatorId: 1, nonSignerOperatorId: 0}, livenessScores)
+
+	// Late signature from the non-signer
+	lateBlsAggServiceResp := blsAggServiceResp
+	lateBlsAggServiceResp.Late = true
+	lateBlsAggServiceResp.LateSignerOperatorId = nonSignerOperatorId
+	lateBlsAggServiceResp.SignersOperatorIds = []eigentypes.OperatorId{signerOperatorId, nonSignerOperatorId}
+	lateBlsAggServiceResp.NonSignersOperatorIds = nil
+
+	mockMsgDb.EXPECT().StoreStateRootUpdate(msg).Return(&model, nil)
+	mockMsgDb.EXPECT().StoreStateRootUpdateAggregation(&model, lateBlsAggServiceResp.MessageBlsAggregation)
+	mockMsgDb.EXPECT().StoreOperatorParticipations(gomock.Len(2))
+
+	aggregator.handleStateRootUpdateReachedQuorum(lateBlsAggServiceResp)
+	assert.Equal(t, float64(1), livenessScores[nonSignerOperatorId])
+}
+
+func TestRecordParticipationOfExpiredMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, _, _, _, mockMsgDb, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	signerOperatorId := eigentypes.OperatorId{1}
+	nonSignerOperatorId := eigentypes.OperatorId{2}
+
+	msg := messages.StateRootUpdateMessage{RollupId: 3, BlockHeight: 4}
+	blsAggServiceResp := blsagg.MessageBlsAggregationServiceResponse{
+		MessageBlsAggregation: messages.MessageBlsAggregation{EthBlockNumber: 5},
+		Message:               msg,
+		MessageKey:            msg.Key(),
+		Finished:              true,
+		Err:                   blsagg.MessageExpiredError,
+		SignersOperatorIds:    []eigentypes.OperatorId{signerOperatorId},
+		NonSignersOperatorIds: []eigentypes.OperatorId{nonSignerOperatorId},
+	}
+
+	mockMsgDb.EXPECT().StoreOperatorParticipations([]messages.OperatorParticipation{
+		{
+			MessageKey:     msg.Key(),
+			MessageType:    messages.StateRootUpdateMessageType,
+			RollupId:       msg.RollupId,
+			OperatorId:     signerOperatorId,
+			EthBlockNumber: 5,
+			Signed:         true,
+		},
+		{
+			MessageKey:     msg.Key(),
+			MessageType:    messages.StateRootUpdateMessageType,
+			RollupId:       msg.RollupId,
+			OperatorId:     nonSignerOperatorId,
+			EthBlockNumber: 5,
+			Signed:         false,
+		},
+	})
+
+	aggregator.handleStateRootUpdateReachedQuorum(blsAggServiceResp)
+
+	score, ok := aggregator.participationTracker.LivenessScore(nonSignerOperatorId)
+	assert.True(t, ok)
+	assert.Zero(t, score)
+
+	// Dropped messages aren't recorded
+	blsAggServiceResp.Err = blsagg.MessageDroppedError
+	aggregator.handleStateRootUpdateReachedQuorum(blsAggServiceResp)
+}
+
+func TestParticipationTrackerWindow(t *testing.T) {
+	tracker := NewParticipationTracker()
+	operatorId := eigentypes.OperatorId{1}
+
+	for i := 0; i < LIVENESS_WINDOW_SIZE; i++ {
+		tracker.Record([]messages.OperatorParticipation{{MessageKey: [32]byte{byte(i)}, OperatorId: operatorId, Signed: false}})
+	}
+	for i := 0; i < LIVENESS_WINDOW_SIZE/2; i++ {
+		tracker.Record([]messages.OperatorParticipation{{MessageKey: [32]byte{byte(i), 1}, OperatorId: operatorId, Signed: true}})
+	}
+
+	score, ok := tracker.LivenessScore(operatorId)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, score)
+
+	_, ok = tracker.LivenessScore(eigentypes.OperatorId{2})
+	assert.False(t, ok)
+}
+
+func TestParticipationTrackerKeyedByMessageType(t *testing.T) {
+	tracker := NewParticipationTracker()
+	operatorId := eigentypes.OperatorId{1}
+	messageKey := [32]byte{1}
+
+	tracker.Record([]messages.OperatorParticipation{{MessageKey: messageKey, MessageType: messages.StateRootUpdateMessageType, OperatorId: operatorId, Signed: true}})
+	tracker.Record([]messages.OperatorParticipation{{MessageKey: messageKey, MessageType: messages.OperatorSetUpdateMessageType, OperatorId: operatorId, Signed: false}})
+
+	score, ok := tracker.LivenessScore(operatorId)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, score)
+}
+
 func TestTimeoutStateRootUpdateMessage(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -170,7 +429,7 @@ func TestTimeoutStateRootUpdateMessage(t *testing.T) {
 	aggregator.clock = core.Clock{Now: func() time.Time { return time.Unix(int64(nowTimestamp), 0) }}
 	messageTimestamp := nowTimestamp - 60 - 1 // 60 seconds for message submission timeout and 1 second to be out of range
 
-	err = aggregator.ProcessSignedStateRootUpdateMessage(&messages.SignedStateRootUpdateMessage{
+	err = aggregator.ProcessSignedStateRootUpdateMessage(context.Background(), &messages.SignedStateRootUpdateMessage{
 		Message: messages.StateRootUpdateMessage{
 			Timestamp: messageTimestamp,
 		},
@@ -190,7 +449,7 @@ func TestTimeoutOperatorSetUpdate(t *testing.T) {
 	aggregator.clock = core.Clock{Now: func() time.Time { return time.Unix(int64(nowTimestamp), 0) }}
 	messageTimestamp := nowTimestamp - 60 - 1 // 60 seconds for message submission timeout and 1 second to be out of range
 
-	err = aggregator.ProcessSignedOperatorSetUpdateMessage(&messages.SignedOperatorSetUpdateMessage{
+	err = aggregator.ProcessSignedOperatorSetUpdateMessage(context.Background(), &messages.SignedOperatorSetUpdateMessage{
 		Message: messages.OperatorSetUpdateMessage{
 			Timestamp: messageTimestamp,
 		},
@@ -199,6 +458,339 @@ func TestTimeoutOperatorSetUpdate(t *testing.T) {
 	assert.Equal(t, MessageTimeoutError, err)
 }
 
+func TestDropMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, mockStateRootUpdateBlsAggService, _, _, _, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	messageKey := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}.Key()
+	mockStateRootUpdateBlsAggService.EXPECT().DropMessage(messageKey).Return(nil)
+
+	err = aggregator.DropMessage(messages.StateRootUpdateMessageType, messageKey)
+	assert.Nil(t, err)
+
+	mockStateRootUpdateBlsAggService.EXPECT().DropMessage(messageKey).Return(blsagg.MessageNotFoundErrorFn(messageKey))
+
+	err = aggregator.DropMessage(messages.StateRootUpdateMessageType, messageKey)
+	assert.Equal(t, MessageNotFoundError, err)
+
+	err = aggregator.DropMessage(messages.MessageType("unknown"), messageKey)
+	assert.Equal(t, UnsupportedMessageTypeError, err)
+}
+
+func TestFetchOperatorSetUpdate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, _, _, _, mockMsgDb, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	msg := messages.OperatorSetUpdateMessage{Id: 1, Timestamp: 2}
+	aggregation := messages.MessageBlsAggregation{
+		NonSignersPubkeysG1: []*bls.G1Point{},
+		QuorumApksG1:        []*bls.G1Point{bls.NewG1Point(big.NewInt(3), big.NewInt(4))},
+		SignersApkG2:        bls.NewZeroG2Point(),
+		SignersAggSigG1:     bls.NewZeroSignature(),
+	}
+
+	mockMsgDb.EXPECT().FetchOperatorSetUpdate(msg.Id).Return(&msg, nil)
+	mockMsgDb.EXPECT().FetchOperatorSetUpdateAggregation(msg.Id).Return(&aggregation, nil)
+
+	fetchedMsg, signatureInfo, err := aggregator.fetchOperatorSetUpdate(context.Background(), msg.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, msg, fetchedMsg)
+	assert.Equal(t, aggregation.ExtractBindingRollup(), signatureInfo)
+
+	mockMsgDb.EXPECT().FetchOperatorSetUpdate(uint64(2)).Return(nil, errors.New("record not found"))
+
+	_, _, err = aggregator.fetchOperatorSetUpdate(context.Background(), 2)
+	assert.Equal(t, OperatorSetNotFoundError, err)
+}
+
+func TestReconcileCheckpointTasks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, mockAvsReader, mockAvsWriter, _, _, _, _, _, _, mockClient, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	now := time.Unix(10_000, 0)
+	aggregator.clock = core.Clock{Now: func() time.Time { return now }}
+
+	var gaps []float64
+	resubmissions, unanswered := 0, 0
+	aggregator.aggregatorListener = &SelectiveAggregatorListener{
+		ObserveCheckpointTaskResponseGapCb: func(seconds float64) { gaps = append(gaps, seconds) },
+		IncCheckpointTaskResubmissionsCb:   func() { resubmissions++ },
+		IncUnansweredCheckpointTasksCb:     func() { unanswered++ },
+	}
+
+	respondedTask := taskmanager.CheckpointTask{TaskCreatedBlock: 90}
+	droppedTask := taskmanager.CheckpointTask{TaskCreatedBlock: 95}
+	expiredTask := taskmanager.CheckpointTask{TaskCreatedBlock: 10}
+	aggregator.checkpointTaskTracker.Track(1, respondedTask, now.Add(-time.Minute))
+	aggregator.checkpointTaskTracker.Track(2, droppedTask, now.Add(-5*time.Minute))
+	aggregator.checkpointTaskTracker.Track(3, expiredTask, now.Add(-30*time.Minute))
+
+	droppedResponse := messages.CheckpointTaskResponse{ReferenceTaskIndex: 2}
+	aggregator.checkpointTaskTracker.RecordSubmission(droppedResponse, messages.MessageBlsAggregation{}, now.Add(-CHECKPOINT_TASK_RESUBMISSION_DELAY))
+
+	taskHash := [32]byte{1}
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(100), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseWindowBlock(gomock.Any()).Return(uint32(30), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskHash(gomock.Any(), gomock.Any()).Return(taskHash, nil).Times(3)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), uint32(1)).Return([32]byte{2}, nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), uint32(2)).Return([32]byte{}, nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), uint32(3)).Return([32]byte{}, nil)
+	mockAvsWriter.EXPECT().SendAggregatedResponse(gomock.Any(), droppedTask, droppedResponse, messages.MessageBlsAggregation{})
+
+	aggregator.reconcileCheckpointTasks(context.Background())
+
+	assert.Equal(t, []float64{60}, gaps)
+	assert.Equal(t, 1, resubmissions)
+	assert.Equal(t, 1, unanswered)
+
+	pending := aggregator.checkpointTaskTracker.Pending()
+	assert.Len(t, pending, 1)
+	assert.Equal(t, uint32(2), pending[0].TaskIndex)
+	assert.Equal(t, now, pending[0].LastSubmittedAt)
+
+	// Recently resubmitted responses aren't sent again
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(101), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseWindowBlock(gomock.Any()).Return(uint32(30), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskHash(gomock.Any(), uint32(2)).Return(taskHash, nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), uint32(2)).Return([32]byte{}, nil)
+
+	aggregator.reconcileCheckpointTasks(context.Background())
+	assert.Equal(t, 1, resubmissions)
+}
+
+func TestLoadUnrespondedCheckpointTasks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, mockAvsReader, _, _, _, _, _, _, _, mockClient, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	respondedTaskIndex := uint32(CHECKPOINT_TASK_STARTUP_LOOKBACK)
+	unrespondedTaskIndex := respondedTaskIndex + 1
+	expiredTaskIndex := respondedTaskIndex + 2
+	nextTaskNum := respondedTaskIndex + 3
+
+	unrespondedTask := taskmanager.CheckpointTask{TaskCreatedBlock: 95}
+
+	mockAvsReader.EXPECT().GetNextCheckpointTaskNum(gomock.Any()).Return(nextTaskNum, nil)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(100), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseWindowBlock(gomock.Any()).Return(uint32(30), nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskHash(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, taskIndex uint32) ([32]byte, error) {
+		// Only the latest tasks are looked at
+		assert.GreaterOrEqual(t, taskIndex, nextTaskNum-CHECKPOINT_TASK_STARTUP_LOOKBACK)
+
+		if taskIndex < respondedTaskIndex {
+			return [32]byte{}, nil
+		}
+		return [32]byte{1}, nil
+	}).Times(CHECKPOINT_TASK_STARTUP_LOOKBACK)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), respondedTaskIndex).Return([32]byte{2}, nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), unrespondedTaskIndex).Return([32]byte{}, nil)
+	mockAvsReader.EXPECT().GetCheckpointTaskResponseHash(gomock.Any(), expiredTaskIndex).Return([32]byte{}, nil)
+	mockAvsReader.EXPECT().GetCheckpointTask(gomock.Any(), unrespondedTaskIndex, uint64(70)).Return(unrespondedTask, nil)
+	mockAvsReader.EXPECT().GetCheckpointTask(gomock.Any(), expiredTaskIndex, uint64(70)).Return(taskmanager.CheckpointTask{}, chainio.CheckpointTaskNotFoundError)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(95)).Return(&gethtypes.Header{Time: 9_000}, nil)
+
+	err = aggregator.loadUnrespondedCheckpointTasks(context.Background())
+	assert.NoError(t, err)
+
+	pending := aggregator.checkpointTaskTracker.Pending()
+	assert.Len(t, pending, 1)
+	assert.Equal(t, unrespondedTaskIndex, pending[0].TaskIndex)
+	assert.Equal(t, unrespondedTask, pending[0].Task)
+	assert.Equal(t, time.Unix(9_000, 0), pending[0].CreatedAt)
+	assert.Nil(t, pending[0].TaskResponse)
+}
+
+func TestCheckpointSchedulingPolicy(t *testing.T) {
+	policy := CheckpointSchedulingPolicy{
+		MinMessages:              10,
+		MaxStaleness:             10 * time.Minute,
+		MaxGasPrice:              big.NewInt(100),
+		GasPriceOverrideDeadline: time.Hour,
+	}
+
+	testCases := []struct {
+		name            string
+		pendingMessages uint64
+		staleness       time.Duration
+		gasPrice        *big.Int
+		expected        CheckpointDecision
+	}{
+		{"no messages", 0, 2 * time.Hour, big.NewInt(1), CheckpointDecision{false, CHECKPOINT_DECISION_NO_MESSAGES}},
+		{"below min messages", 5, time.Minute, big.NewInt(1), CheckpointDecision{false, CHECKPOINT_DECISION_BELOW_MIN_MESSAGES}},
+		{"min messages reached", 10, time.Minute, big.NewInt(1), CheckpointDecision{true, CHECKPOINT_DECISION_MIN_MESSAGES_REACHED}},
+		{"max staleness reached", 5, 10 * time.Minute, big.NewInt(1), CheckpointDecision{true, CHECKPOINT_DECISION_MAX_STALENESS}},
+		{"gas price too high", 10, time.Minute, big.NewInt(101), CheckpointDecision{false, CHECKPOINT_DECISION_GAS_PRICE_TOO_HIGH}},
+		{"gas price override", 5, time.Hour, big.NewInt(101), CheckpointDecision{true, CHECKPOINT_DECISION_GAS_PRICE_OVERRIDE}},
+		{"unknown gas price", 10, time.Minute, nil, CheckpointDecision{true, CHECKPOINT_DECISION_MIN_MESSAGES_REACHED}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, policy.Decide(testCase.pendingMessages, testCase.staleness, testCase.gasPrice))
+		})
+	}
+
+	// Only empty checkpoints are skipped by default
+	assert.Equal(t, CheckpointDecision{true, CHECKPOINT_DECISION_MIN_MESSAGES_REACHED}, CheckpointSchedulingPolicy{}.Decide(1, 0, big.NewInt(1)))
+}
+
+func TestScheduleCheckpointTask(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, mockAvsReader, mockAvsWriter, mockTaskBlsAggService, _, _, _, mockMsgDb, _, mockClient, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	aggregator.checkpointSchedulingPolicy = CheckpointSchedulingPolicy{MaxGasPrice: big.NewInt(100), GasPriceOverrideDeadline: time.Hour}
+
+	decisions := make(map[string]int)
+	aggregator.aggregatorListener = &SelectiveAggregatorListener{
+		IncCheckpointDecisionsCb: func(sent bool, reason string) { decisions[reason]++ },
+	}
+
+	var BLOCK_NUMBER = uint64(100)
+	var FROM_TIMESTAMP = uint64(30_000)
+	var TO_TIMESTAMP = uint64(40_000)
+	aggregator.clock = core.Clock{Now: func() time.Time { return time.Unix(int64(TO_TIMESTAMP), 0) }}
+	expectedToTimestamp := TO_TIMESTAMP - uint64(types.MESSAGE_SUBMISSION_TIMEOUT.Seconds()) - uint64(types.MESSAGE_BLS_AGGREGATION_TIMEOUT.Seconds())
+
+	expectRange := func() {
+		mockClient.EXPECT().BlockNumber(gomock.Any()).Return(BLOCK_NUMBER, nil)
+		mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(int64(BLOCK_NUMBER))).Return(
+			gethtypes.NewBlockWithHeader(&gethtypes.Header{Time: TO_TIMESTAMP}),
+			nil,
+		)
+		mockAvsReader.EXPECT().GetLastCheckpointToTimestamp(gomock.Any()).Return(FROM_TIMESTAMP-1, nil)
+	}
+
+	// Empty checkpoints are skipped without checking the gas price
+	expectRange()
+	mockMsgDb.EXPECT().CountCheckpointMessages(FROM_TIMESTAMP, expectedToTimestamp).Return(uint64(0), nil)
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Nil(t, err)
+
+	// Postponed while the gas price is above the ceiling. Staleness is the
+	// age of the oldest pending message rather than the width of the range
+	expectRange()
+	mockMsgDb.EXPECT().CountCheckpointMessages(FROM_TIMESTAMP, expectedToTimestamp).Return(uint64(3), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(FROM_TIMESTAMP, expectedToTimestamp).Return(TO_TIMESTAMP-600, true, nil)
+	mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(101), nil)
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Nil(t, err)
+
+	expectRange()
+	mockMsgDb.EXPECT().CountCheckpointMessages(FROM_TIMESTAMP, expectedToTimestamp).Return(uint64(3), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(FROM_TIMESTAMP, expectedToTimestamp).Return(TO_TIMESTAMP-600, true, nil)
+	mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(100), nil)
+	mockAvsWriter.EXPECT().SendNewCheckpointTask(
+		gomock.Any(),
+		FROM_TIMESTAMP,
+		expectedToTimestamp,
+		types.TASK_QUORUM_THRESHOLD,
+		coretypes.QUORUM_NUMBERS,
+	).Return(aggmocks.MockSendNewCheckpointTask(uint32(BLOCK_NUMBER), 0, FROM_TIMESTAMP, expectedToTimestamp))
+	mockTaskBlsAggService.EXPECT().InitializeMessageIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Nil(t, err)
+
+	// Sent above the ceiling once the oldest pending message passes the deadline
+	expectRange()
+	mockMsgDb.EXPECT().CountCheckpointMessages(FROM_TIMESTAMP, expectedToTimestamp).Return(uint64(3), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(FROM_TIMESTAMP, expectedToTimestamp).Return(TO_TIMESTAMP-3_601, true, nil)
+	mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(101), nil)
+	mockAvsWriter.EXPECT().SendNewCheckpointTask(
+		gomock.Any(),
+		FROM_TIMESTAMP,
+		expectedToTimestamp,
+		types.TASK_QUORUM_THRESHOLD,
+		coretypes.QUORUM_NUMBERS,
+	).Return(aggmocks.MockSendNewCheckpointTask(uint32(BLOCK_NUMBER), 1, FROM_TIMESTAMP, expectedToTimestamp))
+	mockTaskBlsAggService.EXPECT().InitializeMessageIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Nil(t, err)
+
+	// Failed sends are left to the caller to export
+	expectRange()
+	mockMsgDb.EXPECT().CountCheckpointMessages(FROM_TIMESTAMP, expectedToTimestamp).Return(uint64(3), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(FROM_TIMESTAMP, expectedToTimestamp).Return(TO_TIMESTAMP-600, true, nil)
+	mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(100), nil)
+	mockAvsWriter.EXPECT().SendNewCheckpointTask(gomock.Any(), FROM_TIMESTAMP, expectedToTimestamp, gomock.Any(), gomock.Any()).Return(taskmanager.CheckpointTask{}, uint32(0), errors.New("send error"))
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Error(t, err)
+
+	// Before the first checkpoint, ranges start at the oldest pending message
+	// so that skipped ones are still covered
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(BLOCK_NUMBER, nil)
+	mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(int64(BLOCK_NUMBER))).Return(
+		gethtypes.NewBlockWithHeader(&gethtypes.Header{Time: TO_TIMESTAMP}),
+		nil,
+	)
+	mockAvsReader.EXPECT().GetLastCheckpointToTimestamp(gomock.Any()).Return(uint64(0), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(uint64(0), expectedToTimestamp).Return(TO_TIMESTAMP-600, true, nil)
+	mockMsgDb.EXPECT().CountCheckpointMessages(TO_TIMESTAMP-600, expectedToTimestamp).Return(uint64(3), nil)
+	mockMsgDb.EXPECT().FetchOldestCheckpointMessageTimestamp(TO_TIMESTAMP-600, expectedToTimestamp).Return(TO_TIMESTAMP-600, true, nil)
+	mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(101), nil)
+
+	err = aggregator.scheduleCheckpointTask()
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]int{
+		CHECKPOINT_DECISION_NO_MESSAGES:          1,
+		CHECKPOINT_DECISION_GAS_PRICE_TOO_HIGH:   2,
+		CHECKPOINT_DECISION_MIN_MESSAGES_REACHED: 1,
+		CHECKPOINT_DECISION_GAS_PRICE_OVERRIDE:   1,
+	}, decisions)
+}
+
+func TestRollupHeadTrackerFailsClosed(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Unix(10_000, 0)
+	clock := core.Clock{Now: func() time.Time { return now }}
+
+	mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+	newClient := func(rollupId uint32) (safeclient.SafeClient, error) { return mockClient, nil }
+	tracker := newRollupHeadTracker([]uint32{1, 2}, newClient, clock, sdklogging.NewNoopLogger())
+
+	_, err := tracker.GetRollupHead(3)
+	assert.Equal(t, UnknownRollupError, err)
+
+	// Not polled yet, messages aren't checked until the staleness bound passes
+	_, err = tracker.GetRollupHead(1)
+	assert.Equal(t, RollupHeadUnknownError, err)
+
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(100), nil)
+	tracker.updateHead(context.Background(), 1, mockClient)
+
+	head, err := tracker.GetRollupHead(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), head)
+
+	now = now.Add(ROLLUP_HEAD_MAX_STALENESS + time.Second)
+
+	_, err = tracker.GetRollupHead(1)
+	assert.Equal(t, RollupHeadStaleError, err)
+	_, err = tracker.GetRollupHead(2)
+	assert.Equal(t, RollupHeadStaleError, err)
+}
+
 func createMockAggregator(
 	mockCtrl *gomock.Controller, operatorPubkeyDict map[eigentypes.OperatorId]types.OperatorInfo,
 ) (*Aggregator, *chainiomocks.MockAvsReaderer, *chainiomocks.MockAvsWriterer, *aggmocks.MockMessageBlsAggregationService, *aggmocks.MockMessageBlsAggregationService, *aggmocks.MockMessageBlsAggregationService, *aggmocks.MockOperatorRegistrationsService, *dbmocks.MockDatabaser, *aggmocks.MockRollupBroadcasterer, *safeclientmocks.MockSafeClient, error) {
@@ -222,11 +814,15 @@ func createMockAggregator(
 		operatorSetUpdateBlsAggregationService: mockOperatorSetUpdateBlsAggregationService,
 		operatorRegistrationsService:           mockOperatorRegistrationsService,
 		msgDb:                                  mockMsgDb,
+		participationTracker:                   NewParticipationTracker(),
+		checkpointTaskTracker:                  NewCheckpointTaskTracker(),
+		rateLimiter:                            newOperatorRateLimiter(OPERATOR_RATE_LIMIT, OPERATOR_RATE_BURST),
 		tasks:                                  make(map[coretypes.TaskIndex]taskmanager.CheckpointTask),
 		rollupBroadcaster:                      mockRollupBroadcaster,
 		httpClient:                             mockClient,
 		wsClient:                               mockClient,
 		aggregatorListener:                     &SelectiveAggregatorListener{},
+		signatureVerifier:                      blsagg.NewSignatureVerifier(context.Background(), logger),
 		clock:                                  core.SystemClock,
 	}
 	return aggregator, mockAvsReader, mockAvsWriter, mockTaskBlsAggregationService, mockStateRootUpdateBlsAggregationService, mockOperatorSetUpdateBlsAggregationService, mockOperatorRegistrationsService, mockMsgDb, mockRollupBroadcaster, mockClient, nil
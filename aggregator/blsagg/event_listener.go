@@ -0,0 +1,232 @@
+package blsagg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const AggregatorNamespace = "sffl_aggregator"
+const OperatorStateCacheSubsystem = "operator_state_cache"
+const SignatureVerifierSubsystem = "signature_verifier"
+const MessageAggregatorSubsystem = "message_aggregator"
+
+type EventListener interface {
+	OnOperatorStateCacheHit()
+	OnOperatorStateCacheMiss()
+	OnOperatorStateCacheInvalidation(count int)
+	OnSignatureBatchVerified(size int, fallback bool)
+	OnActiveMessages(messageType string, count int)
+	OnMessageRejected(messageType string)
+	OnBusyWorkers(messageType string, count int)
+	OnResponseQueueDepth(messageType string, shard int, depth int)
+	OnEquivocationDropped(messageType string)
+}
+
+type SelectiveListener struct {
+	OnOperatorStateCacheHitCb          func()
+	OnOperatorStateCacheMissCb         func()
+	OnOperatorStateCacheInvalidationCb func(count int)
+	OnSignatureBatchVerifiedCb         func(size int, fallback bool)
+	OnActiveMessagesCb                 func(messageType string, count int)
+	OnMessageRejectedCb                func(messageType string)
+	OnBusyWorkersCb                    func(messageType string, count int)
+	OnResponseQueueDepthCb             func(messageType string, shard int, depth int)
+	OnEquivocationDroppedCb            func(messageType string)
+}
+
+func (l *SelectiveListener) OnOperatorStateCacheHit() {
+	if l.OnOperatorStateCacheHitCb != nil {
+		l.OnOperatorStateCacheHitCb()
+	}
+}
+
+func (l *SelectiveListener) OnOperatorStateCacheMiss() {
+	if l.OnOperatorStateCacheMissCb != nil {
+		l.OnOperatorStateCacheMissCb()
+	}
+}
+
+func (l *SelectiveListener) OnOperatorStateCacheInvalidation(count int) {
+	if l.OnOperatorStateCacheInvalidationCb != nil {
+		l.OnOperatorStateCacheInvalidationCb(count)
+	}
+}
+
+func (l *SelectiveListener) OnSignatureBatchVerified(size int, fallback bool) {
+	if l.OnSignatureBatchVerifiedCb != nil {
+		l.OnSignatureBatchVerifiedCb(size, fallback)
+	}
+}
+
+func (l *SelectiveListener) OnActiveMessages(messageType string, count int) {
+	if l.OnActiveMessagesCb != nil {
+		l.OnActiveMessagesCb(messageType, count)
+	}
+}
+
+func (l *SelectiveListener) OnMessageRejected(messageType string) {
+	if l.OnMessageRejectedCb != nil {
+		l.OnMessageRejectedCb(messageType)
+	}
+}
+
+func (l *SelectiveListener) OnBusyWorkers(messageType string, count int) {
+	if l.OnBusyWorkersCb != nil {
+		l.OnBusyWorkersCb(messageType, count)
+	}
+}
+
+func (l *SelectiveListener) OnResponseQueueDepth(messageType string, shard int, depth int) {
+	if l.OnResponseQueueDepthCb != nil {
+		l.OnResponseQueueDepthCb(messageType, shard, depth)
+	}
+}
+
+func (l *SelectiveListener) OnEquivocationDropped(messageType string) {
+	if l.OnEquivocationDroppedCb != nil {
+		l.OnEquivocationDroppedCb(messageType)
+	}
+}
+
+func MakeOperatorStateCacheMetrics(registry *prometheus.Registry) (EventListener, error) {
+	operatorStateCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: OperatorStateCacheSubsystem,
+		Name:      "hits_total",
+		Help:      "Total number of operator state lookups served from the cache",
+	})
+	if err := registry.Register(operatorStateCacheHits); err != nil {
+		return nil, fmt.Errorf("error registering operatorStateCacheHits counter: %w", err)
+	}
+
+	operatorStateCacheMisses := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: OperatorStateCacheSubsystem,
+		Name:      "misses_total",
+		Help:      "Total number of operator state lookups fetched from the registry",
+	})
+	if err := registry.Register(operatorStateCacheMisses); err != nil {
+		return nil, fmt.Errorf("error registering operatorStateCacheMisses counter: %w", err)
+	}
+
+	operatorStateCacheInvalidations := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: OperatorStateCacheSubsystem,
+		Name:      "invalidated_snapshots_total",
+		Help:      "Total number of operator state snapshots invalidated by operator set updates",
+	})
+	if err := registry.Register(operatorStateCacheInvalidations); err != nil {
+		return nil, fmt.Errorf("error registering operatorStateCacheInvalidations counter: %w", err)
+	}
+
+	return &SelectiveListener{
+		OnOperatorStateCacheHitCb: func() {
+			operatorStateCacheHits.Inc()
+		},
+		OnOperatorStateCacheMissCb: func() {
+			operatorStateCacheMisses.Inc()
+		},
+		OnOperatorStateCacheInvalidationCb: func(count int) {
+			operatorStateCacheInvalidations.Add(float64(count))
+		},
+	}, nil
+}
+
+func MakeBlsAggMetrics(registry *prometheus.Registry) (EventListener, error) {
+	signatureBatchSize := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: SignatureVerifierSubsystem,
+		Name:      "batch_size",
+		Help:      "Number of signatures verified per batch",
+		Buckets:   []float64{1, 2, 4, 8, 16, 32, 64, 128, math.Inf(0)},
+	})
+	if err := registry.Register(signatureBatchSize); err != nil {
+		return nil, fmt.Errorf("error registering signatureBatchSize histogram: %w", err)
+	}
+
+	signatureBatchFallbacks := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: SignatureVerifierSubsystem,
+		Name:      "batch_fallbacks_total",
+		Help:      "Total number of failed signature batches verified individually",
+	})
+	if err := registry.Register(signatureBatchFallbacks); err != nil {
+		return nil, fmt.Errorf("error registering signatureBatchFallbacks counter: %w", err)
+	}
+
+	activeMessages := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: MessageAggregatorSubsystem,
+		Name:      "active_messages",
+		Help:      "Number of messages being aggregated per message type",
+	}, []string{"message_type"})
+	if err := registry.Register(activeMessages); err != nil {
+		return nil, fmt.Errorf("error registering activeMessages gauge: %w", err)
+	}
+
+	rejectedMessages := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: MessageAggregatorSubsystem,
+		Name:      "rejected_messages_total",
+		Help:      "Total number of new messages rejected due to overload per message type",
+	}, []string{"message_type"})
+	if err := registry.Register(rejectedMessages); err != nil {
+		return nil, fmt.Errorf("error registering rejectedMessages counter: %w", err)
+	}
+
+	busyWorkers := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: MessageAggregatorSubsystem,
+		Name:      "busy_workers",
+		Help:      "Number of workers handling signed messages per message type",
+	}, []string{"message_type"})
+	if err := registry.Register(busyWorkers); err != nil {
+		return nil, fmt.Errorf("error registering busyWorkers gauge: %w", err)
+	}
+
+	responseQueueDepth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: MessageAggregatorSubsystem,
+		Name:      "response_queue_depth",
+		Help:      "Number of responses waiting to be handled per message type and shard",
+	}, []string{"message_type", "shard"})
+	if err := registry.Register(responseQueueDepth); err != nil {
+		return nil, fmt.Errorf("error registering responseQueueDepth gauge: %w", err)
+	}
+
+	droppedEquivocations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: AggregatorNamespace,
+		Subsystem: MessageAggregatorSubsystem,
+		Name:      "dropped_equivocations_total",
+		Help:      "Total number of equivocation evidence dropped due to a full channel per message type",
+	}, []string{"message_type"})
+	if err := registry.Register(droppedEquivocations); err != nil {
+		return nil, fmt.Errorf("error registering droppedEquivocations counter: %w", err)
+	}
+
+	return &SelectiveListener{
+		OnSignatureBatchVerifiedCb: func(size int, fallback bool) {
+			signatureBatchSize.Observe(float64(size))
+			if fallback {
+				signatureBatchFallbacks.Inc()
+			}
+		},
+		OnActiveMessagesCb: func(messageType string, count int) {
+			activeMessages.WithLabelValues(messageType).Set(float64(count))
+		},
+		OnMessageRejectedCb: func(messageType string) {
+			rejectedMessages.WithLabelValues(messageType).Inc()
+		},
+		OnBusyWorkersCb: func(messageType string, count int) {
+			busyWorkers.WithLabelValues(messageType).Set(float64(count))
+		},
+		OnResponseQueueDepthCb: func(messageType string, shard int, depth int) {
+			responseQueueDepth.WithLabelValues(messageType, fmt.Sprintf("%d", shard)).Set(float64(depth))
+		},
+		OnEquivocationDroppedCb: func(messageType string) {
+			droppedEquivocations.WithLabelValues(messageType).Inc()
+		},
+	}, nil
+}
@@ -4,11 +4,14 @@ package blsagg
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/big"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
@@ -33,13 +36,30 @@ var (
 	OperatorNotPartOfMessageQuorumErrorFn = func(operatorId eigentypes.OperatorId, messageDigest coretypes.MessageDigest) error {
 		return fmt.Errorf("operator 0x%x not part of message 0x%x's quorum", operatorId, messageDigest)
 	}
-	SignatureVerificationError = func(err error) error {
-		return fmt.Errorf("Failed to verify signature: %w", err)
-	}
-	IncorrectSignatureError                   = errors.New("Signature verification failed. Incorrect Signature.")
 	MessageDigestNotFoundError                = errors.New("Message digest not found")
 	QuorumThresholdPercentageOutOfBoundsError = errors.New("Quorum threshold percentage out of bounds")
 	QuorumThresholdPercentageLessThan51Error  = errors.New("Quorum threshold percentage less than 51")
+	OperatorEquivocationError                 = errors.New("Operator already signed a different digest for this message")
+	OperatorAlreadySignedError                = errors.New("Operator already signed this message")
+	AggregatorOverloadedError                 = errors.New("Too many messages being aggregated, try again later")
+	MessageDroppedError                       = errors.New("Message dropped")
+)
+
+const (
+	// Default maximum number of messages being aggregated at once, past which
+	// new message keys are rejected. Messages in their late signature grace
+	// period don't count towards it.
+	MAX_ACTIVE_MESSAGES = 4096
+	// Default number of signed messages handled at once across all messages
+	SIGNED_MESSAGE_WORKERS = 16
+	// Default number of response channels. A message's responses always go to
+	// the same one, so they're received in order.
+	RESPONSE_SHARDS = 4
+	// Capacity of each response channel, past which message goroutines block
+	RESPONSE_SHARD_QUEUE_SIZE = 256
+	// Capacity of the equivocation channel, past which evidence is dropped
+	// rather than blocking a worker
+	EQUIVOCATION_QUEUE_SIZE = 64
 )
 
 type MessageBlsAggregationStatus int32
@@ -60,6 +80,31 @@ type MessageBlsAggregationServiceResponse struct {
 	Status   MessageBlsAggregationStatus
 	Finished bool
 	Err      error
+
+	// Operators that did and didn't sign the aggregated digest, sorted by ID
+	SignersOperatorIds    []eigentypes.OperatorId
+	NonSignersOperatorIds []eigentypes.OperatorId
+
+	// Set for improved aggregations from signatures received after the
+	// message was finished, along with the operator who sent it
+	Late                 bool
+	LateSignerOperatorId eigentypes.OperatorId
+}
+
+// Snapshot of a message being aggregated
+type ActiveMessage struct {
+	MessageType      messages.MessageType
+	MessageKey       coretypes.MessageKey
+	InitializedAt    time.Time
+	SignersCount     int
+	ThresholdReached bool
+}
+
+// Bounds of the message aggregation, zero values keep the defaults
+type AggregationLimits struct {
+	MaxActiveMessages    int
+	SignedMessageWorkers int
+	ResponseShards       int
 }
 
 type MessageBlsAggregationServiceMessage interface {
@@ -82,12 +127,34 @@ type SignedMessage struct {
 	SignatureVerificationErrorC chan error
 }
 
+// State of a message being aggregated, shared between its goroutine and the
+// service
+type activeMessage struct {
+	signedMessageC   chan SignedMessage
+	dropC            chan struct{}
+	dropped          bool
+	aggregating      bool
+	initializedAt    time.Time
+	signersCount     atomic.Int32
+	thresholdReached atomic.Bool
+}
+
+func newActiveMessage() *activeMessage {
+	return &activeMessage{
+		signedMessageC: make(chan SignedMessage),
+		dropC:          make(chan struct{}),
+		aggregating:    true,
+		initializedAt:  time.Now(),
+	}
+}
+
 type signedMessageDigestValidationInfo struct {
 	operatorsAvsStateDict         map[eigentypes.OperatorId]eigentypes.OperatorAvsState
 	quorumsAvsStakeDict           map[eigentypes.QuorumNum]eigentypes.QuorumAvsState
 	totalStakePerQuorum           map[eigentypes.QuorumNum]*big.Int
 	quorumApksG1                  []*bls.G1Point
 	aggregatedOperatorsDict       map[eigentypes.TaskResponseDigest]AggregatedOperators
+	operatorSignaturesDict        map[eigentypes.OperatorId]SignedMessage
 	quorumThresholdPercentagesMap map[eigentypes.QuorumNum]eigentypes.QuorumThresholdPercentage
 	quorumNumbers                 []eigentypes.QuorumNum
 	ethBlockNumber                uint64
@@ -103,6 +170,8 @@ type MessageBlsAggregationService interface {
 		ethBlockNumber uint64,
 	) error
 
+	// The signature must already be verified against the operator's pubkey,
+	// e.g. through a SignatureVerifier
 	ProcessNewSignature(
 		ctx context.Context,
 		message MessageBlsAggregationServiceMessage,
@@ -110,47 +179,163 @@ type MessageBlsAggregationService interface {
 		operatorId eigentypes.OperatorId,
 	) error
 
-	GetResponseChannel() <-chan MessageBlsAggregationServiceResponse
+	// Responses are sharded by message key, so each channel should be
+	// consumed independently
+	GetResponseChannels() []<-chan MessageBlsAggregationServiceResponse
+	GetEquivocationChannel() <-chan messages.EquivocationEvidence
+	SetListener(listener EventListener)
+
+	GetActiveMessages() []ActiveMessage
+	DropMessage(messageKey coretypes.MessageKey) error
 }
 
 type MessageBlsAggregatorService struct {
-	aggregatedResponsesC chan MessageBlsAggregationServiceResponse
-	signedMessageCs      map[coretypes.MessageKey]chan SignedMessage
-	messageChansLock     sync.RWMutex
-	avsRegistryService   avsregistry.AvsRegistryService
-	ethClient            eth.Client
-	logger               logging.Logger
+	messageType              messages.MessageType
+	aggregatedResponsesCs    []chan MessageBlsAggregationServiceResponse
+	equivocationsC           chan messages.EquivocationEvidence
+	activeMessages           map[coretypes.MessageKey]*activeMessage
+	aggregatingMessages      int
+	maxActiveMessages        int
+	workerSlots              chan struct{}
+	lateSignatureGracePeriod time.Duration
+	messageChansLock         sync.RWMutex
+	avsRegistryService       avsregistry.AvsRegistryService
+	ethClient                eth.Client
+	listener                 EventListener
+	logger                   logging.Logger
 }
 
 var _ MessageBlsAggregationService = (*MessageBlsAggregatorService)(nil)
 
-func NewMessageBlsAggregatorService(avsRegistryService avsregistry.AvsRegistryService, ethClient eth.Client, logger logging.Logger) *MessageBlsAggregatorService {
+func NewMessageBlsAggregatorService(messageType messages.MessageType, avsRegistryService avsregistry.AvsRegistryService, ethClient eth.Client, logger logging.Logger) *MessageBlsAggregatorService {
 	return &MessageBlsAggregatorService{
-		aggregatedResponsesC: make(chan MessageBlsAggregationServiceResponse),
-		signedMessageCs:      make(map[coretypes.MessageKey]chan SignedMessage),
-		messageChansLock:     sync.RWMutex{},
-		avsRegistryService:   avsRegistryService,
-		ethClient:            ethClient,
-		logger:               logger,
+		messageType:           messageType,
+		aggregatedResponsesCs: makeResponseShards(RESPONSE_SHARDS),
+		equivocationsC:        make(chan messages.EquivocationEvidence, EQUIVOCATION_QUEUE_SIZE),
+		activeMessages:        make(map[coretypes.MessageKey]*activeMessage),
+		maxActiveMessages:     MAX_ACTIVE_MESSAGES,
+		workerSlots:           make(chan struct{}, SIGNED_MESSAGE_WORKERS),
+		messageChansLock:      sync.RWMutex{},
+		avsRegistryService:    avsRegistryService,
+		ethClient:             ethClient,
+		listener:              &SelectiveListener{},
+		logger:                logger,
 	}
 }
 
-func (mbas *MessageBlsAggregatorService) GetResponseChannel() <-chan MessageBlsAggregationServiceResponse {
-	return mbas.aggregatedResponsesC
+func (mbas *MessageBlsAggregatorService) SetListener(listener EventListener) {
+	mbas.listener = listener
 }
 
-func (mbas *MessageBlsAggregatorService) initializeMessageChan(messageKey coretypes.MessageKey) chan SignedMessage {
+func makeResponseShards(shards int) []chan MessageBlsAggregationServiceResponse {
+	aggregatedResponsesCs := make([]chan MessageBlsAggregationServiceResponse, shards)
+	for i := range aggregatedResponsesCs {
+		aggregatedResponsesCs[i] = make(chan MessageBlsAggregationServiceResponse, RESPONSE_SHARD_QUEUE_SIZE)
+	}
+
+	return aggregatedResponsesCs
+}
+
+// Overrides the aggregation bounds. Must be called before the service is used.
+func (mbas *MessageBlsAggregatorService) WithAggregationLimits(limits AggregationLimits) *MessageBlsAggregatorService {
+	if limits.MaxActiveMessages > 0 {
+		mbas.maxActiveMessages = limits.MaxActiveMessages
+	}
+	if limits.SignedMessageWorkers > 0 {
+		mbas.workerSlots = make(chan struct{}, limits.SignedMessageWorkers)
+	}
+	if limits.ResponseShards > 0 {
+		mbas.aggregatedResponsesCs = makeResponseShards(limits.ResponseShards)
+	}
+
+	return mbas
+}
+
+// Keeps finished messages open for a grace period, during which signatures on
+// the aggregated digest still get added and produce late responses
+func (mbas *MessageBlsAggregatorService) WithLateSignatureGracePeriod(gracePeriod time.Duration) *MessageBlsAggregatorService {
+	mbas.lateSignatureGracePeriod = gracePeriod
+	return mbas
+}
+
+func (mbas *MessageBlsAggregatorService) GetResponseChannels() []<-chan MessageBlsAggregationServiceResponse {
+	responseCs := make([]<-chan MessageBlsAggregationServiceResponse, len(mbas.aggregatedResponsesCs))
+	for i, responseC := range mbas.aggregatedResponsesCs {
+		responseCs[i] = responseC
+	}
+
+	return responseCs
+}
+
+func (mbas *MessageBlsAggregatorService) GetEquivocationChannel() <-chan messages.EquivocationEvidence {
+	return mbas.equivocationsC
+}
+
+func (mbas *MessageBlsAggregatorService) GetActiveMessages() []ActiveMessage {
+	mbas.messageChansLock.RLock()
+	defer mbas.messageChansLock.RUnlock()
+
+	activeMessages := make([]ActiveMessage, 0, len(mbas.activeMessages))
+	for messageKey, activeMsg := range mbas.activeMessages {
+		if activeMsg.dropped {
+			continue
+		}
+
+		activeMessages = append(activeMessages, ActiveMessage{
+			MessageType:      mbas.messageType,
+			MessageKey:       messageKey,
+			InitializedAt:    activeMsg.initializedAt,
+			SignersCount:     int(activeMsg.signersCount.Load()),
+			ThresholdReached: activeMsg.thresholdReached.Load(),
+		})
+	}
+
+	sort.SliceStable(activeMessages, func(i, j int) bool {
+		return activeMessages[i].InitializedAt.Before(activeMessages[j].InitializedAt)
+	})
+
+	return activeMessages
+}
+
+// Stops aggregating a message, e.g. if it's stuck. Messages which weren't
+// finished yet get a finished response with MessageDroppedError.
+func (mbas *MessageBlsAggregatorService) DropMessage(messageKey coretypes.MessageKey) error {
 	mbas.messageChansLock.Lock()
 	defer mbas.messageChansLock.Unlock()
 
-	if _, taskExists := mbas.signedMessageCs[messageKey]; taskExists {
-		return nil
+	activeMsg, ok := mbas.activeMessages[messageKey]
+	if !ok || activeMsg.dropped {
+		return MessageNotFoundErrorFn(messageKey)
+	}
+
+	mbas.logger.Info("Dropping message", "key", messageKey)
+
+	activeMsg.dropped = true
+	close(activeMsg.dropC)
+
+	return nil
+}
+
+func (mbas *MessageBlsAggregatorService) initializeMessageChan(messageKey coretypes.MessageKey) (*activeMessage, error) {
+	mbas.messageChansLock.Lock()
+	defer mbas.messageChansLock.Unlock()
+
+	// A dropped message is replaced, its goroutine won't remove the new one
+	if activeMsg, taskExists := mbas.activeMessages[messageKey]; taskExists && !activeMsg.dropped {
+		return nil, nil
+	}
+
+	if mbas.aggregatingMessages >= mbas.maxActiveMessages {
+		mbas.listener.OnMessageRejected(string(mbas.messageType))
+		return nil, AggregatorOverloadedError
 	}
 
-	signedMessageC := make(chan SignedMessage)
-	mbas.signedMessageCs[messageKey] = signedMessageC
+	activeMsg := newActiveMessage()
+	mbas.activeMessages[messageKey] = activeMsg
+	mbas.aggregatingMessages++
+	mbas.listener.OnActiveMessages(string(mbas.messageType), mbas.aggregatingMessages)
 
-	return signedMessageC
+	return activeMsg, nil
 }
 
 func (mbas *MessageBlsAggregatorService) InitializeMessageIfNotExists(
@@ -165,13 +350,18 @@ func (mbas *MessageBlsAggregatorService) InitializeMessageIfNotExists(
 		return err
 	}
 
-	signedMessageC := mbas.initializeMessageChan(messageKey)
-	if signedMessageC == nil {
+	activeMsg, err := mbas.initializeMessageChan(messageKey)
+	if err != nil {
+		mbas.logger.Warn("Rejected new message", "key", messageKey, "err", err)
+		return err
+	}
+	if activeMsg == nil {
 		return nil
 	}
 
 	validationInfo, err := mbas.fetchValidationInfo(quorumNumbers, quorumThresholdPercentages, ethBlockNumber)
 	if err != nil {
+		mbas.closeMessageGoroutine(messageKey, activeMsg)
 		return err
 	}
 
@@ -180,7 +370,7 @@ func (mbas *MessageBlsAggregatorService) InitializeMessageIfNotExists(
 		validationInfo,
 		timeToExpiry,
 		aggregationTimeout,
-		signedMessageC,
+		activeMsg,
 	)
 
 	return nil
@@ -193,7 +383,8 @@ func (mbas *MessageBlsAggregatorService) ProcessNewSignature(
 	operatorId eigentypes.OperatorId,
 ) error {
 	mbas.messageChansLock.RLock()
-	messageC, taskInitialized := mbas.signedMessageCs[message.Key()]
+	activeMsg, taskInitialized := mbas.activeMessages[message.Key()]
+	taskInitialized = taskInitialized && !activeMsg.dropped
 	mbas.messageChansLock.RUnlock()
 
 	if !taskInitialized {
@@ -207,7 +398,7 @@ func (mbas *MessageBlsAggregatorService) ProcessNewSignature(
 	}
 
 	select {
-	case messageC <- SignedMessage{
+	case activeMsg.signedMessageC <- SignedMessage{
 		Message:                     message,
 		MessageDigest:               messageDigest,
 		BlsSignature:                blsSignature,
@@ -215,6 +406,8 @@ func (mbas *MessageBlsAggregatorService) ProcessNewSignature(
 		SignatureVerificationErrorC: signatureVerificationErrorC,
 	}:
 		return <-signatureVerificationErrorC
+	case <-activeMsg.dropC:
+		return MessageNotFoundErrorFn(message.Key())
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -225,13 +418,26 @@ func (mbas *MessageBlsAggregatorService) singleMessageAggregatorGoroutineFunc(
 	validationInfo *signedMessageDigestValidationInfo,
 	timeToExpiry time.Duration,
 	aggregationTimeout time.Duration,
-	signedMessageC <-chan SignedMessage,
+	activeMsg *activeMessage,
 ) {
-	defer mbas.closeMessageGoroutine(messageKey)
+	defer mbas.closeMessageGoroutine(messageKey, activeMsg)
+
+	message, shouldWaitForFullStake := mbas.handleSignedMessagePreThreshold(messageKey, validationInfo, timeToExpiry, activeMsg)
+	if message == nil {
+		return
+	}
+
+	activeMsg.thresholdReached.Store(true)
 
-	message, shouldWaitForFullStake := mbas.handleSignedMessagePreThreshold(messageKey, validationInfo, timeToExpiry, signedMessageC)
 	if shouldWaitForFullStake {
-		mbas.handleSignedMessageThresholdReached(message, validationInfo, signedMessageC, aggregationTimeout)
+		if dropped := mbas.handleSignedMessageThresholdReached(message, validationInfo, activeMsg, aggregationTimeout); dropped {
+			return
+		}
+	}
+
+	if mbas.lateSignatureGracePeriod > 0 {
+		mbas.finishAggregation(activeMsg)
+		mbas.handleLateSignedMessages(message, validationInfo, activeMsg)
 	}
 }
 
@@ -239,17 +445,17 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessagePreThreshold(
 	messageKey coretypes.MessageKey,
 	validationInfo *signedMessageDigestValidationInfo,
 	timeToExpiry time.Duration,
-	signedMessageC <-chan SignedMessage,
+	activeMsg *activeMessage,
 ) (MessageBlsAggregationServiceMessage, bool) {
 	messageExpiredTimer := time.NewTimer(timeToExpiry)
 	defer messageExpiredTimer.Stop()
 
 	for {
 		select {
-		case signedMessage := <-signedMessageC:
+		case signedMessage := <-activeMsg.signedMessageC:
 			mbas.logger.Debug("Message goroutine received new signed message", "key", messageKey)
 
-			err := mbas.handleSignedMessageDigest(signedMessage, validationInfo)
+			err := mbas.handleActiveSignedMessage(signedMessage, validationInfo, activeMsg)
 			signedMessage.SignatureVerificationErrorC <- err
 			if err != nil {
 				continue
@@ -261,7 +467,7 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessagePreThreshold(
 				continue
 			}
 
-			mbas.aggregatedResponsesC <- aggregation
+			mbas.sendResponse(aggregation)
 
 			if aggregation.Status == MessageBlsAggregationStatusThresholdReached {
 				return signedMessage.Message, true
@@ -270,17 +476,11 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessagePreThreshold(
 			}
 		case <-messageExpiredTimer.C:
 			mbas.logger.Debug("Message expired", "key", messageKey)
-			mbas.aggregatedResponsesC <- MessageBlsAggregationServiceResponse{
-				MessageBlsAggregation: messages.MessageBlsAggregation{
-					EthBlockNumber: validationInfo.ethBlockNumber,
-				},
-				MessageKey: messageKey,
-				Message:    nil,
-				Status:     MessageBlsAggregationStatusNone,
-				Finished:   true,
-				Err:        MessageExpiredError,
-			}
+			mbas.sendResponse(mbas.getMessageExpiredResponse(messageKey, validationInfo))
 
+			return nil, false
+		case <-activeMsg.dropC:
+			mbas.sendDroppedResponse(messageKey, validationInfo)
 			return nil, false
 		}
 	}
@@ -289,9 +489,9 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessagePreThreshold(
 func (mbas *MessageBlsAggregatorService) handleSignedMessageThresholdReached(
 	message MessageBlsAggregationServiceMessage,
 	validationInfo *signedMessageDigestValidationInfo,
-	signedMessageC <-chan SignedMessage,
+	activeMsg *activeMessage,
 	aggregationTimeout time.Duration,
-) {
+) (dropped bool) {
 	thresholdReachedTimer := time.NewTimer(aggregationTimeout)
 	defer thresholdReachedTimer.Stop()
 
@@ -299,35 +499,105 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessageThresholdReached(
 	messageDigest, err := message.Digest()
 	if err != nil {
 		mbas.logger.Fatal("Failed to get message digest, should be unreachable", "err", err)
-		return
+		return false
 	}
 
 	for {
 		select {
-		case signedMessage := <-signedMessageC:
+		case signedMessage := <-activeMsg.signedMessageC:
 			mbas.logger.Debug("Message goroutine received new signed message", "key", messageKey)
 
+			// Non-majority digests are still recorded, so that
+			// operators signing both sides are caught
+			err := mbas.handleActiveSignedMessage(signedMessage, validationInfo, activeMsg)
+			signedMessage.SignatureVerificationErrorC <- err
+			if err != nil {
+				continue
+			}
+
 			if signedMessage.MessageDigest != messageDigest {
 				mbas.logger.Warn("Ignored signed message with non-majority digest", "expected", messageDigest, "got", signedMessage.MessageDigest)
-				signedMessage.SignatureVerificationErrorC <- nil
 				continue
 			}
 
-			err := mbas.handleSignedMessageDigest(signedMessage, validationInfo)
+			aggregation := mbas.getMessageBlsAggregationResponse(signedMessage.Message, signedMessage.MessageDigest, validationInfo, false)
+			mbas.sendResponse(aggregation)
+
+			if aggregation.Status == MessageBlsAggregationStatusFullStakeThresholdMet {
+				return false
+			}
+		case <-thresholdReachedTimer.C:
+			mbas.logger.Debug("Message expired", "key", messageKey)
+			mbas.sendResponse(mbas.getMessageBlsAggregationResponse(message, messageDigest, validationInfo, true))
+			return false
+		case <-activeMsg.dropC:
+			mbas.sendDroppedResponse(messageKey, validationInfo)
+			return true
+		}
+	}
+}
+
+// Late signatures on the aggregated digest reduce the non-signer set, which
+// makes the aggregation cheaper to verify onchain
+func (mbas *MessageBlsAggregatorService) handleLateSignedMessages(
+	message MessageBlsAggregationServiceMessage,
+	validationInfo *signedMessageDigestValidationInfo,
+	activeMsg *activeMessage,
+) {
+	messageKey := message.Key()
+	messageDigest, err := message.Digest()
+	if err != nil {
+		mbas.logger.Fatal("Failed to get message digest, should be unreachable", "err", err)
+		return
+	}
+
+	status, err := mbas.getMessageBlsAggregationStatus(messageDigest, validationInfo)
+	if err != nil {
+		mbas.logger.Error("Failed to get message aggregation status, not waiting for late signatures", "key", messageKey, "err", err)
+		return
+	}
+	if status == MessageBlsAggregationStatusFullStakeThresholdMet {
+		return
+	}
+
+	gracePeriodTimer := time.NewTimer(mbas.lateSignatureGracePeriod)
+	defer gracePeriodTimer.Stop()
+
+	for {
+		select {
+		case signedMessage := <-activeMsg.signedMessageC:
+			mbas.logger.Debug("Message goroutine received late signed message", "key", messageKey, "operatorId", signedMessage.OperatorId)
+
+			// Conflicting digests are still handled, so that they're reported
+			previousSignedMessage, ok := validationInfo.operatorSignaturesDict[signedMessage.OperatorId]
+			if ok && previousSignedMessage.MessageDigest == signedMessage.MessageDigest {
+				signedMessage.SignatureVerificationErrorC <- OperatorAlreadySignedError
+				continue
+			}
+
+			err := mbas.handleActiveSignedMessage(signedMessage, validationInfo, activeMsg)
 			signedMessage.SignatureVerificationErrorC <- err
 			if err != nil {
 				continue
 			}
 
-			aggregation := mbas.getMessageBlsAggregationResponse(signedMessage.Message, signedMessage.MessageDigest, validationInfo, false)
-			mbas.aggregatedResponsesC <- aggregation
+			if signedMessage.MessageDigest != messageDigest {
+				mbas.logger.Warn("Ignored late signed message with non-majority digest", "expected", messageDigest, "got", signedMessage.MessageDigest)
+				continue
+			}
+
+			aggregation := mbas.getMessageBlsAggregationResponse(message, messageDigest, validationInfo, true)
+			aggregation.Late = true
+			aggregation.LateSignerOperatorId = signedMessage.OperatorId
+			mbas.sendResponse(aggregation)
 
 			if aggregation.Status == MessageBlsAggregationStatusFullStakeThresholdMet {
 				return
 			}
-		case <-thresholdReachedTimer.C:
-			mbas.logger.Debug("Message expired", "key", messageKey)
-			mbas.aggregatedResponsesC <- mbas.getMessageBlsAggregationResponse(message, messageDigest, validationInfo, true)
+		case <-gracePeriodTimer.C:
+			mbas.logger.Debug("Message late signature grace period ended", "key", messageKey)
+			return
+		case <-activeMsg.dropC:
 			return
 		}
 	}
@@ -376,6 +646,7 @@ func (mbas *MessageBlsAggregatorService) fetchValidationInfo(quorumNumbers []eig
 		totalStakePerQuorum:           totalStakePerQuorum,
 		quorumApksG1:                  quorumApksG1,
 		aggregatedOperatorsDict:       make(map[eigentypes.TaskResponseDigest]AggregatedOperators),
+		operatorSignaturesDict:        make(map[eigentypes.OperatorId]SignedMessage),
 		quorumThresholdPercentagesMap: quorumThresholdPercentagesMap,
 		quorumNumbers:                 quorumNumbers,
 		ethBlockNumber:                ethBlockNumber,
@@ -391,8 +662,22 @@ const (
 	SignedMessageHandlingResultError
 )
 
+// Handles a signed message and updates the signer count of its message
+func (mbas *MessageBlsAggregatorService) handleActiveSignedMessage(signedMessage SignedMessage, validationInfo *signedMessageDigestValidationInfo, activeMsg *activeMessage) error {
+	err := mbas.handleSignedMessageDigest(signedMessage, validationInfo)
+	if err != nil {
+		return err
+	}
+
+	activeMsg.signersCount.Store(int32(len(validationInfo.operatorSignaturesDict)))
+	return nil
+}
+
 func (mbas *MessageBlsAggregatorService) handleSignedMessageDigest(signedMessage SignedMessage, validationInfo *signedMessageDigestValidationInfo) error {
-	err := mbas.verifySignature(signedMessage, validationInfo.operatorsAvsStateDict)
+	mbas.acquireWorker()
+	defer mbas.releaseWorker()
+
+	err := mbas.verifyOperator(signedMessage, validationInfo.operatorsAvsStateDict)
 	if err != nil {
 		return err
 	}
@@ -402,14 +687,31 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessageDigest(signedMessage
 		return err
 	}
 
+	previousSignedMessage, ok := validationInfo.operatorSignaturesDict[signedMessage.OperatorId]
+	if ok && previousSignedMessage.MessageDigest != digest {
+		mbas.reportEquivocation(previousSignedMessage, signedMessage, validationInfo)
+		return OperatorEquivocationError
+	}
+	if ok {
+		return OperatorAlreadySignedError
+	}
+	validationInfo.operatorSignaturesDict[signedMessage.OperatorId] = signedMessage
+
 	digestAggregatedOperators, ok := validationInfo.aggregatedOperatorsDict[digest]
 	if !ok {
+		// The signature and stakes are copied, as they are added to in place and
+		// the operator state may be shared with other messages
+		signersTotalStakePerQuorum := make(map[eigentypes.QuorumNum]*big.Int)
+		for quorumNum, stake := range validationInfo.operatorsAvsStateDict[signedMessage.OperatorId].StakePerQuorum {
+			signersTotalStakePerQuorum[quorumNum] = new(big.Int).Set(stake)
+		}
+
 		digestAggregatedOperators = AggregatedOperators{
 			// we've already verified that the operator is part of the task's quorum, so we don't need checks here
 			signersApkG2:               bls.NewZeroG2Point().Add(validationInfo.operatorsAvsStateDict[signedMessage.OperatorId].OperatorInfo.Pubkeys.G2Pubkey),
-			signersAggSigG1:            signedMessage.BlsSignature,
+			signersAggSigG1:            bls.NewZeroSignature().Add(signedMessage.BlsSignature),
 			signersOperatorIdsSet:      map[eigentypes.OperatorId]bool{signedMessage.OperatorId: true},
-			signersTotalStakePerQuorum: validationInfo.operatorsAvsStateDict[signedMessage.OperatorId].StakePerQuorum,
+			signersTotalStakePerQuorum: signersTotalStakePerQuorum,
 		}
 	} else {
 		digestAggregatedOperators.signersAggSigG1.Add(signedMessage.BlsSignature)
@@ -429,6 +731,51 @@ func (mbas *MessageBlsAggregatorService) handleSignedMessageDigest(signedMessage
 	return nil
 }
 
+// Both signed messages must already be verified. The conflicting signature
+// is not aggregated, so an equivocating operator only counts towards the
+// digest it signed first. Evidence is dropped if the channel is full, as the
+// worker slot is held meanwhile.
+func (mbas *MessageBlsAggregatorService) reportEquivocation(firstSignedMessage, secondSignedMessage SignedMessage, validationInfo *signedMessageDigestValidationInfo) {
+	mbas.logger.Error("Operator signed conflicting message digests",
+		"operatorId", secondSignedMessage.OperatorId,
+		"key", secondSignedMessage.Message.Key(),
+		"firstDigest", firstSignedMessage.MessageDigest,
+		"secondDigest", secondSignedMessage.MessageDigest,
+	)
+
+	firstMessage, err := json.Marshal(firstSignedMessage.Message)
+	if err != nil {
+		mbas.logger.Error("Error encoding equivocated message", "err", err)
+		return
+	}
+
+	secondMessage, err := json.Marshal(secondSignedMessage.Message)
+	if err != nil {
+		mbas.logger.Error("Error encoding equivocated message", "err", err)
+		return
+	}
+
+	evidence := messages.EquivocationEvidence{
+		MessageType:     mbas.messageType,
+		MessageKey:      secondSignedMessage.Message.Key(),
+		OperatorId:      secondSignedMessage.OperatorId,
+		EthBlockNumber:  validationInfo.ethBlockNumber,
+		FirstMessage:    firstMessage,
+		FirstDigest:     firstSignedMessage.MessageDigest,
+		FirstSignature:  firstSignedMessage.BlsSignature,
+		SecondMessage:   secondMessage,
+		SecondDigest:    secondSignedMessage.MessageDigest,
+		SecondSignature: secondSignedMessage.BlsSignature,
+	}
+
+	select {
+	case mbas.equivocationsC <- evidence:
+	default:
+		mbas.logger.Warn("Equivocation channel full, dropping evidence", "operatorId", evidence.OperatorId, "key", evidence.MessageKey)
+		mbas.listener.OnEquivocationDropped(string(mbas.messageType))
+	}
+}
+
 func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationStatus(messageDigest coretypes.MessageDigest, validationInfo *signedMessageDigestValidationInfo) (MessageBlsAggregationStatus, error) {
 	digestAggregatedOperators, ok := validationInfo.aggregatedOperatorsDict[messageDigest]
 	if !ok {
@@ -447,7 +794,19 @@ func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationStatus(messageD
 	return MessageBlsAggregationStatusThresholdNotReached, nil
 }
 
+// The worker is only held while aggregating, and not during the
+// GetCheckSignaturesIndices call
 func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationResponse(message MessageBlsAggregationServiceMessage, messageDigest coretypes.MessageDigest, validationInfo *signedMessageDigestValidationInfo, forceFinished bool) MessageBlsAggregationServiceResponse {
+	mbas.acquireWorker()
+	workerReleased := false
+	releaseWorker := func() {
+		if !workerReleased {
+			workerReleased = true
+			mbas.releaseWorker()
+		}
+	}
+	defer releaseWorker()
+
 	defaultAggregation := messages.MessageBlsAggregation{
 		MessageDigest:  messageDigest,
 		EthBlockNumber: validationInfo.ethBlockNumber,
@@ -477,18 +836,18 @@ func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationResponse(messag
 		}
 	}
 
+	signersOperatorIds := []eigentypes.OperatorId{}
 	nonSignersOperatorIds := []eigentypes.OperatorId{}
 	for operatorId := range validationInfo.operatorsAvsStateDict {
-		if _, operatorSigned := digestAggregatedOperators.signersOperatorIdsSet[operatorId]; !operatorSigned {
+		if _, operatorSigned := digestAggregatedOperators.signersOperatorIdsSet[operatorId]; operatorSigned {
+			signersOperatorIds = append(signersOperatorIds, operatorId)
+		} else {
 			nonSignersOperatorIds = append(nonSignersOperatorIds, operatorId)
 		}
 	}
 
-	sort.SliceStable(nonSignersOperatorIds, func(i, j int) bool {
-		a := new(big.Int).SetBytes(nonSignersOperatorIds[i][:])
-		b := new(big.Int).SetBytes(nonSignersOperatorIds[j][:])
-		return a.Cmp(b) == -1
-	})
+	sortOperatorIds(signersOperatorIds)
+	sortOperatorIds(nonSignersOperatorIds)
 
 	nonSignersG1Pubkeys := []*bls.G1Point{}
 	for _, operatorId := range nonSignersOperatorIds {
@@ -496,6 +855,8 @@ func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationResponse(messag
 		nonSignersG1Pubkeys = append(nonSignersG1Pubkeys, operator.OperatorInfo.Pubkeys.G1Pubkey)
 	}
 
+	releaseWorker()
+
 	indices, err := mbas.avsRegistryService.GetCheckSignaturesIndices(&bind.CallOpts{}, uint32(validationInfo.ethBlockNumber), validationInfo.quorumNumbers, nonSignersOperatorIds)
 	if err != nil {
 		mbas.logger.Error("Failed to get check signatures indices", "err", err)
@@ -521,17 +882,6 @@ func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationResponse(messag
 		TotalStakeIndices:            indices.TotalStakeIndices,
 		NonSignerStakeIndices:        indices.NonSignerStakeIndices,
 	}
-	if err != nil {
-		mbas.logger.Error("Failed to format aggregation", "err", err)
-		return MessageBlsAggregationServiceResponse{
-			MessageBlsAggregation: defaultAggregation,
-			Message:               message,
-			MessageKey:            message.Key(),
-			Status:                MessageBlsAggregationStatusNone,
-			Finished:              forceFinished,
-			Err:                   err,
-		}
-	}
 
 	fullStakeThresholdMet := status == MessageBlsAggregationStatusFullStakeThresholdMet
 
@@ -542,16 +892,118 @@ func (mbas *MessageBlsAggregatorService) getMessageBlsAggregationResponse(messag
 		MessageBlsAggregation: aggregation,
 		Message:               message,
 		MessageKey:            message.Key(),
+		SignersOperatorIds:    signersOperatorIds,
+		NonSignersOperatorIds: nonSignersOperatorIds,
 	}
 }
 
-func (mbas *MessageBlsAggregatorService) closeMessageGoroutine(messageDigest coretypes.MessageDigest) {
+// Expired responses list the operators that signed any digest as signers, and
+// carry one of the signed messages if there's any
+func (mbas *MessageBlsAggregatorService) getMessageExpiredResponse(messageKey coretypes.MessageKey, validationInfo *signedMessageDigestValidationInfo) MessageBlsAggregationServiceResponse {
+	var message MessageBlsAggregationServiceMessage
+	signersOperatorIds := []eigentypes.OperatorId{}
+	nonSignersOperatorIds := []eigentypes.OperatorId{}
+	for operatorId := range validationInfo.operatorsAvsStateDict {
+		if signedMessage, operatorSigned := validationInfo.operatorSignaturesDict[operatorId]; operatorSigned {
+			message = signedMessage.Message
+			signersOperatorIds = append(signersOperatorIds, operatorId)
+		} else {
+			nonSignersOperatorIds = append(nonSignersOperatorIds, operatorId)
+		}
+	}
+
+	sortOperatorIds(signersOperatorIds)
+	sortOperatorIds(nonSignersOperatorIds)
+
+	return MessageBlsAggregationServiceResponse{
+		MessageBlsAggregation: messages.MessageBlsAggregation{
+			EthBlockNumber: validationInfo.ethBlockNumber,
+		},
+		MessageKey:            messageKey,
+		Message:               message,
+		Status:                MessageBlsAggregationStatusNone,
+		Finished:              true,
+		Err:                   MessageExpiredError,
+		SignersOperatorIds:    signersOperatorIds,
+		NonSignersOperatorIds: nonSignersOperatorIds,
+	}
+}
+
+func (mbas *MessageBlsAggregatorService) sendDroppedResponse(messageKey coretypes.MessageKey, validationInfo *signedMessageDigestValidationInfo) {
+	mbas.sendResponse(MessageBlsAggregationServiceResponse{
+		MessageBlsAggregation: messages.MessageBlsAggregation{
+			EthBlockNumber: validationInfo.ethBlockNumber,
+		},
+		MessageKey: messageKey,
+		Message:    nil,
+		Status:     MessageBlsAggregationStatusNone,
+		Finished:   true,
+		Err:        MessageDroppedError,
+	})
+}
+
+// Frees the message's active message slot, keeping it open for late
+// signatures
+func (mbas *MessageBlsAggregatorService) finishAggregation(activeMsg *activeMessage) {
 	mbas.messageChansLock.Lock()
-	delete(mbas.signedMessageCs, messageDigest)
+	defer mbas.messageChansLock.Unlock()
+
+	mbas.finishAggregationLocked(activeMsg)
+}
+
+// Must be called with messageChansLock held
+func (mbas *MessageBlsAggregatorService) finishAggregationLocked(activeMsg *activeMessage) {
+	if !activeMsg.aggregating {
+		return
+	}
+
+	activeMsg.aggregating = false
+	mbas.aggregatingMessages--
+	mbas.listener.OnActiveMessages(string(mbas.messageType), mbas.aggregatingMessages)
+}
+
+func (mbas *MessageBlsAggregatorService) closeMessageGoroutine(messageKey coretypes.MessageKey, activeMsg *activeMessage) {
+	mbas.messageChansLock.Lock()
+	if mbas.activeMessages[messageKey] == activeMsg {
+		delete(mbas.activeMessages, messageKey)
+	}
+	mbas.finishAggregationLocked(activeMsg)
 	mbas.messageChansLock.Unlock()
 }
 
-func (mbas *MessageBlsAggregatorService) verifySignature(
+// Sends a response to its message's shard, blocking while the shard is full
+func (mbas *MessageBlsAggregatorService) sendResponse(response MessageBlsAggregationServiceResponse) {
+	shard := mbas.responseShard(response.MessageKey)
+	responseC := mbas.aggregatedResponsesCs[shard]
+
+	responseC <- response
+	mbas.listener.OnResponseQueueDepth(string(mbas.messageType), shard, len(responseC))
+}
+
+// Message keys are often small numbers padded to 32 bytes, so the whole key is
+// hashed
+func (mbas *MessageBlsAggregatorService) responseShard(messageKey coretypes.MessageKey) int {
+	hash := fnv.New64a()
+	hash.Write(messageKey[:])
+
+	return int(hash.Sum64() % uint64(len(mbas.aggregatedResponsesCs)))
+}
+
+// Blocks until one of the workers is free, bounding the signed messages
+// handled at once
+func (mbas *MessageBlsAggregatorService) acquireWorker() {
+	mbas.workerSlots <- struct{}{}
+	mbas.listener.OnBusyWorkers(string(mbas.messageType), len(mbas.workerSlots))
+}
+
+func (mbas *MessageBlsAggregatorService) releaseWorker() {
+	<-mbas.workerSlots
+	mbas.listener.OnBusyWorkers(string(mbas.messageType), len(mbas.workerSlots))
+}
+
+// Signatures are verified against the operator's pubkey on ingress, so only
+// the operator's membership in the message's quorums is checked here
+func (mbas *MessageBlsAggregatorService) verifyOperator(
 	signedMessage SignedMessage,
 	operatorsAvsStateDict map[eigentypes.OperatorId]eigentypes.OperatorAvsState,
 ) error {
@@ -561,28 +1013,17 @@ func (mbas *MessageBlsAggregatorService) verifySignature(
 		return OperatorNotPartOfMessageQuorumErrorFn(signedMessage.OperatorId, signedMessage.MessageDigest)
 	}
 
-	// 0. verify that the msg actually came from the correct operator
-	operatorG2Pubkey := operatorsAvsStateDict[signedMessage.OperatorId].OperatorInfo.Pubkeys.G2Pubkey
-	if operatorG2Pubkey == nil {
-		mbas.logger.Fatal("Operator G2 pubkey not found")
-	}
-	mbas.logger.Debug("Verifying signed message digest signature",
-		"operatorG2Pubkey", operatorG2Pubkey,
-		"messageDigest", signedMessage.MessageDigest,
-		"blsSignature", signedMessage.BlsSignature,
-	)
-	signatureVerified, err := signedMessage.BlsSignature.Verify(operatorG2Pubkey, signedMessage.MessageDigest)
-	if err != nil {
-		mbas.logger.Error(SignatureVerificationError(err).Error())
-		return SignatureVerificationError(err)
-	}
-	if !signatureVerified {
-		mbas.logger.Error(IncorrectSignatureError.Error())
-		return IncorrectSignatureError
-	}
 	return nil
 }
 
+func sortOperatorIds(operatorIds []eigentypes.OperatorId) {
+	sort.SliceStable(operatorIds, func(i, j int) bool {
+		a := new(big.Int).SetBytes(operatorIds[i][:])
+		b := new(big.Int).SetBytes(operatorIds[j][:])
+		return a.Cmp(b) == -1
+	})
+}
+
 func checkIfStakeThresholdsMet(
 	signedStakePerQuorum map[eigentypes.QuorumNum]*big.Int,
 	totalStakePerQuorum map[eigentypes.QuorumNum]*big.Int,
@@ -0,0 +1,452 @@
+package blsagg
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/assert"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const TEST_ETH_BLOCK_NUMBER = 1
+
+func createTestOperators(n int) []eigentypes.TestOperator {
+	operators := make([]eigentypes.TestOperator, n)
+	for i := range operators {
+		privateKey, err := bls.NewPrivateKey(fmt.Sprintf("%d", i+1))
+		if err != nil {
+			panic(err)
+		}
+
+		operators[i] = eigentypes.TestOperator{
+			OperatorId:     eigentypes.OperatorId{byte(i + 1)},
+			StakePerQuorum: map[eigentypes.QuorumNum]eigentypes.StakeAmount{0: big.NewInt(100)},
+			BlsKeypair:     bls.NewKeyPair(privateKey),
+		}
+	}
+
+	return operators
+}
+
+func signTestMessage(t *testing.T, mbas *MessageBlsAggregatorService, operator eigentypes.TestOperator, message messages.StateRootUpdateMessage) error {
+	digest, err := message.Digest()
+	assert.NoError(t, err)
+
+	return mbas.ProcessNewSignature(context.Background(), message, operator.BlsKeypair.SignMessage(digest), operator.OperatorId)
+}
+
+func receiveResponse(t *testing.T, mbas *MessageBlsAggregatorService, messageKey coretypes.MessageKey) MessageBlsAggregationServiceResponse {
+	select {
+	case response := <-mbas.GetResponseChannels()[mbas.responseShard(messageKey)]:
+		return response
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for aggregation response")
+		return MessageBlsAggregationServiceResponse{}
+	}
+}
+
+func TestLateSignatures(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger()).
+		WithLateSignatureGracePeriod(time.Second)
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, 50*time.Millisecond, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+	assert.NoError(t, signTestMessage(t, mbas, operators[1], message))
+
+	response := receiveResponse(t, mbas, message.Key())
+	assert.Equal(t, MessageBlsAggregationStatusThresholdReached, response.Status)
+	assert.False(t, response.Finished)
+
+	// Aggregation timeout
+	response = receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Finished)
+	assert.False(t, response.Late)
+	assert.Len(t, response.NonSignersPubkeysG1, 1)
+	assert.Equal(t, []eigentypes.OperatorId{operators[0].OperatorId, operators[1].OperatorId}, response.SignersOperatorIds)
+	assert.Equal(t, []eigentypes.OperatorId{operators[2].OperatorId}, response.NonSignersOperatorIds)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[2], message))
+
+	response = receiveResponse(t, mbas, message.Key())
+	assert.NoError(t, response.Err)
+	assert.Equal(t, MessageBlsAggregationStatusFullStakeThresholdMet, response.Status)
+	assert.True(t, response.Finished)
+	assert.True(t, response.Late)
+	assert.Equal(t, operators[2].OperatorId, response.LateSignerOperatorId)
+	assert.Empty(t, response.NonSignersPubkeysG1)
+	assert.Empty(t, response.NonSignersOperatorIds)
+
+	// Full stake closes the message before the grace period ends
+	assert.Eventually(t, func() bool {
+		err := signTestMessage(t, mbas, operators[2], message)
+		return err != nil && err.Error() == MessageNotFoundErrorFn(message.Key()).Error()
+	}, 500*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestLateSignatureGracePeriod(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger()).
+		WithLateSignatureGracePeriod(100 * time.Millisecond)
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, 10*time.Millisecond, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+	assert.NoError(t, signTestMessage(t, mbas, operators[1], message))
+
+	receiveResponse(t, mbas, message.Key())
+	response := receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Finished)
+
+	time.Sleep(200 * time.Millisecond)
+
+	err = signTestMessage(t, mbas, operators[2], message)
+	assert.EqualError(t, err, MessageNotFoundErrorFn(message.Key()).Error())
+}
+
+func TestLateSignatureResentDuringGracePeriod(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger()).
+		WithLateSignatureGracePeriod(time.Second)
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, 10*time.Millisecond, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+	assert.NoError(t, signTestMessage(t, mbas, operators[1], message))
+
+	receiveResponse(t, mbas, message.Key())
+	response := receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Finished)
+
+	// The message no longer holds an aggregation slot, but still takes late
+	// signatures
+	mbas.messageChansLock.RLock()
+	assert.Zero(t, mbas.aggregatingMessages)
+	assert.Len(t, mbas.activeMessages, 1)
+	mbas.messageChansLock.RUnlock()
+
+	err = signTestMessage(t, mbas, operators[1], message)
+	assert.ErrorIs(t, err, OperatorAlreadySignedError)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[2], message))
+
+	response = receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Late)
+	assert.Equal(t, operators[2].OperatorId, response.LateSignerOperatorId)
+	assert.Len(t, response.SignersOperatorIds, 3)
+
+	select {
+	case response := <-mbas.GetResponseChannels()[mbas.responseShard(message.Key())]:
+		t.Fatalf("Unexpected response from resent signature: %+v", response)
+	default:
+	}
+}
+
+func TestExpiredMessageResponseListsSigners(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, 50*time.Millisecond, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+
+	response := receiveResponse(t, mbas, message.Key())
+	assert.ErrorIs(t, response.Err, MessageExpiredError)
+	assert.True(t, response.Finished)
+	assert.Equal(t, message, response.Message)
+	assert.Equal(t, []eigentypes.OperatorId{operators[0].OperatorId}, response.SignersOperatorIds)
+	assert.Equal(t, []eigentypes.OperatorId{operators[1].OperatorId, operators[2].OperatorId}, response.NonSignersOperatorIds)
+}
+
+func TestLateSignaturesDisabled(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, 10*time.Millisecond, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+	assert.NoError(t, signTestMessage(t, mbas, operators[1], message))
+
+	receiveResponse(t, mbas, message.Key())
+	response := receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Finished)
+
+	assert.Eventually(t, func() bool {
+		err := signTestMessage(t, mbas, operators[2], message)
+		return err != nil && err.Error() == MessageNotFoundErrorFn(message.Key()).Error()
+	}, 500*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestMessageAdmissionControl(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	var rejected int
+	mbas.SetListener(&SelectiveListener{
+		OnMessageRejectedCb: func(messageType string) { rejected++ },
+	})
+
+	// Failed initializations don't hold a slot
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER+1)
+	assert.Error(t, err)
+	assert.Empty(t, mbas.activeMessages)
+
+	for i := 0; i < MAX_ACTIVE_MESSAGES; i++ {
+		_, err := mbas.initializeMessageChan(coretypes.MessageKey{byte(i), byte(i >> 8), 1})
+		assert.NoError(t, err)
+	}
+
+	err = mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.ErrorIs(t, err, AggregatorOverloadedError)
+	assert.Equal(t, 1, rejected)
+
+	// Already active keys are still accepted
+	existingKey := coretypes.MessageKey{0, 0, 1}
+	err = mbas.InitializeMessageIfNotExists(existingKey, TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+}
+
+func TestResponsesShardedByMessageKey(t *testing.T) {
+	operators := createTestOperators(1)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	// Nothing consumes the responses, which are buffered instead of blocking
+	// the message goroutines
+	numMessages := 2 * RESPONSE_SHARDS
+	for i := 0; i < numMessages; i++ {
+		message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: uint64(i)}
+		err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+		assert.NoError(t, err)
+		assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+	}
+
+	assert.Eventually(t, func() bool {
+		queued := 0
+		for _, responseC := range mbas.GetResponseChannels() {
+			queued += len(responseC)
+		}
+		return queued == numMessages
+	}, time.Second, 10*time.Millisecond)
+
+	received := 0
+	usedShards := 0
+	for shard, responseC := range mbas.GetResponseChannels() {
+		if len(responseC) > 0 {
+			usedShards++
+		}
+
+		for len(responseC) > 0 {
+			response := <-responseC
+			assert.Equal(t, shard, mbas.responseShard(response.MessageKey))
+			received++
+		}
+	}
+	assert.Equal(t, numMessages, received)
+	assert.Greater(t, usedShards, 1)
+}
+
+func TestAggregationLimits(t *testing.T) {
+	operators := createTestOperators(1)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger()).
+		WithAggregationLimits(AggregationLimits{MaxActiveMessages: 1, ResponseShards: 2})
+
+	assert.Len(t, mbas.GetResponseChannels(), 2)
+	assert.Equal(t, SIGNED_MESSAGE_WORKERS, cap(mbas.workerSlots))
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	otherMessage := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 3}
+	err = mbas.InitializeMessageIfNotExists(otherMessage.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.ErrorIs(t, err, AggregatorOverloadedError)
+}
+
+func TestDropMessage(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Minute, time.Minute, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+
+	activeMessages := mbas.GetActiveMessages()
+	assert.Len(t, activeMessages, 1)
+	assert.Equal(t, messages.StateRootUpdateMessageType, activeMessages[0].MessageType)
+	assert.Equal(t, message.Key(), activeMessages[0].MessageKey)
+	assert.Equal(t, 1, activeMessages[0].SignersCount)
+	assert.False(t, activeMessages[0].ThresholdReached)
+
+	err = mbas.DropMessage(message.Key())
+	assert.NoError(t, err)
+
+	response := receiveResponse(t, mbas, message.Key())
+	assert.True(t, response.Finished)
+	assert.ErrorIs(t, response.Err, MessageDroppedError)
+
+	assert.Empty(t, mbas.GetActiveMessages())
+	assert.Error(t, mbas.DropMessage(message.Key()))
+
+	err = signTestMessage(t, mbas, operators[1], message)
+	assert.EqualError(t, err, MessageNotFoundErrorFn(message.Key()).Error())
+
+	// The message can be initialized again
+	err = mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Minute, time.Minute, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+	assert.Len(t, mbas.GetActiveMessages(), 1)
+}
+
+func TestConflictingDigestsFromOneOperator(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{1}}
+	conflictingMessage := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{2}}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+
+	err = signTestMessage(t, mbas, operators[0], conflictingMessage)
+	assert.ErrorIs(t, err, OperatorEquivocationError)
+
+	select {
+	case evidence := <-mbas.GetEquivocationChannel():
+		assert.Equal(t, messages.StateRootUpdateMessageType, evidence.MessageType)
+		assert.Equal(t, message.Key(), evidence.MessageKey)
+		assert.Equal(t, operators[0].OperatorId, evidence.OperatorId)
+		assert.NoError(t, evidence.Verify(operators[0].BlsKeypair.GetPubKeyG2()))
+
+		firstMessage, err := messages.DecodeMessage(evidence.MessageType, evidence.FirstMessage)
+		assert.NoError(t, err)
+		assert.Equal(t, message, firstMessage)
+
+		secondMessage, err := messages.DecodeMessage(evidence.MessageType, evidence.SecondMessage)
+		assert.NoError(t, err)
+		assert.Equal(t, conflictingMessage, secondMessage)
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for equivocation evidence")
+	}
+
+	// Repeated signatures aren't aggregated again
+	err = signTestMessage(t, mbas, operators[0], message)
+	assert.ErrorIs(t, err, OperatorAlreadySignedError)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[1], message))
+
+	response := receiveResponse(t, mbas, message.Key())
+	assert.NoError(t, response.Err)
+	assert.Equal(t, MessageBlsAggregationStatusThresholdReached, response.Status)
+	assert.Equal(t, []eigentypes.OperatorId{operators[0].OperatorId, operators[1].OperatorId}, response.SignersOperatorIds)
+}
+
+func TestEquivocationDroppedWhenChannelFull(t *testing.T) {
+	operators := createTestOperators(3)
+	registry := avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators)
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger())
+	mbas.equivocationsC = make(chan messages.EquivocationEvidence)
+
+	dropped := make(chan string, 1)
+	mbas.SetListener(&SelectiveListener{OnEquivocationDroppedCb: func(messageType string) { dropped <- messageType }})
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{1}}
+	conflictingMessage := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2, StateRoot: [32]byte{2}}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+
+	// Not blocked by the unread channel
+	err = signTestMessage(t, mbas, operators[0], conflictingMessage)
+	assert.ErrorIs(t, err, OperatorEquivocationError)
+	assert.Equal(t, string(messages.StateRootUpdateMessageType), <-dropped)
+}
+
+// Blocks GetCheckSignaturesIndices calls until released
+type blockingIndicesAvsRegistryService struct {
+	*avsregistry.FakeAvsRegistryService
+
+	calledC  chan struct{}
+	releaseC chan struct{}
+}
+
+func (s *blockingIndicesAvsRegistryService) GetCheckSignaturesIndices(opts *bind.CallOpts, referenceBlockNumber eigentypes.BlockNum, quorumNumbers eigentypes.QuorumNums, nonSignerOperatorIds []eigentypes.OperatorId) (opstateretriever.OperatorStateRetrieverCheckSignaturesIndices, error) {
+	s.calledC <- struct{}{}
+	<-s.releaseC
+
+	return s.FakeAvsRegistryService.GetCheckSignaturesIndices(opts, referenceBlockNumber, quorumNumbers, nonSignerOperatorIds)
+}
+
+func TestWorkerReleasedDuringCheckSignaturesIndicesCall(t *testing.T) {
+	operators := createTestOperators(1)
+	registry := &blockingIndicesAvsRegistryService{
+		FakeAvsRegistryService: avsregistry.NewFakeAvsRegistryService(TEST_ETH_BLOCK_NUMBER, operators),
+		calledC:                make(chan struct{}, 2),
+		releaseC:               make(chan struct{}),
+	}
+	mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logging.NewNoopLogger()).
+		WithAggregationLimits(AggregationLimits{SignedMessageWorkers: 1})
+
+	message := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 2}
+	err := mbas.InitializeMessageIfNotExists(message.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+	assert.NoError(t, signTestMessage(t, mbas, operators[0], message))
+
+	select {
+	case <-registry.calledC:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for GetCheckSignaturesIndices call")
+	}
+
+	// The only worker is free while the first message waits on the call
+	otherMessage := messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 3}
+	err = mbas.InitializeMessageIfNotExists(otherMessage.Key(), TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, time.Second, time.Second, TEST_ETH_BLOCK_NUMBER)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	digest, err := otherMessage.Digest()
+	assert.NoError(t, err)
+	err = mbas.ProcessNewSignature(ctx, otherMessage, operators[0].BlsKeypair.SignMessage(digest), operators[0].OperatorId)
+	assert.NoError(t, err)
+
+	close(registry.releaseC)
+	receiveResponse(t, mbas, message.Key())
+	receiveResponse(t, mbas, otherMessage.Key())
+}
@@ -0,0 +1,178 @@
+package blsagg
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Nuffle-Labs/nffl/core"
+)
+
+const (
+	// Number of (quorums, block) snapshots kept, evicting the oldest blocks first
+	OPERATOR_STATE_CACHE_SIZE = 128
+)
+
+type operatorStateCacheKey struct {
+	quorumNumbers string
+	blockNumber   eigentypes.BlockNum
+}
+
+type operatorStateCacheEntry struct {
+	ready chan struct{}
+
+	operatorsAvsState map[eigentypes.OperatorId]eigentypes.OperatorAvsState
+	quorumsAvsState   map[eigentypes.QuorumNum]eigentypes.QuorumAvsState
+	err               error
+}
+
+// OperatorStateCache is an AvsRegistryService that caches operator and
+// quorum state snapshots by quorums and block, so that messages validated
+// against the same Ethereum block share the registry calls. Concurrent misses
+// for the same snapshot wait for a single fetch, and failed fetches aren't
+// cached.
+//
+// The returned maps are shared between callers and must not be modified.
+type OperatorStateCache struct {
+	avsregistry.AvsRegistryService
+
+	entries  map[operatorStateCacheKey]*operatorStateCacheEntry
+	lock     sync.Mutex
+	listener EventListener
+	logger   logging.Logger
+}
+
+var _ avsregistry.AvsRegistryService = (*OperatorStateCache)(nil)
+var _ core.Metricable = (*OperatorStateCache)(nil)
+
+func NewOperatorStateCache(avsRegistryService avsregistry.AvsRegistryService, logger logging.Logger) *OperatorStateCache {
+	return &OperatorStateCache{
+		AvsRegistryService: avsRegistryService,
+		entries:            make(map[operatorStateCacheKey]*operatorStateCacheEntry),
+		listener:           &SelectiveListener{},
+		logger:             logger,
+	}
+}
+
+func (c *OperatorStateCache) EnableMetrics(registry *prometheus.Registry) error {
+	listener, err := MakeOperatorStateCacheMetrics(registry)
+	if err != nil {
+		return err
+	}
+
+	c.listener = listener
+	return nil
+}
+
+func (c *OperatorStateCache) GetOperatorsAvsStateAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) (map[eigentypes.OperatorId]eigentypes.OperatorAvsState, error) {
+	entry, err := c.getEntry(ctx, quorumNumbers, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.operatorsAvsState, nil
+}
+
+func (c *OperatorStateCache) GetQuorumsAvsStateAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) (map[eigentypes.QuorumNum]eigentypes.QuorumAvsState, error) {
+	entry, err := c.getEntry(ctx, quorumNumbers, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.quorumsAvsState, nil
+}
+
+// Drops the snapshots at or after a block, as an operator set update at that
+// block may have changed them
+func (c *OperatorStateCache) InvalidateFromBlock(blockNumber eigentypes.BlockNum) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	invalidated := 0
+	for key := range c.entries {
+		if key.blockNumber >= blockNumber {
+			delete(c.entries, key)
+			invalidated++
+		}
+	}
+
+	c.logger.Debug("Invalidated operator state snapshots", "fromBlock", blockNumber, "count", invalidated)
+	c.listener.OnOperatorStateCacheInvalidation(invalidated)
+}
+
+func (c *OperatorStateCache) getEntry(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) (*operatorStateCacheEntry, error) {
+	key := operatorStateCacheKey{
+		quorumNumbers: string(quorumNumbers.UnderlyingType()),
+		blockNumber:   blockNumber,
+	}
+
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &operatorStateCacheEntry{ready: make(chan struct{})}
+		c.entries[key] = entry
+		c.evict()
+	}
+	c.lock.Unlock()
+
+	if ok {
+		c.listener.OnOperatorStateCacheHit()
+
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		return entry, entry.err
+	}
+
+	c.listener.OnOperatorStateCacheMiss()
+	c.fetchEntry(ctx, entry, quorumNumbers, blockNumber)
+
+	if entry.err != nil {
+		c.lock.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.lock.Unlock()
+	}
+
+	return entry, entry.err
+}
+
+func (c *OperatorStateCache) fetchEntry(ctx context.Context, entry *operatorStateCacheEntry, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) {
+	defer close(entry.ready)
+
+	entry.operatorsAvsState, entry.err = c.AvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, quorumNumbers, blockNumber)
+	if entry.err != nil {
+		return
+	}
+
+	entry.quorumsAvsState, entry.err = c.AvsRegistryService.GetQuorumsAvsStateAtBlock(ctx, quorumNumbers, blockNumber)
+}
+
+// Must be called with the lock held
+func (c *OperatorStateCache) evict() {
+	if len(c.entries) <= OPERATOR_STATE_CACHE_SIZE {
+		return
+	}
+
+	keys := make([]operatorStateCacheKey, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].blockNumber < keys[j].blockNumber
+	})
+
+	for _, key := range keys[:len(keys)-OPERATOR_STATE_CACHE_SIZE] {
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,177 @@
+package blsagg
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const TEST_MESSAGES_PER_BLOCK = 50
+
+var TEST_QUORUM_NUMBERS = eigentypes.QuorumNums{0}
+var TEST_QUORUM_THRESHOLDS = []eigentypes.QuorumThresholdPercentage{66}
+
+// Counts registry calls, simulating their RPC latency
+type countingAvsRegistryService struct {
+	avsregistry.AvsRegistryService
+	calls   atomic.Int64
+	latency time.Duration
+	err     error
+}
+
+func (s *countingAvsRegistryService) GetOperatorsAvsStateAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) (map[eigentypes.OperatorId]eigentypes.OperatorAvsState, error) {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+
+	return map[eigentypes.OperatorId]eigentypes.OperatorAvsState{
+		{1}: {
+			OperatorId:     eigentypes.OperatorId{1},
+			StakePerQuorum: map[eigentypes.QuorumNum]*big.Int{0: big.NewInt(100)},
+			BlockNumber:    blockNumber,
+		},
+	}, s.err
+}
+
+func (s *countingAvsRegistryService) GetQuorumsAvsStateAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber eigentypes.BlockNum) (map[eigentypes.QuorumNum]eigentypes.QuorumAvsState, error) {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+
+	return map[eigentypes.QuorumNum]eigentypes.QuorumAvsState{
+		0: {
+			QuorumNumber: 0,
+			TotalStake:   big.NewInt(100),
+			AggPubkeyG1:  bls.NewZeroG1Point(),
+			BlockNumber:  blockNumber,
+		},
+	}, s.err
+}
+
+func TestOperatorStateCache(t *testing.T) {
+	registry := &countingAvsRegistryService{}
+	cache := NewOperatorStateCache(registry, logging.NewNoopLogger())
+
+	var hits, misses, invalidations int
+	cache.listener = &SelectiveListener{
+		OnOperatorStateCacheHitCb:          func() { hits++ },
+		OnOperatorStateCacheMissCb:         func() { misses++ },
+		OnOperatorStateCacheInvalidationCb: func(count int) { invalidations += count },
+	}
+
+	ctx := context.Background()
+	for _, blockNumber := range []eigentypes.BlockNum{1, 1, 2, 2} {
+		_, err := cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, blockNumber)
+		assert.NoError(t, err)
+		_, err = cache.GetQuorumsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, blockNumber)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int64(4), registry.calls.Load())
+	assert.Equal(t, 6, hits)
+	assert.Equal(t, 2, misses)
+
+	// Snapshots at or after the update are dropped
+	cache.InvalidateFromBlock(2)
+	assert.Equal(t, 1, invalidations)
+
+	_, err := cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, 1)
+	assert.NoError(t, err)
+	_, err = cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), registry.calls.Load())
+
+	// Other quorums are cached separately
+	_, err = cache.GetOperatorsAvsStateAtBlock(ctx, eigentypes.QuorumNums{0, 1}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), registry.calls.Load())
+}
+
+func TestOperatorStateCacheErrorNotCached(t *testing.T) {
+	registry := &countingAvsRegistryService{err: errors.New("rpc error")}
+	cache := NewOperatorStateCache(registry, logging.NewNoopLogger())
+
+	ctx := context.Background()
+	_, err := cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, 1)
+	assert.Error(t, err)
+
+	registry.err = nil
+	_, err = cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), registry.calls.Load())
+}
+
+func TestOperatorStateCacheConcurrentMisses(t *testing.T) {
+	registry := &countingAvsRegistryService{latency: 10 * time.Millisecond}
+	cache := NewOperatorStateCache(registry, logging.NewNoopLogger())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := cache.GetOperatorsAvsStateAtBlock(context.Background(), TEST_QUORUM_NUMBERS, 1)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(2), registry.calls.Load())
+}
+
+func TestOperatorStateCacheEviction(t *testing.T) {
+	registry := &countingAvsRegistryService{}
+	cache := NewOperatorStateCache(registry, logging.NewNoopLogger())
+
+	ctx := context.Background()
+	for blockNumber := eigentypes.BlockNum(1); blockNumber <= OPERATOR_STATE_CACHE_SIZE+1; blockNumber++ {
+		_, err := cache.GetOperatorsAvsStateAtBlock(ctx, TEST_QUORUM_NUMBERS, blockNumber)
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, cache.entries, OPERATOR_STATE_CACHE_SIZE)
+	assert.NotContains(t, cache.entries, operatorStateCacheKey{quorumNumbers: "\x00", blockNumber: 1})
+}
+
+// Validation info for many messages against the same blocks, as with state
+// root updates for several rollups
+func BenchmarkFetchValidationInfo(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		name := "uncached"
+		if cached {
+			name = "cached"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			registry := &countingAvsRegistryService{}
+			logger := logging.NewNoopLogger()
+
+			mbas := NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, registry, nil, logger)
+			if cached {
+				mbas = NewMessageBlsAggregatorService(messages.StateRootUpdateMessageType, NewOperatorStateCache(registry, logger), nil, logger)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				blockNumber := uint64(1 + i/TEST_MESSAGES_PER_BLOCK)
+				_, err := mbas.fetchValidationInfo(TEST_QUORUM_NUMBERS, TEST_QUORUM_THRESHOLDS, blockNumber)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(registry.calls.Load())/float64(b.N), "registry_calls/op")
+		})
+	}
+}
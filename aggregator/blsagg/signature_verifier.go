@@ -0,0 +1,201 @@
+package blsagg
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	bn254utils "github.com/Layr-Labs/eigensdk-go/crypto/bn254"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+const (
+	// Maximum number of signatures checked in a single pairing check
+	SIGNATURE_BATCH_SIZE = 128
+	// How long the first signature of a batch waits for others
+	SIGNATURE_BATCH_WINDOW = 2 * time.Millisecond
+)
+
+type signatureVerificationRequest struct {
+	pubkey    *bls.G2Point
+	digest    coretypes.MessageDigest
+	signature *bls.Signature
+	resultC   chan bool
+}
+
+// SignatureVerifier verifies BLS signatures in batches. Signatures received
+// within a short window are combined with random scalars into a single
+// pairing check, with one pairing per distinct digest, so that a forged
+// signature can only pass with negligible probability. If a batch fails, its
+// signatures are checked individually.
+type SignatureVerifier struct {
+	requestsC   chan signatureVerificationRequest
+	batchSize   int
+	batchWindow time.Duration
+	listener    EventListener
+	logger      logging.Logger
+}
+
+func NewSignatureVerifier(ctx context.Context, logger logging.Logger) *SignatureVerifier {
+	verifier := &SignatureVerifier{
+		requestsC:   make(chan signatureVerificationRequest),
+		batchSize:   SIGNATURE_BATCH_SIZE,
+		batchWindow: SIGNATURE_BATCH_WINDOW,
+		listener:    &SelectiveListener{},
+		logger:      logger,
+	}
+
+	go verifier.start(ctx)
+
+	return verifier
+}
+
+func (v *SignatureVerifier) SetListener(listener EventListener) {
+	v.listener = listener
+}
+
+// Verifies a signature over a digest, waiting for the batch it's part of
+func (v *SignatureVerifier) Verify(ctx context.Context, pubkey *bls.G2Point, digest coretypes.MessageDigest, signature *bls.Signature) (bool, error) {
+	request := signatureVerificationRequest{
+		pubkey:    pubkey,
+		digest:    digest,
+		signature: signature,
+		resultC:   make(chan bool, 1),
+	}
+
+	select {
+	case v.requestsC <- request:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	select {
+	case ok := <-request.resultC:
+		return ok, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (v *SignatureVerifier) start(ctx context.Context) {
+	for {
+		var batch []signatureVerificationRequest
+
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-v.requestsC:
+			batch = append(batch, request)
+		}
+
+		windowTimer := time.NewTimer(v.batchWindow)
+	collect:
+		for len(batch) < v.batchSize {
+			select {
+			case <-ctx.Done():
+				windowTimer.Stop()
+				return
+			case request := <-v.requestsC:
+				batch = append(batch, request)
+			case <-windowTimer.C:
+				break collect
+			}
+		}
+		windowTimer.Stop()
+
+		go v.verifyBatch(batch)
+	}
+}
+
+func (v *SignatureVerifier) verifyBatch(batch []signatureVerificationRequest) {
+	if len(batch) > 1 && verifyBatchedSignatures(batch) {
+		v.listener.OnSignatureBatchVerified(len(batch), false)
+
+		for _, request := range batch {
+			request.resultC <- true
+		}
+		return
+	}
+
+	if len(batch) > 1 {
+		v.logger.Debug("Signature batch verification failed, verifying individually", "size", len(batch))
+	}
+	v.listener.OnSignatureBatchVerified(len(batch), len(batch) > 1)
+
+	for _, request := range batch {
+		request.resultC <- verifySingleSignature(request)
+	}
+}
+
+func verifySingleSignature(request signatureVerificationRequest) bool {
+	if !isValidRequest(request) {
+		return false
+	}
+
+	ok, err := request.signature.Verify(request.pubkey, request.digest)
+	return err == nil && ok
+}
+
+func isValidRequest(request signatureVerificationRequest) bool {
+	return request.pubkey != nil && request.pubkey.G2Affine != nil &&
+		request.signature != nil && request.signature.G1Point != nil && request.signature.G1Affine != nil &&
+		request.signature.IsOnCurve()
+}
+
+// Checks e(sum(r_i * sig_i), g2) == prod_m e(H(m), sum_{i signing m}(r_i * pk_i))
+// for random scalars r_i
+func verifyBatchedSignatures(batch []signatureVerificationRequest) bool {
+	var sigSum bn254.G1Jac
+	digestApks := make(map[coretypes.MessageDigest]*bn254.G2Jac)
+
+	for _, request := range batch {
+		if !isValidRequest(request) {
+			return false
+		}
+
+		var r fr.Element
+		if _, err := r.SetRandom(); err != nil {
+			return false
+		}
+		scalar := r.BigInt(new(big.Int))
+
+		var sig bn254.G1Jac
+		sig.FromAffine(request.signature.G1Affine)
+		sig.ScalarMultiplication(&sig, scalar)
+		sigSum.AddAssign(&sig)
+
+		var pubkey bn254.G2Jac
+		pubkey.FromAffine(request.pubkey.G2Affine)
+		pubkey.ScalarMultiplication(&pubkey, scalar)
+
+		apk, ok := digestApks[request.digest]
+		if !ok {
+			apk = new(bn254.G2Jac)
+			digestApks[request.digest] = apk
+		}
+		apk.AddAssign(&pubkey)
+	}
+
+	P := make([]bn254.G1Affine, 0, len(digestApks)+1)
+	Q := make([]bn254.G2Affine, 0, len(digestApks)+1)
+
+	for digest, apk := range digestApks {
+		P = append(P, *bn254utils.MapToCurve(digest))
+		Q = append(Q, *new(bn254.G2Affine).FromJacobian(apk))
+	}
+
+	var negSigSum bn254.G1Affine
+	negSigSum.FromJacobian(&sigSum)
+	negSigSum.Neg(&negSigSum)
+
+	P = append(P, negSigSum)
+	Q = append(Q, *bn254utils.GetG2Generator())
+
+	ok, err := bn254.PairingCheck(P, Q)
+	return err == nil && ok
+}
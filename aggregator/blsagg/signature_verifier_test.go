@@ -0,0 +1,184 @@
+package blsagg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+type testSignedDigest struct {
+	pubkey    *bls.G2Point
+	digest    coretypes.MessageDigest
+	signature *bls.Signature
+}
+
+// Signs numDigests digests with numOperators keys each
+func createTestSignedDigests(numOperators, numDigests int) []testSignedDigest {
+	signedDigests := make([]testSignedDigest, 0, numOperators*numDigests)
+
+	for i := 0; i < numOperators; i++ {
+		privateKey, err := bls.NewPrivateKey(fmt.Sprintf("%d", i+1))
+		if err != nil {
+			panic(err)
+		}
+		keyPair := bls.NewKeyPair(privateKey)
+
+		for j := 0; j < numDigests; j++ {
+			digest := coretypes.MessageDigest{byte(j), 1}
+			signedDigests = append(signedDigests, testSignedDigest{
+				pubkey:    keyPair.GetPubKeyG2(),
+				digest:    digest,
+				signature: keyPair.SignMessage(digest),
+			})
+		}
+	}
+
+	return signedDigests
+}
+
+func toRequests(signedDigests []testSignedDigest) []signatureVerificationRequest {
+	requests := make([]signatureVerificationRequest, len(signedDigests))
+	for i, signedDigest := range signedDigests {
+		requests[i] = signatureVerificationRequest{
+			pubkey:    signedDigest.pubkey,
+			digest:    signedDigest.digest,
+			signature: signedDigest.signature,
+			resultC:   make(chan bool, 1),
+		}
+	}
+
+	return requests
+}
+
+func TestVerifyBatchedSignatures(t *testing.T) {
+	signedDigests := createTestSignedDigests(4, 3)
+	assert.True(t, verifyBatchedSignatures(toRequests(signedDigests)))
+
+	// Signature over another digest
+	signedDigests[5].signature = signedDigests[4].signature
+	assert.False(t, verifyBatchedSignatures(toRequests(signedDigests)))
+
+	// Signatures that only add up to a valid aggregate
+	signedDigests = createTestSignedDigests(2, 1)
+	offset := bls.NewKeyPair(new(bls.PrivateKey).SetUint64(7)).SignMessage(signedDigests[0].digest)
+	signedDigests[0].signature = bls.NewZeroSignature().Add(signedDigests[0].signature).Add(offset)
+	signedDigests[1].signature = bls.NewZeroSignature().Add(signedDigests[1].signature)
+	signedDigests[1].signature.Sub(offset.G1Point)
+	assert.False(t, verifyBatchedSignatures(toRequests(signedDigests)))
+}
+
+func TestSignatureVerifierFallback(t *testing.T) {
+	verifier := NewSignatureVerifier(context.Background(), logging.NewNoopLogger())
+
+	var batchSizes []int
+	var fallbacks int
+	var lock sync.Mutex
+	verifier.SetListener(&SelectiveListener{
+		OnSignatureBatchVerifiedCb: func(size int, fallback bool) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			batchSizes = append(batchSizes, size)
+			if fallback {
+				fallbacks++
+			}
+		},
+	})
+
+	signedDigests := createTestSignedDigests(8, 2)
+	invalidIndex := 3
+	signedDigests[invalidIndex].signature = signedDigests[0].signature
+
+	var wg sync.WaitGroup
+	results := make([]bool, len(signedDigests))
+	for i, signedDigest := range signedDigests {
+		wg.Add(1)
+		go func(i int, signedDigest testSignedDigest) {
+			defer wg.Done()
+
+			ok, err := verifier.Verify(context.Background(), signedDigest.pubkey, signedDigest.digest, signedDigest.signature)
+			assert.NoError(t, err)
+			results[i] = ok
+		}(i, signedDigest)
+	}
+	wg.Wait()
+
+	for i, ok := range results {
+		assert.Equal(t, i != invalidIndex, ok)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	total := 0
+	for _, size := range batchSizes {
+		total += size
+	}
+	assert.Equal(t, len(signedDigests), total)
+	assert.Equal(t, 1, fallbacks)
+}
+
+func TestSignatureVerifierCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	verifier := NewSignatureVerifier(ctx, logging.NewNoopLogger())
+	cancel()
+
+	signedDigest := createTestSignedDigests(1, 1)[0]
+	_, err := verifier.Verify(ctx, signedDigest.pubkey, signedDigest.digest, signedDigest.signature)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// Verification throughput with operators signing the same digests, as with
+// state root updates
+func BenchmarkSignatureVerification(b *testing.B) {
+	signedDigests := createTestSignedDigests(32, 4)
+
+	b.Run("individual", func(b *testing.B) {
+		b.SetParallelism(len(signedDigests))
+
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				signedDigest := signedDigests[i%len(signedDigests)]
+				i++
+
+				ok, err := signedDigest.signature.Verify(signedDigest.pubkey, signedDigest.digest)
+				if err != nil || !ok {
+					b.Error("verification failed")
+					return
+				}
+			}
+		})
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		verifier := NewSignatureVerifier(ctx, logging.NewNoopLogger())
+
+		b.SetParallelism(len(signedDigests))
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				signedDigest := signedDigests[i%len(signedDigests)]
+				i++
+
+				ok, err := verifier.Verify(ctx, signedDigest.pubkey, signedDigest.digest, signedDigest.signature)
+				if err != nil || !ok {
+					b.Error("verification failed")
+					return
+				}
+			}
+		})
+	})
+}
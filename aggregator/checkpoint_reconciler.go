@@ -0,0 +1,274 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	taskmanager "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLTaskManager"
+	"github.com/Nuffle-Labs/nffl/core/chainio"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const (
+	// Interval between checks of the created tasks against their onchain state
+	CHECKPOINT_RECONCILIATION_INTERVAL = 30 * time.Second
+	// Minimum time since the last submission before resubmitting a response,
+	// so that submissions still waiting for a receipt aren't duplicated
+	CHECKPOINT_TASK_RESUBMISSION_DELAY = 2 * time.Minute
+	// Number of latest tasks checked for a response on startup, so that tasks
+	// created before a restart are still reconciled
+	CHECKPOINT_TASK_STARTUP_LOOKBACK = 100
+)
+
+type trackedCheckpointTask struct {
+	TaskIndex       coretypes.TaskIndex
+	Task            taskmanager.CheckpointTask
+	CreatedAt       time.Time
+	TaskResponse    *messages.CheckpointTaskResponse
+	Aggregation     *messages.MessageBlsAggregation
+	LastSubmittedAt time.Time
+}
+
+// CheckpointTaskTracker keeps the checkpoint tasks created by the aggregator
+// until their response is seen onchain or their response window passes,
+// along with the last aggregated response submitted for each.
+type CheckpointTaskTracker struct {
+	tasks map[coretypes.TaskIndex]*trackedCheckpointTask
+	lock  sync.Mutex
+}
+
+func NewCheckpointTaskTracker() *CheckpointTaskTracker {
+	return &CheckpointTaskTracker{
+		tasks: make(map[coretypes.TaskIndex]*trackedCheckpointTask),
+	}
+}
+
+func (t *CheckpointTaskTracker) Track(taskIndex coretypes.TaskIndex, task taskmanager.CheckpointTask, createdAt time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.tasks[taskIndex] = &trackedCheckpointTask{
+		TaskIndex: taskIndex,
+		Task:      task,
+		CreatedAt: createdAt,
+	}
+}
+
+// Records a response submission, keeping it for resubmissions. Ignored if the
+// task isn't tracked
+func (t *CheckpointTaskTracker) RecordSubmission(taskResponse messages.CheckpointTaskResponse, aggregation messages.MessageBlsAggregation, submittedAt time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tracked, ok := t.tasks[taskResponse.ReferenceTaskIndex]
+	if !ok {
+		return
+	}
+
+	tracked.TaskResponse = &taskResponse
+	tracked.Aggregation = &aggregation
+	tracked.LastSubmittedAt = submittedAt
+}
+
+func (t *CheckpointTaskTracker) Remove(taskIndex coretypes.TaskIndex) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.tasks, taskIndex)
+}
+
+// Returns copies of the tracked tasks
+func (t *CheckpointTaskTracker) Pending() []trackedCheckpointTask {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	pending := make([]trackedCheckpointTask, 0, len(t.tasks))
+	for _, tracked := range t.tasks {
+		pending = append(pending, *tracked)
+	}
+
+	return pending
+}
+
+// Tracks the latest tasks which weren't responded yet and are still inside
+// their response window, which otherwise would be lost on restart. Their
+// response can't be resubmitted, as the aggregation was lost, but they're
+// still reported if the response window passes.
+func (agg *Aggregator) loadUnrespondedCheckpointTasks(ctx context.Context) error {
+	nextTaskNum, err := agg.avsReader.GetNextCheckpointTaskNum(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to get next checkpoint task number", "err", err)
+		return err
+	}
+
+	currentBlock, err := agg.httpClient.BlockNumber(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to get block number", "err", err)
+		return err
+	}
+
+	responseWindowBlock, err := agg.avsReader.GetCheckpointTaskResponseWindowBlock(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to get checkpoint task response window", "err", err)
+		return err
+	}
+
+	fromBlock := uint64(0)
+	if currentBlock > uint64(responseWindowBlock) {
+		fromBlock = currentBlock - uint64(responseWindowBlock)
+	}
+
+	firstTaskNum := uint32(0)
+	if nextTaskNum > CHECKPOINT_TASK_STARTUP_LOOKBACK {
+		firstTaskNum = nextTaskNum - CHECKPOINT_TASK_STARTUP_LOOKBACK
+	}
+
+	for taskIndex := firstTaskNum; taskIndex < nextTaskNum; taskIndex++ {
+		taskHash, err := agg.avsReader.GetCheckpointTaskHash(ctx, taskIndex)
+		if err != nil {
+			return err
+		}
+		if taskHash == [32]byte{} {
+			continue
+		}
+
+		responseHash, err := agg.avsReader.GetCheckpointTaskResponseHash(ctx, taskIndex)
+		if err != nil {
+			return err
+		}
+		if responseHash != [32]byte{} {
+			continue
+		}
+
+		task, err := agg.avsReader.GetCheckpointTask(ctx, taskIndex, fromBlock)
+		if errors.Is(err, chainio.CheckpointTaskNotFoundError) {
+			// Created before fromBlock, so its response window already passed
+			continue
+		}
+		if err != nil {
+			agg.logger.Error("Failed to get checkpoint task", "taskIndex", taskIndex, "err", err)
+			return err
+		}
+
+		header, err := agg.httpClient.HeaderByNumber(ctx, new(big.Int).SetUint64(uint64(task.TaskCreatedBlock)))
+		if err != nil {
+			agg.logger.Error("Failed to get checkpoint task creation block", "taskIndex", taskIndex, "err", err)
+			return err
+		}
+
+		agg.logger.Info("Tracking unresponded checkpoint task", "taskIndex", taskIndex, "taskCreatedBlock", task.TaskCreatedBlock)
+		agg.checkpointTaskTracker.Track(taskIndex, task, time.Unix(int64(header.Time), 0))
+	}
+
+	return nil
+}
+
+func (agg *Aggregator) reconcileCheckpointTasksLoop(ctx context.Context) {
+	err := agg.loadUnrespondedCheckpointTasks(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to load unresponded checkpoint tasks", "err", err)
+	}
+
+	ticker := time.NewTicker(CHECKPOINT_RECONCILIATION_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			agg.reconcileCheckpointTasks(ctx)
+		}
+	}
+}
+
+// Checks the tracked checkpoint tasks against the task manager. Responded
+// tasks have their creation to response gap observed, unresponded ones are
+// resubmitted while inside the response window and reported once it passes.
+func (agg *Aggregator) reconcileCheckpointTasks(ctx context.Context) {
+	pending := agg.checkpointTaskTracker.Pending()
+	if len(pending) == 0 {
+		return
+	}
+
+	currentBlock, err := agg.httpClient.BlockNumber(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to get block number for checkpoint reconciliation", "err", err)
+		return
+	}
+
+	responseWindowBlock, err := agg.avsReader.GetCheckpointTaskResponseWindowBlock(ctx)
+	if err != nil {
+		agg.logger.Error("Failed to get checkpoint task response window", "err", err)
+		return
+	}
+
+	for _, tracked := range pending {
+		agg.reconcileCheckpointTask(ctx, tracked, currentBlock, responseWindowBlock)
+	}
+}
+
+func (agg *Aggregator) reconcileCheckpointTask(ctx context.Context, tracked trackedCheckpointTask, currentBlock uint64, responseWindowBlock uint32) {
+	taskHash, err := agg.avsReader.GetCheckpointTaskHash(ctx, tracked.TaskIndex)
+	if err != nil {
+		agg.logger.Warn("Failed to get checkpoint task hash", "taskIndex", tracked.TaskIndex, "err", err)
+		return
+	}
+	if taskHash == [32]byte{} {
+		agg.logger.Error("Checkpoint task not found onchain, it may have been reorged out", "taskIndex", tracked.TaskIndex)
+		agg.aggregatorListener.IncUnansweredCheckpointTasks()
+		agg.checkpointTaskTracker.Remove(tracked.TaskIndex)
+		return
+	}
+
+	responseHash, err := agg.avsReader.GetCheckpointTaskResponseHash(ctx, tracked.TaskIndex)
+	if err != nil {
+		agg.logger.Warn("Failed to get checkpoint task response hash", "taskIndex", tracked.TaskIndex, "err", err)
+		return
+	}
+	if responseHash != [32]byte{} {
+		gap := agg.clock.Now().Sub(tracked.CreatedAt)
+		agg.logger.Info("Checkpoint task responded onchain", "taskIndex", tracked.TaskIndex, "gap", gap.String())
+
+		agg.aggregatorListener.ObserveCheckpointTaskResponseGap(gap.Seconds())
+		agg.checkpointTaskTracker.Remove(tracked.TaskIndex)
+		return
+	}
+
+	if currentBlock > uint64(tracked.Task.TaskCreatedBlock)+uint64(responseWindowBlock) {
+		agg.logger.Error("Checkpoint task response window passed without a response",
+			"taskIndex", tracked.TaskIndex,
+			"taskCreatedBlock", tracked.Task.TaskCreatedBlock,
+			"currentBlock", currentBlock,
+			"submitted", tracked.TaskResponse != nil,
+		)
+
+		agg.aggregatorListener.IncUnansweredCheckpointTasks()
+		agg.checkpointTaskTracker.Remove(tracked.TaskIndex)
+		return
+	}
+
+	// Either still being aggregated or the aggregation failed, in which case
+	// there's nothing to resubmit and the task is reported once it expires
+	if tracked.TaskResponse == nil {
+		return
+	}
+
+	now := agg.clock.Now()
+	if now.Sub(tracked.LastSubmittedAt) < CHECKPOINT_TASK_RESUBMISSION_DELAY {
+		return
+	}
+
+	agg.logger.Warn("Checkpoint task response not found onchain, resubmitting", "taskIndex", tracked.TaskIndex)
+	agg.aggregatorListener.IncCheckpointTaskResubmissions()
+	agg.checkpointTaskTracker.RecordSubmission(*tracked.TaskResponse, *tracked.Aggregation, now)
+
+	_, err = agg.avsWriter.SendAggregatedResponse(ctx, tracked.Task, *tracked.TaskResponse, *tracked.Aggregation)
+	if err != nil {
+		agg.logger.Error("Failed to resubmit checkpoint task response", "taskIndex", tracked.TaskIndex, "err", err)
+	}
+}
@@ -0,0 +1,162 @@
+package aggregator
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Nuffle-Labs/nffl/core/config"
+)
+
+const (
+	CHECKPOINT_DECISION_NO_MESSAGES          = "no_messages"
+	CHECKPOINT_DECISION_BELOW_MIN_MESSAGES   = "below_min_messages"
+	CHECKPOINT_DECISION_GAS_PRICE_TOO_HIGH   = "gas_price_too_high"
+	CHECKPOINT_DECISION_MIN_MESSAGES_REACHED = "min_messages_reached"
+	CHECKPOINT_DECISION_MAX_STALENESS        = "max_staleness_reached"
+	CHECKPOINT_DECISION_GAS_PRICE_OVERRIDE   = "gas_price_override_deadline_reached"
+	// Ticks that failed before deciding or while sending the task
+	CHECKPOINT_DECISION_ERROR = "error"
+)
+
+// CheckpointSchedulingPolicy decides on each checkpoint tick whether a task
+// is worth sending. Checkpoints without messages are always skipped. Zero
+// values disable each of the other bounds.
+type CheckpointSchedulingPolicy struct {
+	// Pending messages required to send a checkpoint
+	MinMessages uint64
+	// Age of the oldest pending message after which it's checkpointed
+	// regardless of MinMessages
+	MaxStaleness time.Duration
+	// Gas price above which checkpoints are postponed
+	MaxGasPrice *big.Int
+	// Age of the oldest pending message after which it's checkpointed
+	// regardless of MaxGasPrice
+	GasPriceOverrideDeadline time.Duration
+}
+
+type CheckpointDecision struct {
+	Send   bool
+	Reason string
+}
+
+func NewCheckpointSchedulingPolicyFromConfig(config *config.Config) CheckpointSchedulingPolicy {
+	return CheckpointSchedulingPolicy{
+		MinMessages:              config.AggregatorCheckpointMinMessages,
+		MaxStaleness:             config.AggregatorCheckpointMaxStaleness,
+		MaxGasPrice:              config.AggregatorCheckpointMaxGasPrice,
+		GasPriceOverrideDeadline: config.AggregatorCheckpointGasPriceOverrideDeadline,
+	}
+}
+
+// Decides whether to send a checkpoint given the aggregated messages since
+// the last one, the age of the oldest of them and the current gas price. A
+// nil gas price is considered to be below the ceiling.
+func (p CheckpointSchedulingPolicy) Decide(pendingMessages uint64, staleness time.Duration, gasPrice *big.Int) CheckpointDecision {
+	if pendingMessages == 0 {
+		return CheckpointDecision{Send: false, Reason: CHECKPOINT_DECISION_NO_MESSAGES}
+	}
+
+	reason := CHECKPOINT_DECISION_MIN_MESSAGES_REACHED
+	if pendingMessages < p.MinMessages {
+		if p.MaxStaleness == 0 || staleness < p.MaxStaleness {
+			return CheckpointDecision{Send: false, Reason: CHECKPOINT_DECISION_BELOW_MIN_MESSAGES}
+		}
+
+		reason = CHECKPOINT_DECISION_MAX_STALENESS
+	}
+
+	if p.MaxGasPrice != nil && gasPrice != nil && gasPrice.Cmp(p.MaxGasPrice) > 0 {
+		if p.GasPriceOverrideDeadline == 0 || staleness < p.GasPriceOverrideDeadline {
+			return CheckpointDecision{Send: false, Reason: CHECKPOINT_DECISION_GAS_PRICE_TOO_HIGH}
+		}
+
+		reason = CHECKPOINT_DECISION_GAS_PRICE_OVERRIDE
+	}
+
+	return CheckpointDecision{Send: true, Reason: reason}
+}
+
+// Sends a new checkpoint task if the scheduling policy allows it. Each
+// decision is logged, and exported once acted upon, skipped ranges are
+// covered by the next checkpoint sent. Failures are left to the caller to
+// export.
+func (agg *Aggregator) scheduleCheckpointTask() error {
+	ctx := context.Background()
+
+	fromTimestamp, toTimestamp, err := agg.getNextCheckpointRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	pendingMessages, err := agg.msgDb.CountCheckpointMessages(fromTimestamp, toTimestamp)
+	if err != nil {
+		agg.logger.Error("Failed to count pending checkpoint messages", "err", err)
+		return err
+	}
+
+	staleness, err := agg.getCheckpointStaleness(pendingMessages, fromTimestamp, toTimestamp)
+	if err != nil {
+		return err
+	}
+
+	var gasPrice *big.Int
+	if pendingMessages > 0 && agg.checkpointSchedulingPolicy.MaxGasPrice != nil {
+		gasPrice, err = agg.httpClient.SuggestGasPrice(ctx)
+		if err != nil {
+			agg.logger.Warn("Failed to get gas price, ignoring gas price ceiling", "err", err)
+			gasPrice = nil
+		}
+	}
+
+	decision := agg.checkpointSchedulingPolicy.Decide(pendingMessages, staleness, gasPrice)
+	agg.logger.Info("Checkpoint scheduling decision",
+		"send", decision.Send,
+		"reason", decision.Reason,
+		"fromTimestamp", fromTimestamp,
+		"toTimestamp", toTimestamp,
+		"pendingMessages", pendingMessages,
+		"staleness", staleness.String(),
+		"gasPrice", gasPrice,
+	)
+
+	agg.aggregatorListener.ObserveCheckpointPendingMessages(pendingMessages)
+	agg.aggregatorListener.ObserveCheckpointStaleness(staleness.Seconds())
+
+	if !decision.Send {
+		agg.aggregatorListener.IncCheckpointDecisions(false, decision.Reason)
+		return nil
+	}
+
+	err = agg.sendCheckpointTask(fromTimestamp, toTimestamp)
+	if err != nil {
+		return err
+	}
+
+	agg.aggregatorListener.IncCheckpointDecisions(true, decision.Reason)
+	return nil
+}
+
+// Returns how long the oldest pending message has been waiting for a
+// checkpoint, or zero if there's none
+func (agg *Aggregator) getCheckpointStaleness(pendingMessages, fromTimestamp, toTimestamp uint64) (time.Duration, error) {
+	if pendingMessages == 0 {
+		return 0, nil
+	}
+
+	oldestTimestamp, ok, err := agg.msgDb.FetchOldestCheckpointMessageTimestamp(fromTimestamp, toTimestamp)
+	if err != nil {
+		agg.logger.Error("Failed to fetch oldest pending checkpoint message", "err", err)
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	staleness := agg.clock.Now().Sub(time.Unix(int64(oldestTimestamp), 0))
+	if staleness < 0 {
+		return 0, nil
+	}
+
+	return staleness, nil
+}
@@ -7,13 +7,14 @@ import (
 	"log"
 	"os"
 
-	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli"
 
 	"github.com/Nuffle-Labs/nffl/aggregator"
+	adminserver "github.com/Nuffle-Labs/nffl/aggregator/admin_server"
 	restserver "github.com/Nuffle-Labs/nffl/aggregator/rest_server"
 	rpcserver "github.com/Nuffle-Labs/nffl/aggregator/rpc_server"
+	"github.com/Nuffle-Labs/nffl/core"
 	"github.com/Nuffle-Labs/nffl/core/config"
 )
 
@@ -47,7 +48,7 @@ func aggregatorMain(ctx *cli.Context) error {
 		return err
 	}
 
-	logger, err := sdklogging.NewZapLogger(configRaw.Environment)
+	logger, logLevel, err := core.NewZapLoggerWithLevel(configRaw.Environment)
 	if err != nil {
 		return err
 	}
@@ -92,6 +93,11 @@ func aggregatorMain(ctx *cli.Context) error {
 	}
 	go restServer.Start()
 
+	if config.AggregatorAdminServerIpPortAddr != "" {
+		adminServer := adminserver.NewAdminServer(config.AggregatorAdminServerIpPortAddr, config.AggregatorAdminApiToken, agg, logLevel, logger)
+		go adminServer.Start()
+	}
+
 	err = agg.Start(bgCtx)
 	if err != nil {
 		return err
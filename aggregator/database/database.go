@@ -2,19 +2,24 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log"
 	"math"
 	"os"
 	"time"
 
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	"github.com/Nuffle-Labs/nffl/aggregator/database/models"
+	"github.com/Nuffle-Labs/nffl/aggregator/types"
 	"github.com/Nuffle-Labs/nffl/core"
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
 )
 
@@ -31,6 +36,17 @@ type Databaser interface {
 	StoreOperatorSetUpdateAggregation(operatorSetUpdateMessage *models.OperatorSetUpdateMessage, aggregation messages.MessageBlsAggregation) error
 	FetchOperatorSetUpdateAggregation(id uint64) (*messages.MessageBlsAggregation, error)
 	FetchCheckpointMessages(fromTimestamp uint64, toTimestamp uint64) (*messages.CheckpointMessages, error)
+	CountCheckpointMessages(fromTimestamp uint64, toTimestamp uint64) (uint64, error)
+	FetchOldestCheckpointMessageTimestamp(fromTimestamp uint64, toTimestamp uint64) (uint64, bool, error)
+	StoreEquivocationEvidence(evidence messages.EquivocationEvidence) error
+	FetchEquivocationEvidence(operatorId *eigentypes.OperatorId) ([]messages.EquivocationEvidence, error)
+	StoreOperatorParticipations(participations []messages.OperatorParticipation) error
+	FetchMissedMessages(operatorId eigentypes.OperatorId, cursor uint64, limit int) ([]messages.OperatorParticipation, uint64, error)
+	PruneOperatorParticipations(before time.Time) (int64, error)
+	StoreAuditLogEntry(entry types.AuditLogEntry) error
+	FetchAuditLogEntries(limit int) ([]types.AuditLogEntry, error)
+	FetchCursor(key string) (uint64, bool, error)
+	StoreCursor(key string, block uint64) error
 	DB() *gorm.DB
 }
 
@@ -42,6 +58,7 @@ type Database struct {
 
 var _ core.Metricable = (*Database)(nil)
 var _ Databaser = (*Database)(nil)
+var _ safeclient.CursorStore = (*Database)(nil)
 
 func NewDatabase(dbPath string) (*Database, error) {
 	logger := logger.New(
@@ -68,6 +85,10 @@ func NewDatabase(dbPath string) (*Database, error) {
 		&models.MessageBlsAggregation{},
 		&models.StateRootUpdateMessage{},
 		&models.OperatorSetUpdateMessage{},
+		&models.EventCursor{},
+		&models.EquivocationEvidence{},
+		&models.OperatorParticipation{},
+		&models.AuditLogEntry{},
 	)
 	if err != nil {
 		return nil, err
@@ -266,6 +287,39 @@ func (d *Database) FetchOperatorSetUpdateAggregation(id uint64) (*messages.Messa
 	return &aggregation, nil
 }
 
+func (d *Database) FetchCursor(key string) (uint64, bool, error) {
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var model models.EventCursor
+	tx := d.db.
+		Where("key = ?", key).
+		Limit(1).
+		Find(&model)
+	if tx.Error != nil {
+		return 0, false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return 0, false, nil
+	}
+
+	return model.Block, true, nil
+}
+
+func (d *Database) StoreCursor(key string, block uint64) error {
+	start := time.Now()
+	defer func() { d.listener.OnStore(time.Since(start)) }()
+
+	model := models.EventCursor{Key: key}
+	tx := d.db.
+		Where("key = ?", key).
+		Assign(models.EventCursor{Block: block}).
+		FirstOrCreate(&model)
+
+	return tx.Error
+}
+
 func (d *Database) FetchCheckpointMessages(fromTimestamp uint64, toTimestamp uint64) (*messages.CheckpointMessages, error) {
 	if fromTimestamp > math.MaxInt64 || toTimestamp > math.MaxInt64 {
 		return nil, errors.New("timestamp does not fit in int64")
@@ -339,6 +393,232 @@ func (d *Database) FetchCheckpointMessages(fromTimestamp uint64, toTimestamp uin
 	return result, nil
 }
 
+// Counts the aggregated messages a checkpoint over the given range would
+// include, without loading them
+func (d *Database) CountCheckpointMessages(fromTimestamp uint64, toTimestamp uint64) (uint64, error) {
+	if fromTimestamp > math.MaxInt64 || toTimestamp > math.MaxInt64 {
+		return 0, errors.New("timestamp does not fit in int64")
+	}
+
+	if toTimestamp < fromTimestamp {
+		return 0, errors.New("toTimestamp is less than fromTimestamp")
+	}
+
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var stateRootUpdateCount int64
+	tx := d.db.
+		Model(&models.StateRootUpdateMessage{}).
+		Where("timestamp >= ?", fromTimestamp).
+		Where("timestamp <= ?", toTimestamp).
+		Where("aggregation_id <> 0").
+		Count(&stateRootUpdateCount)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	var operatorSetUpdateCount int64
+	tx = d.db.
+		Model(&models.OperatorSetUpdateMessage{}).
+		Where("timestamp >= ?", fromTimestamp).
+		Where("timestamp <= ?", toTimestamp).
+		Where("aggregation_id <> 0").
+		Count(&operatorSetUpdateCount)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	return uint64(stateRootUpdateCount + operatorSetUpdateCount), nil
+}
+
+// Returns the timestamp of the oldest aggregated message in the range, and
+// whether there's any
+func (d *Database) FetchOldestCheckpointMessageTimestamp(fromTimestamp uint64, toTimestamp uint64) (uint64, bool, error) {
+	if fromTimestamp > math.MaxInt64 || toTimestamp > math.MaxInt64 {
+		return 0, false, errors.New("timestamp does not fit in int64")
+	}
+
+	if toTimestamp < fromTimestamp {
+		return 0, false, errors.New("toTimestamp is less than fromTimestamp")
+	}
+
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var oldest sql.NullInt64
+	for _, model := range []interface{}{&models.StateRootUpdateMessage{}, &models.OperatorSetUpdateMessage{}} {
+		var modelOldest sql.NullInt64
+		tx := d.db.
+			Model(model).
+			Where("timestamp >= ?", fromTimestamp).
+			Where("timestamp <= ?", toTimestamp).
+			Where("aggregation_id <> 0").
+			Select("MIN(timestamp)").
+			Scan(&modelOldest)
+		if tx.Error != nil {
+			return 0, false, tx.Error
+		}
+
+		if modelOldest.Valid && (!oldest.Valid || modelOldest.Int64 < oldest.Int64) {
+			oldest = modelOldest
+		}
+	}
+
+	if !oldest.Valid {
+		return 0, false, nil
+	}
+
+	return uint64(oldest.Int64), true, nil
+}
+
+// Only the first evidence for each operator, message type and key is kept
+func (d *Database) StoreEquivocationEvidence(evidence messages.EquivocationEvidence) error {
+	start := time.Now()
+	defer func() { d.listener.OnStore(time.Since(start)) }()
+
+	model := models.NewEquivocationEvidenceModel(evidence)
+
+	tx := d.db.
+		Where("message_type = ?", model.MessageType).
+		Where("message_key = ?", model.MessageKey).
+		Where("operator_id = ?", model.OperatorId).
+		FirstOrCreate(&model)
+
+	return tx.Error
+}
+
+// Fetches the evidence against an operator, or against all operators if
+// operatorId is nil
+func (d *Database) FetchEquivocationEvidence(operatorId *eigentypes.OperatorId) ([]messages.EquivocationEvidence, error) {
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var evidenceModels []models.EquivocationEvidence
+
+	tx := d.db.Model(&models.EquivocationEvidence{})
+	if operatorId != nil {
+		tx = tx.Where("operator_id = ?", operatorId[:])
+	}
+
+	tx = tx.Order("id").Find(&evidenceModels)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	evidence := make([]messages.EquivocationEvidence, 0, len(evidenceModels))
+	for _, model := range evidenceModels {
+		evidence = append(evidence, model.ToMessage())
+	}
+
+	return evidence, nil
+}
+
+// Stores or updates the participation of each operator in a message, as late
+// signatures may turn a missed message into a signed one
+func (d *Database) StoreOperatorParticipations(participations []messages.OperatorParticipation) error {
+	start := time.Now()
+	defer func() { d.listener.OnStore(time.Since(start)) }()
+
+	if len(participations) == 0 {
+		return nil
+	}
+
+	participationModels := make([]models.OperatorParticipation, 0, len(participations))
+	for _, participation := range participations {
+		participationModels = append(participationModels, models.NewOperatorParticipationModel(participation))
+	}
+
+	tx := d.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "message_key"}, {Name: "message_type"}, {Name: "operator_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"signed", "updated_at"}),
+		}).
+		Create(&participationModels)
+
+	return tx.Error
+}
+
+// Fetches up to limit messages an operator missed after a cursor, oldest
+// first. The returned cursor fetches the next page, and is 0 on the last one.
+func (d *Database) FetchMissedMessages(operatorId eigentypes.OperatorId, cursor uint64, limit int) ([]messages.OperatorParticipation, uint64, error) {
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var participationModels []models.OperatorParticipation
+
+	tx := d.db.
+		Model(&models.OperatorParticipation{}).
+		Where("operator_id = ?", operatorId[:]).
+		Where("signed = ?", false).
+		Where("id > ?", cursor).
+		Order("id").
+		Limit(limit).
+		Find(&participationModels)
+	if tx.Error != nil {
+		return nil, 0, tx.Error
+	}
+
+	missedMessages := make([]messages.OperatorParticipation, 0, len(participationModels))
+	for _, model := range participationModels {
+		missedMessages = append(missedMessages, model.ToMessage())
+	}
+
+	nextCursor := uint64(0)
+	if len(participationModels) == limit {
+		nextCursor = uint64(participationModels[len(participationModels)-1].ID)
+	}
+
+	return missedMessages, nextCursor, nil
+}
+
+// Deletes the participations last updated before a time
+func (d *Database) PruneOperatorParticipations(before time.Time) (int64, error) {
+	start := time.Now()
+	defer func() { d.listener.OnStore(time.Since(start)) }()
+
+	tx := d.db.
+		Unscoped().
+		Where("updated_at < ?", before).
+		Delete(&models.OperatorParticipation{})
+
+	return tx.RowsAffected, tx.Error
+}
+
+func (d *Database) StoreAuditLogEntry(entry types.AuditLogEntry) error {
+	start := time.Now()
+	defer func() { d.listener.OnStore(time.Since(start)) }()
+
+	model := models.NewAuditLogEntryModel(entry)
+
+	tx := d.db.Create(&model)
+	return tx.Error
+}
+
+// Fetches the latest audit log entries, most recent first
+func (d *Database) FetchAuditLogEntries(limit int) ([]types.AuditLogEntry, error) {
+	start := time.Now()
+	defer func() { d.listener.OnFetch(time.Since(start)) }()
+
+	var entryModels []models.AuditLogEntry
+
+	tx := d.db.
+		Model(&models.AuditLogEntry{}).
+		Order("id desc").
+		Limit(limit).
+		Find(&entryModels)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	entries := make([]types.AuditLogEntry, 0, len(entryModels))
+	for _, model := range entryModels {
+		entries = append(entries, model.ToEntry())
+	}
+
+	return entries, nil
+}
+
 func (d *Database) DB() *gorm.DB {
 	return d.db
 }
@@ -4,6 +4,7 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/Nuffle-Labs/nffl/aggregator/database"
 	"github.com/Nuffle-Labs/nffl/aggregator/database/models"
+	"github.com/Nuffle-Labs/nffl/aggregator/types"
 	coretypes "github.com/Nuffle-Labs/nffl/core/types"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
 	"github.com/Nuffle-Labs/nffl/tests"
@@ -381,6 +383,41 @@ func TestFetchCheckpointMessages(t *testing.T) {
 	err = db.StoreOperatorSetUpdateAggregation(operatorSetUpdateMsgModel, aggregation4)
 	assert.Nil(t, err)
 
+	// Not aggregated, so not part of any checkpoint
+	_, err = db.StoreStateRootUpdate(messages.StateRootUpdateMessage{RollupId: 1, BlockHeight: 3, Timestamp: 2})
+	assert.Nil(t, err)
+
+	count, err := db.CountCheckpointMessages(0, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), count)
+
+	count, err = db.CountCheckpointMessages(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	count, err = db.CountCheckpointMessages(4, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	oldest, ok, err := db.FetchOldestCheckpointMessageTimestamp(0, 3)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), oldest)
+
+	oldest, ok, err = db.FetchOldestCheckpointMessageTimestamp(1, 3)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), oldest)
+
+	oldest, ok, err = db.FetchOldestCheckpointMessageTimestamp(2, 3)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), oldest)
+
+	_, ok, err = db.FetchOldestCheckpointMessageTimestamp(4, 10)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
 	result, err := db.FetchCheckpointMessages(0, 3)
 	assert.Nil(t, err)
 	assert.Equal(t, *result, messages.CheckpointMessages{
@@ -473,3 +510,192 @@ func TestStoreStateRootUpdate_LargeMsgValues(t *testing.T) {
 
 	assert.Equal(t, &msg, stored)
 }
+
+func TestStoreAndFetchCursor(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.Nil(t, err)
+
+	_, ok, err := db.FetchCursor("key")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	err = db.StoreCursor("key", 10)
+	assert.Nil(t, err)
+
+	err = db.StoreCursor("key", 20)
+	assert.Nil(t, err)
+
+	block, ok, err := db.FetchCursor("key")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(20), block)
+}
+
+func TestStoreAndFetchEquivocationEvidence(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.Nil(t, err)
+
+	evidence := messages.EquivocationEvidence{
+		MessageType:     messages.StateRootUpdateMessageType,
+		MessageKey:      tests.Keccak256(1),
+		OperatorId:      tests.Keccak256(2),
+		EthBlockNumber:  3,
+		FirstMessage:    []byte(`{"RollupId":1}`),
+		FirstDigest:     tests.Keccak256(4),
+		FirstSignature:  bls.NewZeroSignature(),
+		SecondMessage:   []byte(`{"RollupId":2}`),
+		SecondDigest:    tests.Keccak256(5),
+		SecondSignature: bls.NewZeroSignature(),
+	}
+	otherEvidence := evidence
+	otherEvidence.OperatorId = tests.Keccak256(6)
+	otherTypeEvidence := evidence
+	otherTypeEvidence.MessageType = messages.OperatorSetUpdateMessageType
+
+	err = db.StoreEquivocationEvidence(evidence)
+	assert.Nil(t, err)
+
+	// Later evidence for the same operator and key is ignored
+	duplicateEvidence := evidence
+	duplicateEvidence.SecondDigest = tests.Keccak256(7)
+	err = db.StoreEquivocationEvidence(duplicateEvidence)
+	assert.Nil(t, err)
+
+	err = db.StoreEquivocationEvidence(otherEvidence)
+	assert.Nil(t, err)
+
+	// Keys are only unique within a message type
+	err = db.StoreEquivocationEvidence(otherTypeEvidence)
+	assert.Nil(t, err)
+
+	entries, err := db.FetchEquivocationEvidence(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []messages.EquivocationEvidence{evidence, otherEvidence, otherTypeEvidence}, entries)
+
+	operatorId := otherEvidence.OperatorId
+	entries, err = db.FetchEquivocationEvidence(&operatorId)
+	assert.Nil(t, err)
+	assert.Equal(t, []messages.EquivocationEvidence{otherEvidence}, entries)
+}
+
+func TestStoreAndFetchMissedMessages(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.Nil(t, err)
+
+	operatorId := tests.Keccak256(1)
+	otherOperatorId := tests.Keccak256(2)
+
+	missed := messages.OperatorParticipation{
+		MessageKey:     tests.Keccak256(3),
+		MessageType:    messages.StateRootUpdateMessageType,
+		RollupId:       4,
+		OperatorId:     operatorId,
+		EthBlockNumber: 5,
+		Signed:         false,
+	}
+	otherMissed := missed
+	otherMissed.MessageKey = tests.Keccak256(6)
+	otherMissed.MessageType = messages.OperatorSetUpdateMessageType
+	otherMissed.RollupId = 0
+	signed := missed
+	signed.OperatorId = otherOperatorId
+	signed.Signed = true
+
+	// Message keys may repeat across message types
+	otherTypeMissed := missed
+	otherTypeMissed.MessageType = messages.CheckpointTaskResponseMessageType
+	otherTypeMissed.RollupId = 0
+
+	err = db.StoreOperatorParticipations([]messages.OperatorParticipation{missed, otherMissed, signed, otherTypeMissed})
+	assert.Nil(t, err)
+
+	entries, cursor, err := db.FetchMissedMessages(operatorId, 0, 10)
+	assert.Nil(t, err)
+	assert.Zero(t, cursor)
+	assert.Equal(t, []messages.OperatorParticipation{missed, otherMissed, otherTypeMissed}, entries)
+
+	entries, _, err = db.FetchMissedMessages(otherOperatorId, 0, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+
+	// Pagination
+	entries, cursor, err = db.FetchMissedMessages(operatorId, 0, 2)
+	assert.Nil(t, err)
+	assert.NotZero(t, cursor)
+	assert.Equal(t, []messages.OperatorParticipation{missed, otherMissed}, entries)
+
+	entries, cursor, err = db.FetchMissedMessages(operatorId, cursor, 2)
+	assert.Nil(t, err)
+	assert.Zero(t, cursor)
+	assert.Equal(t, []messages.OperatorParticipation{otherTypeMissed}, entries)
+
+	// Late signature
+	lateSigned := missed
+	lateSigned.Signed = true
+	err = db.StoreOperatorParticipations([]messages.OperatorParticipation{lateSigned})
+	assert.Nil(t, err)
+
+	entries, _, err = db.FetchMissedMessages(operatorId, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []messages.OperatorParticipation{otherMissed, otherTypeMissed}, entries)
+}
+
+func TestPruneOperatorParticipations(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.Nil(t, err)
+
+	operatorId := tests.Keccak256(1)
+	participation := messages.OperatorParticipation{
+		MessageKey:  tests.Keccak256(2),
+		MessageType: messages.StateRootUpdateMessageType,
+		OperatorId:  operatorId,
+	}
+
+	err = db.StoreOperatorParticipations([]messages.OperatorParticipation{participation})
+	assert.Nil(t, err)
+
+	pruned, err := db.PruneOperatorParticipations(time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Zero(t, pruned)
+
+	pruned, err = db.PruneOperatorParticipations(time.Now().Add(time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), pruned)
+
+	entries, _, err := db.FetchMissedMessages(operatorId, 0, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStoreAndFetchAuditLogEntries(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.Nil(t, err)
+
+	pause := types.AuditLogEntry{Action: "pause_checkpoint_tasks", RemoteAddr: "127.0.0.1:1234"}
+	drop := types.AuditLogEntry{
+		Action:     "drop_message",
+		Params:     "messageType=state_root_update&messageKey=0x01",
+		RemoteAddr: "127.0.0.1:1234",
+		Error:      "message not found",
+	}
+
+	err = db.StoreAuditLogEntry(pause)
+	assert.Nil(t, err)
+	err = db.StoreAuditLogEntry(drop)
+	assert.Nil(t, err)
+
+	entries, err := db.FetchAuditLogEntries(10)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, drop.Action, entries[0].Action)
+	assert.Equal(t, drop.Params, entries[0].Params)
+	assert.Equal(t, drop.Error, entries[0].Error)
+	assert.Equal(t, pause.Action, entries[1].Action)
+	assert.False(t, entries[1].Timestamp.IsZero())
+
+	entries, err = db.FetchAuditLogEntries(1)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, drop.Action, entries[0].Action)
+}
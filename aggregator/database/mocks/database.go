@@ -5,13 +5,17 @@
 //
 //	mockgen -destination=./mocks/database.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator/database Databaser
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
+	types "github.com/Layr-Labs/eigensdk-go/types"
 	models "github.com/Nuffle-Labs/nffl/aggregator/database/models"
+	types0 "github.com/Nuffle-Labs/nffl/aggregator/types"
 	messages "github.com/Nuffle-Labs/nffl/core/types/messages"
 	prometheus "github.com/prometheus/client_golang/prometheus"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +59,21 @@ func (mr *MockDatabaserMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabaser)(nil).Close))
 }
 
+// CountCheckpointMessages mocks base method.
+func (m *MockDatabaser) CountCheckpointMessages(arg0, arg1 uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCheckpointMessages", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCheckpointMessages indicates an expected call of CountCheckpointMessages.
+func (mr *MockDatabaserMockRecorder) CountCheckpointMessages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCheckpointMessages", reflect.TypeOf((*MockDatabaser)(nil).CountCheckpointMessages), arg0, arg1)
+}
+
 // DB mocks base method.
 func (m *MockDatabaser) DB() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -83,6 +102,21 @@ func (mr *MockDatabaserMockRecorder) EnableMetrics(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMetrics", reflect.TypeOf((*MockDatabaser)(nil).EnableMetrics), arg0)
 }
 
+// FetchAuditLogEntries mocks base method.
+func (m *MockDatabaser) FetchAuditLogEntries(arg0 int) ([]types0.AuditLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAuditLogEntries", arg0)
+	ret0, _ := ret[0].([]types0.AuditLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAuditLogEntries indicates an expected call of FetchAuditLogEntries.
+func (mr *MockDatabaserMockRecorder) FetchAuditLogEntries(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAuditLogEntries", reflect.TypeOf((*MockDatabaser)(nil).FetchAuditLogEntries), arg0)
+}
+
 // FetchCheckpointMessages mocks base method.
 func (m *MockDatabaser) FetchCheckpointMessages(arg0, arg1 uint64) (*messages.CheckpointMessages, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +132,69 @@ func (mr *MockDatabaserMockRecorder) FetchCheckpointMessages(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCheckpointMessages", reflect.TypeOf((*MockDatabaser)(nil).FetchCheckpointMessages), arg0, arg1)
 }
 
+// FetchCursor mocks base method.
+func (m *MockDatabaser) FetchCursor(arg0 string) (uint64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCursor", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchCursor indicates an expected call of FetchCursor.
+func (mr *MockDatabaserMockRecorder) FetchCursor(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCursor", reflect.TypeOf((*MockDatabaser)(nil).FetchCursor), arg0)
+}
+
+// FetchEquivocationEvidence mocks base method.
+func (m *MockDatabaser) FetchEquivocationEvidence(arg0 *types.Bytes32) ([]messages.EquivocationEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEquivocationEvidence", arg0)
+	ret0, _ := ret[0].([]messages.EquivocationEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEquivocationEvidence indicates an expected call of FetchEquivocationEvidence.
+func (mr *MockDatabaserMockRecorder) FetchEquivocationEvidence(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEquivocationEvidence", reflect.TypeOf((*MockDatabaser)(nil).FetchEquivocationEvidence), arg0)
+}
+
+// FetchMissedMessages mocks base method.
+func (m *MockDatabaser) FetchMissedMessages(arg0 types.Bytes32, arg1 uint64, arg2 int) ([]messages.OperatorParticipation, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMissedMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]messages.OperatorParticipation)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchMissedMessages indicates an expected call of FetchMissedMessages.
+func (mr *MockDatabaserMockRecorder) FetchMissedMessages(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMissedMessages", reflect.TypeOf((*MockDatabaser)(nil).FetchMissedMessages), arg0, arg1, arg2)
+}
+
+// FetchOldestCheckpointMessageTimestamp mocks base method.
+func (m *MockDatabaser) FetchOldestCheckpointMessageTimestamp(arg0, arg1 uint64) (uint64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOldestCheckpointMessageTimestamp", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchOldestCheckpointMessageTimestamp indicates an expected call of FetchOldestCheckpointMessageTimestamp.
+func (mr *MockDatabaserMockRecorder) FetchOldestCheckpointMessageTimestamp(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOldestCheckpointMessageTimestamp", reflect.TypeOf((*MockDatabaser)(nil).FetchOldestCheckpointMessageTimestamp), arg0, arg1)
+}
+
 // FetchOperatorSetUpdate mocks base method.
 func (m *MockDatabaser) FetchOperatorSetUpdate(arg0 uint64) (*messages.OperatorSetUpdateMessage, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +255,77 @@ func (mr *MockDatabaserMockRecorder) FetchStateRootUpdateAggregation(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchStateRootUpdateAggregation", reflect.TypeOf((*MockDatabaser)(nil).FetchStateRootUpdateAggregation), arg0, arg1)
 }
 
+// PruneOperatorParticipations mocks base method.
+func (m *MockDatabaser) PruneOperatorParticipations(arg0 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneOperatorParticipations", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneOperatorParticipations indicates an expected call of PruneOperatorParticipations.
+func (mr *MockDatabaserMockRecorder) PruneOperatorParticipations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneOperatorParticipations", reflect.TypeOf((*MockDatabaser)(nil).PruneOperatorParticipations), arg0)
+}
+
+// StoreAuditLogEntry mocks base method.
+func (m *MockDatabaser) StoreAuditLogEntry(arg0 types0.AuditLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreAuditLogEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreAuditLogEntry indicates an expected call of StoreAuditLogEntry.
+func (mr *MockDatabaserMockRecorder) StoreAuditLogEntry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAuditLogEntry", reflect.TypeOf((*MockDatabaser)(nil).StoreAuditLogEntry), arg0)
+}
+
+// StoreCursor mocks base method.
+func (m *MockDatabaser) StoreCursor(arg0 string, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCursor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCursor indicates an expected call of StoreCursor.
+func (mr *MockDatabaserMockRecorder) StoreCursor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCursor", reflect.TypeOf((*MockDatabaser)(nil).StoreCursor), arg0, arg1)
+}
+
+// StoreEquivocationEvidence mocks base method.
+func (m *MockDatabaser) StoreEquivocationEvidence(arg0 messages.EquivocationEvidence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEquivocationEvidence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreEquivocationEvidence indicates an expected call of StoreEquivocationEvidence.
+func (mr *MockDatabaserMockRecorder) StoreEquivocationEvidence(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEquivocationEvidence", reflect.TypeOf((*MockDatabaser)(nil).StoreEquivocationEvidence), arg0)
+}
+
+// StoreOperatorParticipations mocks base method.
+func (m *MockDatabaser) StoreOperatorParticipations(arg0 []messages.OperatorParticipation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOperatorParticipations", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOperatorParticipations indicates an expected call of StoreOperatorParticipations.
+func (mr *MockDatabaserMockRecorder) StoreOperatorParticipations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOperatorParticipations", reflect.TypeOf((*MockDatabaser)(nil).StoreOperatorParticipations), arg0)
+}
+
 // StoreOperatorSetUpdate mocks base method.
 func (m *MockDatabaser) StoreOperatorSetUpdate(arg0 messages.OperatorSetUpdateMessage) (*models.OperatorSetUpdateMessage, error) {
 	m.ctrl.T.Helper()
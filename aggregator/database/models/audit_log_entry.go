@@ -0,0 +1,35 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Nuffle-Labs/nffl/aggregator/types"
+)
+
+type AuditLogEntry struct {
+	gorm.Model
+
+	Action     string `gorm:"index"`
+	Params     string
+	RemoteAddr string
+	Error      string
+}
+
+func NewAuditLogEntryModel(entry types.AuditLogEntry) AuditLogEntry {
+	return AuditLogEntry{
+		Action:     entry.Action,
+		Params:     entry.Params,
+		RemoteAddr: entry.RemoteAddr,
+		Error:      entry.Error,
+	}
+}
+
+func (model AuditLogEntry) ToEntry() types.AuditLogEntry {
+	return types.AuditLogEntry{
+		Timestamp:  model.CreatedAt,
+		Action:     model.Action,
+		Params:     model.Params,
+		RemoteAddr: model.RemoteAddr,
+		Error:      model.Error,
+	}
+}
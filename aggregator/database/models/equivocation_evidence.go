@@ -0,0 +1,53 @@
+package models
+
+import (
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"gorm.io/gorm"
+
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+type EquivocationEvidence struct {
+	gorm.Model
+
+	MessageType     string `gorm:"uniqueIndex:equivocation_evidence_key"`
+	MessageKey      []byte `gorm:"uniqueIndex:equivocation_evidence_key"`
+	OperatorId      []byte `gorm:"uniqueIndex:equivocation_evidence_key;index"`
+	EthBlockNumber  uint64 `gorm:"type:text"`
+	FirstMessage    []byte
+	FirstDigest     []byte
+	FirstSignature  *bls.Signature `gorm:"type:json;serializer:json"`
+	SecondMessage   []byte
+	SecondDigest    []byte
+	SecondSignature *bls.Signature `gorm:"type:json;serializer:json"`
+}
+
+func NewEquivocationEvidenceModel(evidence messages.EquivocationEvidence) EquivocationEvidence {
+	return EquivocationEvidence{
+		MessageType:     string(evidence.MessageType),
+		MessageKey:      evidence.MessageKey[:],
+		OperatorId:      evidence.OperatorId[:],
+		EthBlockNumber:  evidence.EthBlockNumber,
+		FirstMessage:    evidence.FirstMessage,
+		FirstDigest:     evidence.FirstDigest[:],
+		FirstSignature:  evidence.FirstSignature,
+		SecondMessage:   evidence.SecondMessage,
+		SecondDigest:    evidence.SecondDigest[:],
+		SecondSignature: evidence.SecondSignature,
+	}
+}
+
+func (model EquivocationEvidence) ToMessage() messages.EquivocationEvidence {
+	return messages.EquivocationEvidence{
+		MessageType:     messages.MessageType(model.MessageType),
+		MessageKey:      [32]byte(model.MessageKey),
+		OperatorId:      [32]byte(model.OperatorId),
+		EthBlockNumber:  model.EthBlockNumber,
+		FirstMessage:    model.FirstMessage,
+		FirstDigest:     [32]byte(model.FirstDigest),
+		FirstSignature:  model.FirstSignature,
+		SecondMessage:   model.SecondMessage,
+		SecondDigest:    [32]byte(model.SecondDigest),
+		SecondSignature: model.SecondSignature,
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Block up to which a log subscription delivered all logs
+type EventCursor struct {
+	gorm.Model
+
+	Key   string `gorm:"uniqueIndex"`
+	Block uint64 `gorm:"type:text"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+type OperatorParticipation struct {
+	gorm.Model
+
+	MessageKey     []byte `gorm:"uniqueIndex:operator_participation_key"`
+	MessageType    string `gorm:"uniqueIndex:operator_participation_key;index"`
+	RollupId       uint32
+	OperatorId     []byte `gorm:"uniqueIndex:operator_participation_key;index"`
+	EthBlockNumber uint64 `gorm:"type:text"`
+	Signed         bool
+}
+
+func NewOperatorParticipationModel(participation messages.OperatorParticipation) OperatorParticipation {
+	return OperatorParticipation{
+		MessageKey:     participation.MessageKey[:],
+		MessageType:    string(participation.MessageType),
+		RollupId:       participation.RollupId,
+		OperatorId:     participation.OperatorId[:],
+		EthBlockNumber: participation.EthBlockNumber,
+		Signed:         participation.Signed,
+	}
+}
+
+func (model OperatorParticipation) ToMessage() messages.OperatorParticipation {
+	return messages.OperatorParticipation{
+		MessageKey:     [32]byte(model.MessageKey),
+		MessageType:    messages.MessageType(model.MessageType),
+		RollupId:       model.RollupId,
+		OperatorId:     [32]byte(model.OperatorId),
+		EthBlockNumber: model.EthBlockNumber,
+		Signed:         model.Signed,
+	}
+}
@@ -3,6 +3,7 @@ package aggregator
 import (
 	"fmt"
 
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -22,6 +23,15 @@ type AggregatorEventListener interface {
 	ObserveLastCheckpointReferenceSent(referenceId uint32)
 	ObserveLastCheckpointTaskReferenceReceived(referenceId uint32)
 	ObserveLastCheckpointTaskReferenceAggregated(referenceId uint32)
+	IncOperatorEquivocations(operatorId eigentypes.OperatorId)
+	IncLateSignatures(operatorId eigentypes.OperatorId)
+	ObserveOperatorLiveness(operatorId eigentypes.OperatorId, score float64)
+	ObserveCheckpointTaskResponseGap(seconds float64)
+	IncCheckpointTaskResubmissions()
+	IncUnansweredCheckpointTasks()
+	IncCheckpointDecisions(sent bool, reason string)
+	ObserveCheckpointPendingMessages(count uint64)
+	ObserveCheckpointStaleness(seconds float64)
 }
 
 type SelectiveAggregatorListener struct {
@@ -36,6 +46,15 @@ type SelectiveAggregatorListener struct {
 	ObserveLastCheckpointReferenceSentCb           func(referenceId uint32)
 	ObserveLastCheckpointTaskReferenceReceivedCb   func(referenceId uint32)
 	ObserveLastCheckpointTaskReferenceAggregatedCb func(referenceId uint32)
+	IncOperatorEquivocationsCb                     func(operatorId eigentypes.OperatorId)
+	IncLateSignaturesCb                            func(operatorId eigentypes.OperatorId)
+	ObserveOperatorLivenessCb                      func(operatorId eigentypes.OperatorId, score float64)
+	ObserveCheckpointTaskResponseGapCb             func(seconds float64)
+	IncCheckpointTaskResubmissionsCb               func()
+	IncUnansweredCheckpointTasksCb                 func()
+	IncCheckpointDecisionsCb                       func(sent bool, reason string)
+	ObserveCheckpointPendingMessagesCb             func(count uint64)
+	ObserveCheckpointStalenessCb                   func(seconds float64)
 }
 
 func (l *SelectiveAggregatorListener) ObserveLastOperatorSetUpdateAggregated(operatorSetUpdateId uint64) {
@@ -104,6 +123,60 @@ func (l *SelectiveAggregatorListener) ObserveLastCheckpointTaskReferenceAggregat
 	}
 }
 
+func (l *SelectiveAggregatorListener) IncOperatorEquivocations(operatorId eigentypes.OperatorId) {
+	if l.IncOperatorEquivocationsCb != nil {
+		l.IncOperatorEquivocationsCb(operatorId)
+	}
+}
+
+func (l *SelectiveAggregatorListener) IncLateSignatures(operatorId eigentypes.OperatorId) {
+	if l.IncLateSignaturesCb != nil {
+		l.IncLateSignaturesCb(operatorId)
+	}
+}
+
+func (l *SelectiveAggregatorListener) ObserveOperatorLiveness(operatorId eigentypes.OperatorId, score float64) {
+	if l.ObserveOperatorLivenessCb != nil {
+		l.ObserveOperatorLivenessCb(operatorId, score)
+	}
+}
+
+func (l *SelectiveAggregatorListener) ObserveCheckpointTaskResponseGap(seconds float64) {
+	if l.ObserveCheckpointTaskResponseGapCb != nil {
+		l.ObserveCheckpointTaskResponseGapCb(seconds)
+	}
+}
+
+func (l *SelectiveAggregatorListener) IncCheckpointTaskResubmissions() {
+	if l.IncCheckpointTaskResubmissionsCb != nil {
+		l.IncCheckpointTaskResubmissionsCb()
+	}
+}
+
+func (l *SelectiveAggregatorListener) IncUnansweredCheckpointTasks() {
+	if l.IncUnansweredCheckpointTasksCb != nil {
+		l.IncUnansweredCheckpointTasksCb()
+	}
+}
+
+func (l *SelectiveAggregatorListener) IncCheckpointDecisions(sent bool, reason string) {
+	if l.IncCheckpointDecisionsCb != nil {
+		l.IncCheckpointDecisionsCb(sent, reason)
+	}
+}
+
+func (l *SelectiveAggregatorListener) ObserveCheckpointPendingMessages(count uint64) {
+	if l.ObserveCheckpointPendingMessagesCb != nil {
+		l.ObserveCheckpointPendingMessagesCb(count)
+	}
+}
+
+func (l *SelectiveAggregatorListener) ObserveCheckpointStaleness(seconds float64) {
+	if l.ObserveCheckpointStalenessCb != nil {
+		l.ObserveCheckpointStalenessCb(seconds)
+	}
+}
+
 func MakeAggregatorMetrics(registry *prometheus.Registry) (AggregatorEventListener, error) {
 	lastStateRootUpdateAggregated := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -228,6 +301,110 @@ func MakeAggregatorMetrics(registry *prometheus.Registry) (AggregatorEventListen
 		return nil, fmt.Errorf("error registering lastCheckpointTaskReferenceAggregated gauge: %w", err)
 	}
 
+	operatorEquivocations := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "operator_equivocations_total",
+			Help:      "Total number of conflicting message digests signed per operator ID",
+		},
+		[]string{"operator_id"},
+	)
+	if err := registry.Register(operatorEquivocations); err != nil {
+		return nil, fmt.Errorf("error registering operatorEquivocations counter: %w", err)
+	}
+
+	lateSignatures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "late_signatures_total",
+			Help:      "Total number of signatures aggregated after their message was finished per operator ID",
+		},
+		[]string{"operator_id"},
+	)
+	if err := registry.Register(lateSignatures); err != nil {
+		return nil, fmt.Errorf("error registering lateSignatures counter: %w", err)
+	}
+
+	operatorLiveness := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "operator_liveness",
+			Help:      "Fraction of the latest finished messages signed per operator ID",
+		},
+		[]string{"operator_id"},
+	)
+	if err := registry.Register(operatorLiveness); err != nil {
+		return nil, fmt.Errorf("error registering operatorLiveness gauge: %w", err)
+	}
+
+	checkpointTaskResponseGap := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "checkpoint_task_response_gap_seconds",
+			Help:      "Time between a checkpoint task's creation and its response being seen onchain",
+			Buckets:   []float64{30, 60, 120, 300, 600, 900, 1200, 1800},
+		},
+	)
+	if err := registry.Register(checkpointTaskResponseGap); err != nil {
+		return nil, fmt.Errorf("error registering checkpointTaskResponseGap histogram: %w", err)
+	}
+
+	checkpointTaskResubmissions := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "checkpoint_task_resubmissions_total",
+			Help:      "Total number of checkpoint task responses resubmitted by the reconciler",
+		},
+	)
+	if err := registry.Register(checkpointTaskResubmissions); err != nil {
+		return nil, fmt.Errorf("error registering checkpointTaskResubmissions counter: %w", err)
+	}
+
+	unansweredCheckpointTasks := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "unanswered_checkpoint_tasks_total",
+			Help:      "Total number of checkpoint tasks whose response window passed without a response",
+		},
+	)
+	if err := registry.Register(unansweredCheckpointTasks); err != nil {
+		return nil, fmt.Errorf("error registering unansweredCheckpointTasks counter: %w", err)
+	}
+
+	checkpointDecisions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "checkpoint_decisions_total",
+			Help:      "Total number of checkpoint scheduling decisions per outcome and reason",
+		},
+		[]string{"sent", "reason"},
+	)
+	if err := registry.Register(checkpointDecisions); err != nil {
+		return nil, fmt.Errorf("error registering checkpointDecisions counter: %w", err)
+	}
+
+	checkpointPendingMessages := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "checkpoint_pending_messages",
+			Help:      "Aggregated messages since the last checkpoint at the latest scheduling decision",
+		},
+	)
+	if err := registry.Register(checkpointPendingMessages); err != nil {
+		return nil, fmt.Errorf("error registering checkpointPendingMessages gauge: %w", err)
+	}
+
+	checkpointStaleness := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: AggregatorNamespace,
+			Name:      "checkpoint_staleness_seconds",
+			Help:      "Age of the oldest message pending a checkpoint at the latest scheduling decision",
+		},
+	)
+	if err := registry.Register(checkpointStaleness); err != nil {
+		return nil, fmt.Errorf("error registering checkpointStaleness gauge: %w", err)
+	}
+
 	return &SelectiveAggregatorListener{
 		ObserveLastStateRootUpdateAggregatedCb: func(rollupId uint32, blockNumber uint64) {
 			lastStateRootUpdateAggregated.WithLabelValues(fmt.Sprintf("%d", rollupId)).Set(float64(blockNumber))
@@ -262,5 +439,32 @@ func MakeAggregatorMetrics(registry *prometheus.Registry) (AggregatorEventListen
 		ObserveLastCheckpointTaskReferenceAggregatedCb: func(referenceId uint32) {
 			lastCheckpointTaskReferenceAggregated.Set(float64(referenceId))
 		},
+		IncOperatorEquivocationsCb: func(operatorId eigentypes.OperatorId) {
+			operatorEquivocations.WithLabelValues(fmt.Sprintf("0x%x", operatorId)).Inc()
+		},
+		IncLateSignaturesCb: func(operatorId eigentypes.OperatorId) {
+			lateSignatures.WithLabelValues(fmt.Sprintf("0x%x", operatorId)).Inc()
+		},
+		ObserveOperatorLivenessCb: func(operatorId eigentypes.OperatorId, score float64) {
+			operatorLiveness.WithLabelValues(fmt.Sprintf("0x%x", operatorId)).Set(score)
+		},
+		ObserveCheckpointTaskResponseGapCb: func(seconds float64) {
+			checkpointTaskResponseGap.Observe(seconds)
+		},
+		IncCheckpointTaskResubmissionsCb: func() {
+			checkpointTaskResubmissions.Inc()
+		},
+		IncUnansweredCheckpointTasksCb: func() {
+			unansweredCheckpointTasks.Inc()
+		},
+		IncCheckpointDecisionsCb: func(sent bool, reason string) {
+			checkpointDecisions.WithLabelValues(fmt.Sprintf("%t", sent), reason).Inc()
+		},
+		ObserveCheckpointPendingMessagesCb: func(count uint64) {
+			checkpointPendingMessages.Set(float64(count))
+		},
+		ObserveCheckpointStalenessCb: func(seconds float64) {
+			checkpointStaleness.Set(seconds)
+		},
 	}, nil
 }
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Nuffle-Labs/nffl/aggregator (interfaces: AdminAggregatorer)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/admin_aggregator.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator AdminAggregatorer
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	blsagg "github.com/Nuffle-Labs/nffl/aggregator/blsagg"
+	types "github.com/Nuffle-Labs/nffl/aggregator/types"
+	messages "github.com/Nuffle-Labs/nffl/core/types/messages"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminAggregatorer is a mock of AdminAggregatorer interface.
+type MockAdminAggregatorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminAggregatorerMockRecorder
+}
+
+// MockAdminAggregatorerMockRecorder is the mock recorder for MockAdminAggregatorer.
+type MockAdminAggregatorerMockRecorder struct {
+	mock *MockAdminAggregatorer
+}
+
+// NewMockAdminAggregatorer creates a new mock instance.
+func NewMockAdminAggregatorer(ctrl *gomock.Controller) *MockAdminAggregatorer {
+	mock := &MockAdminAggregatorer{ctrl: ctrl}
+	mock.recorder = &MockAdminAggregatorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminAggregatorer) EXPECT() *MockAdminAggregatorerMockRecorder {
+	return m.recorder
+}
+
+// CheckpointTasksPaused mocks base method.
+func (m *MockAdminAggregatorer) CheckpointTasksPaused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckpointTasksPaused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckpointTasksPaused indicates an expected call of CheckpointTasksPaused.
+func (mr *MockAdminAggregatorerMockRecorder) CheckpointTasksPaused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckpointTasksPaused", reflect.TypeOf((*MockAdminAggregatorer)(nil).CheckpointTasksPaused))
+}
+
+// DropMessage mocks base method.
+func (m *MockAdminAggregatorer) DropMessage(arg0 messages.MessageType, arg1 [32]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropMessage indicates an expected call of DropMessage.
+func (mr *MockAdminAggregatorerMockRecorder) DropMessage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropMessage", reflect.TypeOf((*MockAdminAggregatorer)(nil).DropMessage), arg0, arg1)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockAdminAggregatorer) GetAuditLog(arg0 int) ([]types.AuditLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", arg0)
+	ret0, _ := ret[0].([]types.AuditLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockAdminAggregatorerMockRecorder) GetAuditLog(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockAdminAggregatorer)(nil).GetAuditLog), arg0)
+}
+
+// GetInFlightAggregations mocks base method.
+func (m *MockAdminAggregatorer) GetInFlightAggregations() []blsagg.ActiveMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInFlightAggregations")
+	ret0, _ := ret[0].([]blsagg.ActiveMessage)
+	return ret0
+}
+
+// GetInFlightAggregations indicates an expected call of GetInFlightAggregations.
+func (mr *MockAdminAggregatorerMockRecorder) GetInFlightAggregations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInFlightAggregations", reflect.TypeOf((*MockAdminAggregatorer)(nil).GetInFlightAggregations))
+}
+
+// PauseCheckpointTasks mocks base method.
+func (m *MockAdminAggregatorer) PauseCheckpointTasks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PauseCheckpointTasks")
+}
+
+// PauseCheckpointTasks indicates an expected call of PauseCheckpointTasks.
+func (mr *MockAdminAggregatorerMockRecorder) PauseCheckpointTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseCheckpointTasks", reflect.TypeOf((*MockAdminAggregatorer)(nil).PauseCheckpointTasks))
+}
+
+// ResumeCheckpointTasks mocks base method.
+func (m *MockAdminAggregatorer) ResumeCheckpointTasks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeCheckpointTasks")
+}
+
+// ResumeCheckpointTasks indicates an expected call of ResumeCheckpointTasks.
+func (mr *MockAdminAggregatorerMockRecorder) ResumeCheckpointTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeCheckpointTasks", reflect.TypeOf((*MockAdminAggregatorer)(nil).ResumeCheckpointTasks))
+}
+
+// ResyncRollupOperatorSets mocks base method.
+func (m *MockAdminAggregatorer) ResyncRollupOperatorSets(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncRollupOperatorSets", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResyncRollupOperatorSets indicates an expected call of ResyncRollupOperatorSets.
+func (mr *MockAdminAggregatorerMockRecorder) ResyncRollupOperatorSets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncRollupOperatorSets", reflect.TypeOf((*MockAdminAggregatorer)(nil).ResyncRollupOperatorSets), arg0)
+}
+
+// StoreAuditLogEntry mocks base method.
+func (m *MockAdminAggregatorer) StoreAuditLogEntry(arg0 types.AuditLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreAuditLogEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreAuditLogEntry indicates an expected call of StoreAuditLogEntry.
+func (mr *MockAdminAggregatorerMockRecorder) StoreAuditLogEntry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAuditLogEntry", reflect.TypeOf((*MockAdminAggregatorer)(nil).StoreAuditLogEntry), arg0)
+}
+
+// TriggerCheckpointTask mocks base method.
+func (m *MockAdminAggregatorer) TriggerCheckpointTask() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TriggerCheckpointTask")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TriggerCheckpointTask indicates an expected call of TriggerCheckpointTask.
+func (mr *MockAdminAggregatorerMockRecorder) TriggerCheckpointTask() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerCheckpointTask", reflect.TypeOf((*MockAdminAggregatorer)(nil).TriggerCheckpointTask))
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -destination=./mocks/message_blsagg.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator/blsagg MessageBlsAggregationService
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
@@ -16,6 +17,7 @@ import (
 	bls "github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	types "github.com/Layr-Labs/eigensdk-go/types"
 	blsagg "github.com/Nuffle-Labs/nffl/aggregator/blsagg"
+	messages "github.com/Nuffle-Labs/nffl/core/types/messages"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,18 +44,60 @@ func (m *MockMessageBlsAggregationService) EXPECT() *MockMessageBlsAggregationSe
 	return m.recorder
 }
 
-// GetResponseChannel mocks base method.
-func (m *MockMessageBlsAggregationService) GetResponseChannel() <-chan blsagg.MessageBlsAggregationServiceResponse {
+// DropMessage mocks base method.
+func (m *MockMessageBlsAggregationService) DropMessage(arg0 [32]byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResponseChannel")
-	ret0, _ := ret[0].(<-chan blsagg.MessageBlsAggregationServiceResponse)
+	ret := m.ctrl.Call(m, "DropMessage", arg0)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetResponseChannel indicates an expected call of GetResponseChannel.
-func (mr *MockMessageBlsAggregationServiceMockRecorder) GetResponseChannel() *gomock.Call {
+// DropMessage indicates an expected call of DropMessage.
+func (mr *MockMessageBlsAggregationServiceMockRecorder) DropMessage(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponseChannel", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).GetResponseChannel))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropMessage", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).DropMessage), arg0)
+}
+
+// GetActiveMessages mocks base method.
+func (m *MockMessageBlsAggregationService) GetActiveMessages() []blsagg.ActiveMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveMessages")
+	ret0, _ := ret[0].([]blsagg.ActiveMessage)
+	return ret0
+}
+
+// GetActiveMessages indicates an expected call of GetActiveMessages.
+func (mr *MockMessageBlsAggregationServiceMockRecorder) GetActiveMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveMessages", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).GetActiveMessages))
+}
+
+// GetEquivocationChannel mocks base method.
+func (m *MockMessageBlsAggregationService) GetEquivocationChannel() <-chan messages.EquivocationEvidence {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEquivocationChannel")
+	ret0, _ := ret[0].(<-chan messages.EquivocationEvidence)
+	return ret0
+}
+
+// GetEquivocationChannel indicates an expected call of GetEquivocationChannel.
+func (mr *MockMessageBlsAggregationServiceMockRecorder) GetEquivocationChannel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquivocationChannel", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).GetEquivocationChannel))
+}
+
+// GetResponseChannels mocks base method.
+func (m *MockMessageBlsAggregationService) GetResponseChannels() []<-chan blsagg.MessageBlsAggregationServiceResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResponseChannels")
+	ret0, _ := ret[0].([]<-chan blsagg.MessageBlsAggregationServiceResponse)
+	return ret0
+}
+
+// GetResponseChannels indicates an expected call of GetResponseChannels.
+func (mr *MockMessageBlsAggregationServiceMockRecorder) GetResponseChannels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponseChannels", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).GetResponseChannels))
 }
 
 // InitializeMessageIfNotExists mocks base method.
@@ -83,3 +127,15 @@ func (mr *MockMessageBlsAggregationServiceMockRecorder) ProcessNewSignature(arg0
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessNewSignature", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).ProcessNewSignature), arg0, arg1, arg2, arg3)
 }
+
+// SetListener mocks base method.
+func (m *MockMessageBlsAggregationService) SetListener(arg0 blsagg.EventListener) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetListener", arg0)
+}
+
+// SetListener indicates an expected call of SetListener.
+func (mr *MockMessageBlsAggregationServiceMockRecorder) SetListener(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetListener", reflect.TypeOf((*MockMessageBlsAggregationService)(nil).SetListener), arg0)
+}
@@ -5,13 +5,15 @@
 //
 //	mockgen -destination=./mocks/rest_aggregator.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator RestAggregatorer
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	reflect "reflect"
 
-	types "github.com/Nuffle-Labs/nffl/aggregator/types"
+	types "github.com/Layr-Labs/eigensdk-go/types"
+	types0 "github.com/Nuffle-Labs/nffl/aggregator/types"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,10 +41,10 @@ func (m *MockRestAggregatorer) EXPECT() *MockRestAggregatorerMockRecorder {
 }
 
 // GetCheckpointMessages mocks base method.
-func (m *MockRestAggregatorer) GetCheckpointMessages(arg0, arg1 uint64) (*types.GetCheckpointMessagesResponse, error) {
+func (m *MockRestAggregatorer) GetCheckpointMessages(arg0, arg1 uint64) (*types0.GetCheckpointMessagesResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCheckpointMessages", arg0, arg1)
-	ret0, _ := ret[0].(*types.GetCheckpointMessagesResponse)
+	ret0, _ := ret[0].(*types0.GetCheckpointMessagesResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -53,11 +55,41 @@ func (mr *MockRestAggregatorerMockRecorder) GetCheckpointMessages(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpointMessages", reflect.TypeOf((*MockRestAggregatorer)(nil).GetCheckpointMessages), arg0, arg1)
 }
 
+// GetEquivocationEvidence mocks base method.
+func (m *MockRestAggregatorer) GetEquivocationEvidence(arg0 *types.Bytes32) (*types0.GetEquivocationEvidenceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEquivocationEvidence", arg0)
+	ret0, _ := ret[0].(*types0.GetEquivocationEvidenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEquivocationEvidence indicates an expected call of GetEquivocationEvidence.
+func (mr *MockRestAggregatorerMockRecorder) GetEquivocationEvidence(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquivocationEvidence", reflect.TypeOf((*MockRestAggregatorer)(nil).GetEquivocationEvidence), arg0)
+}
+
+// GetOperatorMissedMessages mocks base method.
+func (m *MockRestAggregatorer) GetOperatorMissedMessages(arg0 types.Bytes32, arg1 uint64, arg2 int) (*types0.GetOperatorMissedMessagesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorMissedMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.GetOperatorMissedMessagesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorMissedMessages indicates an expected call of GetOperatorMissedMessages.
+func (mr *MockRestAggregatorerMockRecorder) GetOperatorMissedMessages(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorMissedMessages", reflect.TypeOf((*MockRestAggregatorer)(nil).GetOperatorMissedMessages), arg0, arg1, arg2)
+}
+
 // GetOperatorSetUpdateAggregation mocks base method.
-func (m *MockRestAggregatorer) GetOperatorSetUpdateAggregation(arg0 uint64) (*types.GetOperatorSetUpdateAggregationResponse, error) {
+func (m *MockRestAggregatorer) GetOperatorSetUpdateAggregation(arg0 uint64) (*types0.GetOperatorSetUpdateAggregationResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOperatorSetUpdateAggregation", arg0)
-	ret0, _ := ret[0].(*types.GetOperatorSetUpdateAggregationResponse)
+	ret0, _ := ret[0].(*types0.GetOperatorSetUpdateAggregationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -69,10 +101,10 @@ func (mr *MockRestAggregatorerMockRecorder) GetOperatorSetUpdateAggregation(arg0
 }
 
 // GetStateRootUpdateAggregation mocks base method.
-func (m *MockRestAggregatorer) GetStateRootUpdateAggregation(arg0 uint32, arg1 uint64) (*types.GetStateRootUpdateAggregationResponse, error) {
+func (m *MockRestAggregatorer) GetStateRootUpdateAggregation(arg0 uint32, arg1 uint64) (*types0.GetStateRootUpdateAggregationResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateRootUpdateAggregation", arg0, arg1)
-	ret0, _ := ret[0].(*types.GetStateRootUpdateAggregationResponse)
+	ret0, _ := ret[0].(*types0.GetStateRootUpdateAggregationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
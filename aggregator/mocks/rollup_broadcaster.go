@@ -5,6 +5,7 @@
 //
 //	mockgen -destination=./mocks/rollup_broadcaster.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator RollupBroadcasterer
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
@@ -77,3 +78,17 @@ func (mr *MockRollupBroadcastererMockRecorder) GetErrorChan() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorChan", reflect.TypeOf((*MockRollupBroadcasterer)(nil).GetErrorChan))
 }
+
+// ResyncOperatorSets mocks base method.
+func (m *MockRollupBroadcasterer) ResyncOperatorSets(arg0 context.Context, arg1 func(context.Context, uint64) (messages.OperatorSetUpdateMessage, contractSFFLRegistryRollup.RollupOperatorsSignatureInfo, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncOperatorSets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResyncOperatorSets indicates an expected call of ResyncOperatorSets.
+func (mr *MockRollupBroadcastererMockRecorder) ResyncOperatorSets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncOperatorSets", reflect.TypeOf((*MockRollupBroadcasterer)(nil).ResyncOperatorSets), arg0, arg1)
+}
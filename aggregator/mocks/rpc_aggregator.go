@@ -5,6 +5,7 @@
 //
 //	mockgen -destination=./mocks/rpc_aggregator.go -package=mocks github.com/Nuffle-Labs/nffl/aggregator RpcAggregatorer
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
@@ -84,44 +85,59 @@ func (mr *MockRpcAggregatorerMockRecorder) GetRegistryCoordinatorAddress(arg0 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryCoordinatorAddress", reflect.TypeOf((*MockRpcAggregatorer)(nil).GetRegistryCoordinatorAddress), arg0)
 }
 
+// GetRollupHead mocks base method.
+func (m *MockRpcAggregatorer) GetRollupHead(arg0 uint32) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRollupHead", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRollupHead indicates an expected call of GetRollupHead.
+func (mr *MockRpcAggregatorerMockRecorder) GetRollupHead(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRollupHead", reflect.TypeOf((*MockRpcAggregatorer)(nil).GetRollupHead), arg0)
+}
+
 // ProcessSignedCheckpointTaskResponse mocks base method.
-func (m *MockRpcAggregatorer) ProcessSignedCheckpointTaskResponse(arg0 *messages.SignedCheckpointTaskResponse) error {
+func (m *MockRpcAggregatorer) ProcessSignedCheckpointTaskResponse(arg0 context.Context, arg1 *messages.SignedCheckpointTaskResponse) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessSignedCheckpointTaskResponse", arg0)
+	ret := m.ctrl.Call(m, "ProcessSignedCheckpointTaskResponse", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessSignedCheckpointTaskResponse indicates an expected call of ProcessSignedCheckpointTaskResponse.
-func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedCheckpointTaskResponse(arg0 any) *gomock.Call {
+func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedCheckpointTaskResponse(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedCheckpointTaskResponse", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedCheckpointTaskResponse), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedCheckpointTaskResponse", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedCheckpointTaskResponse), arg0, arg1)
 }
 
 // ProcessSignedOperatorSetUpdateMessage mocks base method.
-func (m *MockRpcAggregatorer) ProcessSignedOperatorSetUpdateMessage(arg0 *messages.SignedOperatorSetUpdateMessage) error {
+func (m *MockRpcAggregatorer) ProcessSignedOperatorSetUpdateMessage(arg0 context.Context, arg1 *messages.SignedOperatorSetUpdateMessage) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessSignedOperatorSetUpdateMessage", arg0)
+	ret := m.ctrl.Call(m, "ProcessSignedOperatorSetUpdateMessage", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessSignedOperatorSetUpdateMessage indicates an expected call of ProcessSignedOperatorSetUpdateMessage.
-func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedOperatorSetUpdateMessage(arg0 any) *gomock.Call {
+func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedOperatorSetUpdateMessage(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedOperatorSetUpdateMessage", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedOperatorSetUpdateMessage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedOperatorSetUpdateMessage", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedOperatorSetUpdateMessage), arg0, arg1)
 }
 
 // ProcessSignedStateRootUpdateMessage mocks base method.
-func (m *MockRpcAggregatorer) ProcessSignedStateRootUpdateMessage(arg0 *messages.SignedStateRootUpdateMessage) error {
+func (m *MockRpcAggregatorer) ProcessSignedStateRootUpdateMessage(arg0 context.Context, arg1 *messages.SignedStateRootUpdateMessage) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessSignedStateRootUpdateMessage", arg0)
+	ret := m.ctrl.Call(m, "ProcessSignedStateRootUpdateMessage", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessSignedStateRootUpdateMessage indicates an expected call of ProcessSignedStateRootUpdateMessage.
-func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedStateRootUpdateMessage(arg0 any) *gomock.Call {
+func (mr *MockRpcAggregatorerMockRecorder) ProcessSignedStateRootUpdateMessage(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedStateRootUpdateMessage", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedStateRootUpdateMessage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSignedStateRootUpdateMessage", reflect.TypeOf((*MockRpcAggregatorer)(nil).ProcessSignedStateRootUpdateMessage), arg0, arg1)
 }
@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
+	blsapkreg "github.com/Layr-Labs/eigensdk-go/contracts/bindings/BLSApkRegistry"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/services/operatorsinfo"
@@ -12,6 +14,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	// Socket updates kept per operator to roll back reorged out ones
+	SOCKET_UPDATES_HISTORY_SIZE = 16
+)
+
 type OperatorRegistrationsService interface {
 	operatorsinfo.OperatorsInfoService
 
@@ -29,6 +36,15 @@ type OperatorRegistrationsServiceInMemory struct {
 	addrToId    map[common.Address]types.OperatorId
 	pubkeysById map[types.OperatorId]types.OperatorPubkeys
 	socketById  map[types.OperatorId]types.Socket
+	// Socket updates received through the subscription, latest last, so that
+	// reorged out updates can be rolled back
+	socketUpdatesById map[types.OperatorId][]socketUpdate
+}
+
+type socketUpdate struct {
+	socket   types.Socket
+	block    uint64
+	logIndex uint
 }
 
 type queryByAddr struct {
@@ -67,6 +83,7 @@ func NewOperatorRegistrationsServiceInMemory(
 		addrToId:              make(map[common.Address]types.OperatorId),
 		pubkeysById:           make(map[types.OperatorId]types.OperatorPubkeys),
 		socketById:            make(map[types.OperatorId]types.Socket),
+		socketUpdatesById:     make(map[types.OperatorId][]socketUpdate),
 	}
 	err := ors.asyncInit(ctx, queryByAddrC, queryByIdC)
 	if err != nil {
@@ -131,32 +148,10 @@ func (ors *OperatorRegistrationsServiceInMemory) startServiceInGoroutine(ctx con
 				ors.logger.Error("Error in websocket subscription for new socket registration events", "err", err, "service", "OperatorRegistrationsServiceInMemory")
 
 			case newPubkeyRegistrationEvent := <-newPubkeyRegistrationC:
-				pubkeys := types.OperatorPubkeys{
-					G1Pubkey: bls.NewG1Point(newPubkeyRegistrationEvent.PubkeyG1.X, newPubkeyRegistrationEvent.PubkeyG1.Y),
-					G2Pubkey: bls.NewG2Point(newPubkeyRegistrationEvent.PubkeyG2.X, newPubkeyRegistrationEvent.PubkeyG2.Y),
-				}
-				operatorId := types.OperatorIdFromG1Pubkey(pubkeys.G1Pubkey)
-				operatorAddr := newPubkeyRegistrationEvent.Operator
-
-				ors.idToAddr[operatorId] = operatorAddr
-				ors.addrToId[operatorAddr] = operatorId
-				ors.pubkeysById[operatorId] = pubkeys
-
-				ors.logger.Debug("Added operator info to dict",
-					"service", "OperatorRegistrationsServiceInMemory",
-					"block", newPubkeyRegistrationEvent.Raw.BlockNumber,
-					"operatorAddr", operatorAddr,
-					"operatorId", operatorId,
-					"G1pubkey", pubkeys.G1Pubkey,
-					"G2pubkey", pubkeys.G2Pubkey,
-				)
+				ors.handlePubkeyRegistration(newPubkeyRegistrationEvent)
 
 			case newSocketRegistrationEvent := <-newSocketRegistrationC:
-				operatorId := types.OperatorId(newSocketRegistrationEvent.OperatorId)
-				socket := types.Socket(newSocketRegistrationEvent.Socket)
-				ors.logger.Debug("Received new socket registration event", "service", "OperatorRegistrationsServiceInMemory", "operatorId", operatorId, "socket", socket)
-
-				ors.socketById[operatorId] = socket
+				ors.handleSocketUpdate(newSocketRegistrationEvent)
 
 			case q := <-queryByAddrC:
 				operatorId, idExists := ors.addrToId[q.operatorAddr]
@@ -183,6 +178,97 @@ func (ors *OperatorRegistrationsServiceInMemory) startServiceInGoroutine(ctx con
 	}()
 }
 
+// Adds the registered operator, or removes it if the registration was reorged
+// out
+func (ors *OperatorRegistrationsServiceInMemory) handlePubkeyRegistration(event *blsapkreg.ContractBLSApkRegistryNewPubkeyRegistration) {
+	pubkeys := types.OperatorPubkeys{
+		G1Pubkey: bls.NewG1Point(event.PubkeyG1.X, event.PubkeyG1.Y),
+		G2Pubkey: bls.NewG2Point(event.PubkeyG2.X, event.PubkeyG2.Y),
+	}
+	operatorId := types.OperatorIdFromG1Pubkey(pubkeys.G1Pubkey)
+	operatorAddr := event.Operator
+
+	if event.Raw.Removed {
+		ors.logger.Warn("Pubkey registration was reorged out, removing operator",
+			"service", "OperatorRegistrationsServiceInMemory",
+			"block", event.Raw.BlockNumber,
+			"operatorAddr", operatorAddr,
+			"operatorId", operatorId,
+		)
+
+		if ors.addrToId[operatorAddr] == operatorId {
+			delete(ors.addrToId, operatorAddr)
+		}
+		if ors.idToAddr[operatorId] == operatorAddr {
+			delete(ors.idToAddr, operatorId)
+			delete(ors.pubkeysById, operatorId)
+		}
+		return
+	}
+
+	ors.idToAddr[operatorId] = operatorAddr
+	ors.addrToId[operatorAddr] = operatorId
+	ors.pubkeysById[operatorId] = pubkeys
+
+	ors.logger.Debug("Added operator info to dict",
+		"service", "OperatorRegistrationsServiceInMemory",
+		"block", event.Raw.BlockNumber,
+		"operatorAddr", operatorAddr,
+		"operatorId", operatorId,
+		"G1pubkey", pubkeys.G1Pubkey,
+		"G2pubkey", pubkeys.G2Pubkey,
+	)
+}
+
+// Sets the operator socket, or restores the previous one if the update was
+// reorged out
+func (ors *OperatorRegistrationsServiceInMemory) handleSocketUpdate(event *regcoord.ContractRegistryCoordinatorOperatorSocketUpdate) {
+	operatorId := types.OperatorId(event.OperatorId)
+	socket := types.Socket(event.Socket)
+	ors.logger.Debug("Received new socket registration event", "service", "OperatorRegistrationsServiceInMemory", "operatorId", operatorId, "socket", socket, "removed", event.Raw.Removed)
+
+	updates := ors.socketUpdatesById[operatorId]
+
+	if !event.Raw.Removed {
+		if len(updates) == 0 {
+			// Keeps the socket queried on startup to fall back to
+			if previous, exists := ors.socketById[operatorId]; exists {
+				updates = append(updates, socketUpdate{socket: previous})
+			}
+		}
+
+		updates = append(updates, socketUpdate{socket, event.Raw.BlockNumber, event.Raw.Index})
+		if len(updates) > SOCKET_UPDATES_HISTORY_SIZE {
+			updates = updates[len(updates)-SOCKET_UPDATES_HISTORY_SIZE:]
+		}
+
+		ors.socketUpdatesById[operatorId] = updates
+		ors.socketById[operatorId] = socket
+		return
+	}
+
+	for i := len(updates) - 1; i >= 0; i-- {
+		if updates[i].block == event.Raw.BlockNumber && updates[i].logIndex == event.Raw.Index {
+			updates = append(updates[:i], updates[i+1:]...)
+			break
+		}
+	}
+	ors.socketUpdatesById[operatorId] = updates
+
+	if len(updates) == 0 {
+		delete(ors.socketById, operatorId)
+	} else {
+		ors.socketById[operatorId] = updates[len(updates)-1].socket
+	}
+
+	ors.logger.Warn("Socket update was reorged out, restored previous socket",
+		"service", "OperatorRegistrationsServiceInMemory",
+		"block", event.Raw.BlockNumber,
+		"operatorId", operatorId,
+		"socket", ors.socketById[operatorId],
+	)
+}
+
 func (ors *OperatorRegistrationsServiceInMemory) queryPastRegisteredOperators(ctx context.Context) error {
 	alreadyRegisteredOperatorAddrs, alreadyRegisteredOperatorPubkeys, err := ors.avsRegistryReader.QueryExistingRegisteredOperatorPubKeys(ctx, nil, nil)
 	if err != nil {
@@ -0,0 +1,81 @@
+package aggregator
+
+import (
+	"math/big"
+	"testing"
+
+	blsapkreg "github.com/Layr-Labs/eigensdk-go/contracts/bindings/BLSApkRegistry"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestRegistrationsService() *OperatorRegistrationsServiceInMemory {
+	return &OperatorRegistrationsServiceInMemory{
+		logger:            logging.NewNoopLogger(),
+		idToAddr:          make(map[types.OperatorId]common.Address),
+		addrToId:          make(map[common.Address]types.OperatorId),
+		pubkeysById:       make(map[types.OperatorId]types.OperatorPubkeys),
+		socketById:        make(map[types.OperatorId]types.Socket),
+		socketUpdatesById: make(map[types.OperatorId][]socketUpdate),
+	}
+}
+
+func TestOperatorRegistrationsReorgedPubkey(t *testing.T) {
+	ors := createTestRegistrationsService()
+
+	operatorAddr := common.Address{1}
+	event := &blsapkreg.ContractBLSApkRegistryNewPubkeyRegistration{
+		Operator: operatorAddr,
+		PubkeyG1: blsapkreg.BN254G1Point{X: big.NewInt(1), Y: big.NewInt(2)},
+		PubkeyG2: blsapkreg.BN254G2Point{X: [2]*big.Int{big.NewInt(3), big.NewInt(4)}, Y: [2]*big.Int{big.NewInt(5), big.NewInt(6)}},
+		Raw:      ethtypes.Log{BlockNumber: 10},
+	}
+	operatorId := types.OperatorIdFromG1Pubkey(bls.NewG1Point(big.NewInt(1), big.NewInt(2)))
+
+	ors.handlePubkeyRegistration(event)
+	assert.Equal(t, operatorId, ors.addrToId[operatorAddr])
+	assert.Contains(t, ors.pubkeysById, operatorId)
+
+	removedEvent := *event
+	removedEvent.Raw.Removed = true
+	ors.handlePubkeyRegistration(&removedEvent)
+
+	assert.NotContains(t, ors.addrToId, operatorAddr)
+	assert.NotContains(t, ors.idToAddr, operatorId)
+	assert.NotContains(t, ors.pubkeysById, operatorId)
+}
+
+func TestOperatorRegistrationsReorgedSocket(t *testing.T) {
+	ors := createTestRegistrationsService()
+
+	operatorId := types.OperatorId{1}
+	// Socket queried on startup
+	ors.socketById[operatorId] = "initial:1"
+
+	socketEvent := func(socket string, block uint64, removed bool) *regcoord.ContractRegistryCoordinatorOperatorSocketUpdate {
+		return &regcoord.ContractRegistryCoordinatorOperatorSocketUpdate{
+			OperatorId: operatorId,
+			Socket:     socket,
+			Raw:        ethtypes.Log{BlockNumber: block, Removed: removed},
+		}
+	}
+
+	ors.handleSocketUpdate(socketEvent("first:1", 10, false))
+	ors.handleSocketUpdate(socketEvent("second:1", 11, false))
+	assert.Equal(t, types.Socket("second:1"), ors.socketById[operatorId])
+
+	ors.handleSocketUpdate(socketEvent("second:1", 11, true))
+	assert.Equal(t, types.Socket("first:1"), ors.socketById[operatorId])
+
+	ors.handleSocketUpdate(socketEvent("first:1", 10, true))
+	assert.Equal(t, types.Socket("initial:1"), ors.socketById[operatorId])
+
+	// Reincluded on the new chain
+	ors.handleSocketUpdate(socketEvent("first:1", 12, false))
+	assert.Equal(t, types.Socket("first:1"), ors.socketById[operatorId])
+}
@@ -0,0 +1,132 @@
+package aggregator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+const (
+	// Number of most recent finished messages liveness scores are computed over
+	LIVENESS_WINDOW_SIZE = 100
+	// How long operator participations are kept in the database
+	PARTICIPATION_RETENTION = 7 * 24 * time.Hour
+	// Interval between deletions of the participations past retention
+	PARTICIPATION_PRUNE_INTERVAL = time.Hour
+)
+
+// Message keys are only unique within a message type
+type participationKey struct {
+	messageType messages.MessageType
+	messageKey  coretypes.MessageKey
+}
+
+type livenessEntry struct {
+	key    participationKey
+	signed bool
+}
+
+// ParticipationTracker keeps a rolling window of the latest finished messages
+// for each operator, scoring their liveness as the fraction they signed.
+type ParticipationTracker struct {
+	windows map[eigentypes.OperatorId][]livenessEntry
+	lock    sync.RWMutex
+}
+
+func NewParticipationTracker() *ParticipationTracker {
+	return &ParticipationTracker{
+		windows: make(map[eigentypes.OperatorId][]livenessEntry),
+	}
+}
+
+// Records participations, updating the entries for messages already in an
+// operator's window, and returns the updated liveness scores
+func (t *ParticipationTracker) Record(participations []messages.OperatorParticipation) map[eigentypes.OperatorId]float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	scores := make(map[eigentypes.OperatorId]float64)
+	for _, participation := range participations {
+		window := t.windows[participation.OperatorId]
+		key := participationKey{messageType: participation.MessageType, messageKey: participation.MessageKey}
+
+		found := false
+		for i := range window {
+			if window[i].key == key {
+				window[i].signed = window[i].signed || participation.Signed
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			window = append(window, livenessEntry{key: key, signed: participation.Signed})
+			if len(window) > LIVENESS_WINDOW_SIZE {
+				window = window[len(window)-LIVENESS_WINDOW_SIZE:]
+			}
+		}
+
+		t.windows[participation.OperatorId] = window
+		scores[participation.OperatorId] = livenessScore(window)
+	}
+
+	return scores
+}
+
+// Returns an operator's liveness score, or false if none of its messages were
+// recorded
+func (t *ParticipationTracker) LivenessScore(operatorId eigentypes.OperatorId) (float64, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	window, ok := t.windows[operatorId]
+	if !ok {
+		return 0, false
+	}
+
+	return livenessScore(window), true
+}
+
+func livenessScore(window []livenessEntry) float64 {
+	if len(window) == 0 {
+		return 0
+	}
+
+	signed := 0
+	for _, entry := range window {
+		if entry.signed {
+			signed++
+		}
+	}
+
+	return float64(signed) / float64(len(window))
+}
+
+func (agg *Aggregator) pruneOperatorParticipationsLoop(ctx context.Context) {
+	ticker := time.NewTicker(PARTICIPATION_PRUNE_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			agg.pruneOperatorParticipations()
+		}
+	}
+}
+
+func (agg *Aggregator) pruneOperatorParticipations() {
+	pruned, err := agg.msgDb.PruneOperatorParticipations(agg.clock.Now().Add(-PARTICIPATION_RETENTION))
+	if err != nil {
+		agg.logger.Error("Aggregator could not prune operator participations", "err", err)
+		return
+	}
+
+	agg.logger.Debug("Pruned operator participations", "count", pruned)
+}
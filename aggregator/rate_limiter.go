@@ -0,0 +1,47 @@
+package aggregator
+
+import (
+	"sync"
+
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Sustained messages per second accepted from each operator
+	OPERATOR_RATE_LIMIT = 50
+	// Messages an operator can send at once before being rate limited, e.g.
+	// when catching up after a restart
+	OPERATOR_RATE_BURST = 200
+)
+
+// operatorRateLimiter holds a token bucket per operator.
+type operatorRateLimiter struct {
+	limiters map[eigentypes.OperatorId]*rate.Limiter
+	limit    rate.Limit
+	burst    int
+	lock     sync.Mutex
+}
+
+func newOperatorRateLimiter(limit rate.Limit, burst int) *operatorRateLimiter {
+	return &operatorRateLimiter{
+		limiters: make(map[eigentypes.OperatorId]*rate.Limiter),
+		limit:    limit,
+		burst:    burst,
+	}
+}
+
+// Takes a token from the operator's bucket, returning whether there was one
+// left. Must only be called once the operator's signature is verified, so
+// spoofed messages can't exhaust an operator's rate limit
+func (l *operatorRateLimiter) Allow(operatorId eigentypes.OperatorId) bool {
+	l.lock.Lock()
+	limiter, ok := l.limiters[operatorId]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[operatorId] = limiter
+	}
+	l.lock.Unlock()
+
+	return limiter.Allow()
+}
@@ -11,14 +11,18 @@ type EventListener interface {
 	IncStateRootUpdateRequests()
 	IncOperatorSetUpdateRequests()
 	IncCheckpointMessagesRequests()
+	IncEquivocationEvidenceRequests()
+	IncOperatorMissedMessagesRequests()
 	APIErrors()
 }
 
 type SelectiveListener struct {
-	IncStateRootUpdateRequestsCb    func()
-	IncOperatorSetUpdateRequestsCb  func()
-	IncCheckpointMessagesRequestsCb func()
-	APIErrorsCb                     func()
+	IncStateRootUpdateRequestsCb        func()
+	IncOperatorSetUpdateRequestsCb      func()
+	IncCheckpointMessagesRequestsCb     func()
+	IncEquivocationEvidenceRequestsCb   func()
+	IncOperatorMissedMessagesRequestsCb func()
+	APIErrorsCb                         func()
 }
 
 func (l *SelectiveListener) IncStateRootUpdateRequests() {
@@ -39,6 +43,18 @@ func (l *SelectiveListener) IncCheckpointMessagesRequests() {
 	}
 }
 
+func (l *SelectiveListener) IncEquivocationEvidenceRequests() {
+	if l.IncEquivocationEvidenceRequestsCb != nil {
+		l.IncEquivocationEvidenceRequestsCb()
+	}
+}
+
+func (l *SelectiveListener) IncOperatorMissedMessagesRequests() {
+	if l.IncOperatorMissedMessagesRequestsCb != nil {
+		l.IncOperatorMissedMessagesRequestsCb()
+	}
+}
+
 func (l *SelectiveListener) APIErrors() {
 	if l.APIErrorsCb != nil {
 		l.APIErrorsCb()
@@ -73,6 +89,24 @@ func MakeServerMetrics(registry *prometheus.Registry) (EventListener, error) {
 		return nil, fmt.Errorf("error registering checkpointMessagesRequests counter: %w", err)
 	}
 
+	equivocationEvidenceRequests := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: aggregator.AggregatorNamespace,
+		Name:      "equivocation_evidence_requests_total",
+		Help:      "Total number of equivocation evidence requests received",
+	})
+	if err := registry.Register(equivocationEvidenceRequests); err != nil {
+		return nil, fmt.Errorf("error registering equivocationEvidenceRequests counter: %w", err)
+	}
+
+	operatorMissedMessagesRequests := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: aggregator.AggregatorNamespace,
+		Name:      "operator_missed_messages_requests_total",
+		Help:      "Total number of operator missed messages requests received",
+	})
+	if err := registry.Register(operatorMissedMessagesRequests); err != nil {
+		return nil, fmt.Errorf("error registering operatorMissedMessagesRequests counter: %w", err)
+	}
+
 	apiErrors := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: aggregator.AggregatorNamespace,
 		Name:      "api_errors_total",
@@ -92,6 +126,12 @@ func MakeServerMetrics(registry *prometheus.Registry) (EventListener, error) {
 		IncCheckpointMessagesRequestsCb: func() {
 			checkpointMessagesRequests.Inc()
 		},
+		IncEquivocationEvidenceRequestsCb: func() {
+			equivocationEvidenceRequests.Inc()
+		},
+		IncOperatorMissedMessagesRequestsCb: func() {
+			operatorMissedMessagesRequests.Inc()
+		},
 		APIErrorsCb: func() {
 			apiErrors.Inc()
 		},
@@ -2,10 +2,13 @@ package rest_server
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -13,7 +16,16 @@ import (
 	"github.com/Nuffle-Labs/nffl/core"
 )
 
+const (
+	DEFAULT_MISSED_MESSAGES_LIMIT = 100
+	MAX_MISSED_MESSAGES_LIMIT     = 1000
+)
+
 var (
+	InvalidOperatorIdLengthError = errors.New("Invalid operator ID length")
+	InvalidLimitError            = errors.New("Invalid limit")
+	InvalidCursorError           = errors.New("Invalid cursor")
+
 	errorToCode = map[error]int{
 		aggregator.StateRootUpdateNotFoundError: http.StatusNotFound,
 		aggregator.StateRootAggNotFoundError:    http.StatusNotFound,
@@ -58,6 +70,8 @@ func (s *RestServer) Start() error {
 	router.HandleFunc("/aggregation/state-root-update", wrapRequest(s.listener.APIErrors, s.handleGetStateRootUpdateAggregation)).Methods("GET")
 	router.HandleFunc("/aggregation/operator-set-update", wrapRequest(s.listener.APIErrors, s.handleGetOperatorSetUpdateAggregation)).Methods("GET")
 	router.HandleFunc("/checkpoint/messages", wrapRequest(s.listener.APIErrors, s.handleGetCheckpointMessages)).Methods("GET")
+	router.HandleFunc("/equivocation/evidence", wrapRequest(s.listener.APIErrors, s.handleGetEquivocationEvidence)).Methods("GET")
+	router.HandleFunc("/operator/missed-messages", wrapRequest(s.listener.APIErrors, s.handleGetOperatorMissedMessages)).Methods("GET")
 
 	err := http.ListenAndServe(s.serverIpPortAddr, router)
 	if err != nil {
@@ -157,6 +171,86 @@ func (s *RestServer) handleGetCheckpointMessages(w http.ResponseWriter, r *http.
 	return json.NewEncoder(w).Encode(*response)
 }
 
+func (s *RestServer) handleGetEquivocationEvidence(w http.ResponseWriter, r *http.Request) error {
+	s.listener.IncEquivocationEvidenceRequests()
+	params := r.URL.Query()
+
+	var operatorId *eigentypes.OperatorId
+	if params.Has("operatorId") {
+		parsedOperatorId, err := parseOperatorId(params.Get("operatorId"))
+		if err != nil {
+			http.Error(w, "Invalid operatorId", http.StatusBadRequest)
+			return err
+		}
+
+		operatorId = &parsedOperatorId
+	}
+
+	response, err := s.app.GetEquivocationEvidence(operatorId)
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(*response)
+}
+
+func (s *RestServer) handleGetOperatorMissedMessages(w http.ResponseWriter, r *http.Request) error {
+	s.listener.IncOperatorMissedMessagesRequests()
+
+	params := r.URL.Query()
+	operatorId, err := parseOperatorId(params.Get("operatorId"))
+	if err != nil {
+		http.Error(w, "Invalid operatorId", http.StatusBadRequest)
+		return err
+	}
+
+	limit := DEFAULT_MISSED_MESSAGES_LIMIT
+	if params.Has("limit") {
+		parsedLimit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil || parsedLimit <= 0 || parsedLimit > MAX_MISSED_MESSAGES_LIMIT {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return InvalidLimitError
+		}
+
+		limit = parsedLimit
+	}
+
+	cursor := uint64(0)
+	if params.Has("cursor") {
+		cursor, err = strconv.ParseUint(params.Get("cursor"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return InvalidCursorError
+		}
+	}
+
+	response, err := s.app.GetOperatorMissedMessages(operatorId, cursor, limit)
+	if err != nil {
+		http.Error(w, err.Error(), mapErrorToCode(err))
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(*response)
+}
+
+func parseOperatorId(param string) (eigentypes.OperatorId, error) {
+	operatorIdBytes, err := hexutil.Decode(param)
+	if err != nil {
+		return eigentypes.OperatorId{}, err
+	}
+
+	if len(operatorIdBytes) != len(eigentypes.OperatorId{}) {
+		return eigentypes.OperatorId{}, InvalidOperatorIdLengthError
+	}
+
+	return eigentypes.OperatorId(operatorIdBytes), nil
+}
+
 func mapErrorToCode(err error) int {
 	status, ok := errorToCode[err]
 	if !ok {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/Nuffle-Labs/nffl/aggregator/mocks"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
 	"github.com/Nuffle-Labs/nffl/tests"
@@ -159,3 +160,117 @@ func TestGetCheckpointMessages(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, response, actual)
 }
+
+func TestGetEquivocationEvidence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	logger := sdklogging.NewNoopLogger()
+	aggregator := mocks.NewMockRestAggregatorer(mockCtrl)
+	restServer := NewRestServer("", aggregator, logger)
+
+	evidence := messages.EquivocationEvidence{
+		MessageType:   messages.StateRootUpdateMessageType,
+		MessageKey:    tests.Keccak256(1),
+		OperatorId:    tests.Keccak256(2),
+		FirstMessage:  []byte(`{"RollupId":1}`),
+		FirstDigest:   tests.Keccak256(3),
+		SecondMessage: []byte(`{"RollupId":2}`),
+		SecondDigest:  tests.Keccak256(4),
+	}
+	response := aggtypes.GetEquivocationEvidenceResponse{
+		Evidence: []messages.EquivocationEvidence{evidence},
+	}
+	aggregator.EXPECT().GetEquivocationEvidence(&evidence.OperatorId).Return(&response, nil)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("/equivocation/evidence?operatorId=0x%x", evidence.OperatorId), nil)
+	assert.Nil(t, err)
+
+	recorder := httptest.NewRecorder()
+	err = restServer.handleGetEquivocationEvidence(recorder, req)
+	assert.Nil(t, err)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+
+	var actual aggtypes.GetEquivocationEvidenceResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &actual)
+	assert.Nil(t, err)
+	assert.Equal(t, response, actual)
+
+	req, err = http.NewRequest("GET", "/equivocation/evidence?operatorId=0x01", nil)
+	assert.Nil(t, err)
+
+	recorder = httptest.NewRecorder()
+	err = restServer.handleGetEquivocationEvidence(recorder, req)
+	assert.ErrorIs(t, err, InvalidOperatorIdLengthError)
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+}
+
+func TestGetOperatorMissedMessages(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	logger := sdklogging.NewNoopLogger()
+	aggregator := mocks.NewMockRestAggregatorer(mockCtrl)
+	restServer := NewRestServer("", aggregator, logger)
+
+	operatorId := tests.Keccak256(1)
+	response := aggtypes.GetOperatorMissedMessagesResponse{
+		LivenessScore: 0.5,
+		MissedMessages: []messages.OperatorParticipation{
+			{
+				MessageKey:     tests.Keccak256(2),
+				MessageType:    messages.StateRootUpdateMessageType,
+				RollupId:       3,
+				OperatorId:     operatorId,
+				EthBlockNumber: 4,
+			},
+		},
+		NextCursor: 5,
+	}
+	aggregator.EXPECT().GetOperatorMissedMessages(eigentypes.OperatorId(operatorId), uint64(0), DEFAULT_MISSED_MESSAGES_LIMIT).Return(&response, nil)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("/operator/missed-messages?operatorId=0x%x", operatorId), nil)
+	assert.Nil(t, err)
+
+	recorder := httptest.NewRecorder()
+	err = restServer.handleGetOperatorMissedMessages(recorder, req)
+	assert.Nil(t, err)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+
+	var actual aggtypes.GetOperatorMissedMessagesResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &actual)
+	assert.Nil(t, err)
+	assert.Equal(t, response, actual)
+
+	aggregator.EXPECT().GetOperatorMissedMessages(eigentypes.OperatorId(operatorId), uint64(5), 10).Return(&aggtypes.GetOperatorMissedMessagesResponse{}, nil)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/operator/missed-messages?operatorId=0x%x&cursor=5&limit=10", operatorId), nil)
+	assert.Nil(t, err)
+
+	recorder = httptest.NewRecorder()
+	err = restServer.handleGetOperatorMissedMessages(recorder, req)
+	assert.Nil(t, err)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+
+	req, err = http.NewRequest("GET", "/operator/missed-messages", nil)
+	assert.Nil(t, err)
+
+	recorder = httptest.NewRecorder()
+	err = restServer.handleGetOperatorMissedMessages(recorder, req)
+	assert.NotNil(t, err)
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/operator/missed-messages?operatorId=0x%x&limit=%d", operatorId, MAX_MISSED_MESSAGES_LIMIT+1), nil)
+	assert.Nil(t, err)
+
+	recorder = httptest.NewRecorder()
+	err = restServer.handleGetOperatorMissedMessages(recorder, req)
+	assert.ErrorIs(t, err, InvalidLimitError)
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/operator/missed-messages?operatorId=0x%x&cursor=-1", operatorId), nil)
+	assert.Nil(t, err)
+
+	recorder = httptest.NewRecorder()
+	err = restServer.handleGetOperatorMissedMessages(recorder, req)
+	assert.ErrorIs(t, err, InvalidCursorError)
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+}
@@ -15,6 +15,7 @@ import (
 	registryrollup "github.com/Nuffle-Labs/nffl/contracts/bindings/SFFLRegistryRollup"
 	"github.com/Nuffle-Labs/nffl/core/chainio"
 	"github.com/Nuffle-Labs/nffl/core/config"
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
 )
 
@@ -23,14 +24,20 @@ const (
 	GET_OPERATOR_SET_RETRY_INTERVAL = time.Millisecond * 500
 )
 
+// Fetches an aggregated operator set update, so it can be replayed on rollups
+// which missed it
+type OperatorSetUpdateFetcher = func(ctx context.Context, id uint64) (messages.OperatorSetUpdateMessage, registryrollup.RollupOperatorsSignatureInfo, error)
+
 type RollupBroadcasterer interface {
 	BroadcastOperatorSetUpdate(ctx context.Context, message messages.OperatorSetUpdateMessage, signatureInfo registryrollup.RollupOperatorsSignatureInfo)
+	ResyncOperatorSets(ctx context.Context, fetchUpdate OperatorSetUpdateFetcher) error
 	GetErrorChan() <-chan error
 	Close()
 }
 
 type RollupBroadcaster struct {
 	writers   []*RollupWriter
+	avsReader chainio.AvsReaderer
 	logger    logging.Logger
 	errorChan chan error
 }
@@ -58,6 +65,7 @@ func NewRollupBroadcaster(
 
 	broadcaster := &RollupBroadcaster{
 		writers:   writers,
+		avsReader: avsReader,
 		logger:    logger,
 		errorChan: make(chan error),
 	}
@@ -84,7 +92,7 @@ func (b *RollupBroadcaster) initializeRollupOperatorSetsOnUpdate(ctx context.Con
 
 	b.logger.Info("Initializing rollup operator sets on update")
 
-	operatorSetUpdateSub, err := avsSubscriber.SubscribeToOperatorSetUpdates(operatorSetUpdatedChan)
+	operatorSetUpdateSub, err := avsSubscriber.SubscribeToOperatorSetUpdates(safeclient.WithCursorName(ctx, "rollup_broadcaster_operator_set_updates"), operatorSetUpdatedChan)
 	if err != nil {
 		b.logger.Fatal("Error subscribing to operator set updates", "err", err)
 	}
@@ -99,6 +107,11 @@ func (b *RollupBroadcaster) initializeRollupOperatorSetsOnUpdate(ctx context.Con
 			close(operatorSetUpdatedChan)
 			return
 		case event := <-operatorSetUpdatedChan:
+			if event.Raw.Removed {
+				b.logger.Warn("Operator set update was reorged out", "id", event.Id, "block", event.Raw.BlockNumber)
+				continue
+			}
+
 			b.logger.Info("Received operator set update", "id", event.Id)
 
 			operators, err := avsReader.GetOperatorSetById(ctx, event.Id)
@@ -107,13 +120,7 @@ func (b *RollupBroadcaster) initializeRollupOperatorSetsOnUpdate(ctx context.Con
 				continue
 			}
 
-			convertedOperators := make([]registryrollup.RollupOperatorsOperator, len(operators))
-			for i, op := range operators {
-				convertedOperators[i] = registryrollup.RollupOperatorsOperator{
-					Pubkey: registryrollup.BN254G1Point{X: op.Pubkey.X, Y: op.Pubkey.Y},
-					Weight: op.Weight,
-				}
-			}
+			convertedOperators := convertOperators(operators)
 
 			for _, writer := range b.writers {
 				go func(writer *RollupWriter) {
@@ -151,19 +158,71 @@ func (b *RollupBroadcaster) tryInitializeRollupOperatorSet(ctx context.Context,
 		return
 	}
 
-	convertedOperators := make([]registryrollup.RollupOperatorsOperator, len(operators))
-	for i, op := range operators {
-		convertedOperators[i] = registryrollup.RollupOperatorsOperator{
-			Pubkey: registryrollup.BN254G1Point{X: op.Pubkey.X, Y: op.Pubkey.Y},
-			Weight: op.Weight,
+	err = writer.InitializeOperatorSet(ctx, convertOperators(operators), mainnetNextOperatorSetUpdateId-1)
+	if err != nil {
+		b.logger.Error("Error initializing operator set", "err", err)
+		b.errorChan <- err
+	}
+}
+
+// Brings the rollups' operator sets up to date with the mainnet one, by
+// initializing them if needed or otherwise replaying the updates they missed
+func (b *RollupBroadcaster) ResyncOperatorSets(ctx context.Context, fetchUpdate OperatorSetUpdateFetcher) error {
+	mainnetNextOperatorSetUpdateId, err := b.avsReader.GetNextOperatorSetUpdateId(ctx)
+	if err != nil {
+		b.logger.Error("Error fetching operator set update id", "err", err)
+		return err
+	}
+
+	// The operator sets are initialized once the first update happens
+	if mainnetNextOperatorSetUpdateId == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, writer := range b.writers {
+		err := b.resyncRollupOperatorSet(ctx, writer, mainnetNextOperatorSetUpdateId, fetchUpdate)
+		if err != nil {
+			b.logger.Error("Error resyncing rollup operator set", "rollupId", writer.rollupId, "err", err)
+			errs = append(errs, fmt.Errorf("failed to resync operator set on writer %d: %w", writer.rollupId, err))
 		}
 	}
 
-	err = writer.InitializeOperatorSet(ctx, convertedOperators, mainnetNextOperatorSetUpdateId-1)
+	return errors.Join(errs...)
+}
+
+func (b *RollupBroadcaster) resyncRollupOperatorSet(ctx context.Context, writer *RollupWriter, mainnetNextOperatorSetUpdateId uint64, fetchUpdate OperatorSetUpdateFetcher) error {
+	nextOperatorUpdateId, err := writer.sfflRegistryRollup.NextOperatorUpdateId(&bind.CallOpts{Context: ctx})
 	if err != nil {
-		b.logger.Error("Error initializing operator set", "err", err)
-		b.errorChan <- err
+		return err
+	}
+
+	if nextOperatorUpdateId == 0 {
+		b.logger.Info("Initializing rollup operator set", "rollupId", writer.rollupId, "mainnetNextOperatorSetUpdateId", mainnetNextOperatorSetUpdateId)
+
+		operators, err := b.tryGetOperatorSetById(ctx, b.avsReader, mainnetNextOperatorSetUpdateId-1)
+		if err != nil {
+			return err
+		}
+
+		return writer.InitializeOperatorSet(ctx, convertOperators(operators), mainnetNextOperatorSetUpdateId-1)
+	}
+
+	b.logger.Info("Resyncing rollup operator set", "rollupId", writer.rollupId, "nextOperatorUpdateId", nextOperatorUpdateId, "mainnetNextOperatorSetUpdateId", mainnetNextOperatorSetUpdateId)
+
+	for id := nextOperatorUpdateId; id < mainnetNextOperatorSetUpdateId; id++ {
+		message, signatureInfo, err := fetchUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = writer.UpdateOperatorSet(ctx, message, signatureInfo)
+		if err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 func (b *RollupBroadcaster) BroadcastOperatorSetUpdate(ctx context.Context, message messages.OperatorSetUpdateMessage, signatureInfo registryrollup.RollupOperatorsSignatureInfo) {
@@ -218,3 +277,15 @@ func (b *RollupBroadcaster) tryGetOperatorSetById(ctx context.Context, avsReader
 
 	return nil, errors.New("failed to fetch operator set after retries")
 }
+
+func convertOperators(operators []opsetupdatereg.RollupOperatorsOperator) []registryrollup.RollupOperatorsOperator {
+	convertedOperators := make([]registryrollup.RollupOperatorsOperator, len(operators))
+	for i, op := range operators {
+		convertedOperators[i] = registryrollup.RollupOperatorsOperator{
+			Pubkey: registryrollup.BN254G1Point{X: op.Pubkey.X, Y: op.Pubkey.Y},
+			Weight: op.Weight,
+		}
+	}
+
+	return convertedOperators
+}
@@ -0,0 +1,142 @@
+package aggregator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	"github.com/Nuffle-Labs/nffl/core/config"
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
+)
+
+const (
+	ROLLUP_HEAD_POLL_INTERVAL = 5 * time.Second
+	// How long a rollup head can go without being polled before messages
+	// checked against it are rejected, rather than let through unchecked
+	ROLLUP_HEAD_MAX_STALENESS = 2 * time.Minute
+)
+
+type rollupHead struct {
+	blockNumber uint64
+	known       bool
+	updatedAt   time.Time
+}
+
+type rollupClientFactory func(rollupId uint32) (safeclient.SafeClient, error)
+
+// RollupHeadTracker polls the latest block of each configured rollup, so that
+// incoming messages can be checked against it without RPC calls. Rollup
+// clients are dialed lazily, so an unreachable rollup doesn't abort startup.
+type RollupHeadTracker struct {
+	newClient rollupClientFactory
+	heads     map[uint32]rollupHead
+	startedAt time.Time
+	clock     core.Clock
+	lock      sync.RWMutex
+	logger    logging.Logger
+}
+
+func NewRollupHeadTracker(ctx context.Context, rollupsInfo map[uint32]config.RollupInfo, logger logging.Logger) *RollupHeadTracker {
+	newClient := func(rollupId uint32) (safeclient.SafeClient, error) {
+		rollupInfo := rollupsInfo[rollupId]
+		return safeclient.NewRollupSafeEthClient(rollupId, rollupInfo.RpcUrl, rollupInfo.FailoverRpcUrls, logger)
+	}
+
+	rollupIds := make([]uint32, 0, len(rollupsInfo))
+	for rollupId := range rollupsInfo {
+		rollupIds = append(rollupIds, rollupId)
+	}
+
+	tracker := newRollupHeadTracker(rollupIds, newClient, core.SystemClock, logger)
+	for _, rollupId := range rollupIds {
+		go tracker.pollHead(ctx, rollupId)
+	}
+
+	return tracker
+}
+
+func newRollupHeadTracker(rollupIds []uint32, newClient rollupClientFactory, clock core.Clock, logger logging.Logger) *RollupHeadTracker {
+	heads := make(map[uint32]rollupHead, len(rollupIds))
+	for _, rollupId := range rollupIds {
+		heads[rollupId] = rollupHead{}
+	}
+
+	return &RollupHeadTracker{
+		newClient: newClient,
+		heads:     heads,
+		startedAt: clock.Now(),
+		clock:     clock,
+		logger:    logger,
+	}
+}
+
+// Returns the latest polled block of a rollup. Returns UnknownRollupError if
+// the rollup isn't configured, RollupHeadUnknownError if it wasn't polled yet
+// and RollupHeadStaleError if it wasn't polled for ROLLUP_HEAD_MAX_STALENESS
+func (t *RollupHeadTracker) GetRollupHead(rollupId uint32) (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	head, ok := t.heads[rollupId]
+	if !ok {
+		return 0, UnknownRollupError
+	}
+
+	lastUpdate := head.updatedAt
+	if !head.known {
+		lastUpdate = t.startedAt
+	}
+	if t.clock.Now().Sub(lastUpdate) > ROLLUP_HEAD_MAX_STALENESS {
+		return 0, RollupHeadStaleError
+	}
+	if !head.known {
+		return 0, RollupHeadUnknownError
+	}
+
+	return head.blockNumber, nil
+}
+
+func (t *RollupHeadTracker) pollHead(ctx context.Context, rollupId uint32) {
+	ticker := time.NewTicker(ROLLUP_HEAD_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	var client safeclient.SafeClient
+	for {
+		if client == nil {
+			var err error
+			client, err = t.newClient(rollupId)
+			if err != nil {
+				t.logger.Warn("Couldn't create rollup client, retrying", "rollupId", rollupId, "err", err)
+				client = nil
+			}
+		}
+
+		if client != nil {
+			t.updateHead(ctx, rollupId, client)
+		}
+
+		select {
+		case <-ctx.Done():
+			if client != nil {
+				client.Close()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *RollupHeadTracker) updateHead(ctx context.Context, rollupId uint32, client safeclient.SafeClient) {
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		t.logger.Warn("Failed to fetch rollup head", "rollupId", rollupId, "err", err)
+		return
+	}
+
+	t.lock.Lock()
+	t.heads[rollupId] = rollupHead{blockNumber: blockNumber, known: true, updatedAt: t.clock.Now()}
+	t.lock.Unlock()
+}
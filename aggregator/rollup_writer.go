@@ -44,7 +44,7 @@ func NewRollupWriter(
 	address common.Address,
 	logger logging.Logger,
 ) (*RollupWriter, error) {
-	client, err := safeclient.NewSafeEthClient(rollupInfo.RpcUrl, logger)
+	client, err := safeclient.NewRollupSafeEthClient(rollupId, rollupInfo.RpcUrl, rollupInfo.FailoverRpcUrls, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,7 @@ type EventListener interface {
 	IncTotalSignedStateRootUpdateMessage()
 	IncTotalSignedOperatorSetUpdateMessage()
 	ObserveLastMessageReceivedTime(operatorId [32]byte, messageType string)
+	IncRejectedRequests(messageType, reason string)
 }
 
 type SelectiveRpcListener struct {
@@ -32,6 +33,7 @@ type SelectiveRpcListener struct {
 	IncTotalSignedStateRootUpdateMessageCb   func()
 	IncTotalSignedOperatorSetUpdateMessageCb func()
 	ObserveLastMessageReceivedTimeCb         func(operatorId [32]byte, messageType string)
+	IncRejectedRequestsCb                    func(messageType, reason string)
 }
 
 func (l *SelectiveRpcListener) IncOperatorInitializations(operatorId [32]byte) {
@@ -82,6 +84,12 @@ func (l *SelectiveRpcListener) IncTotalSignedOperatorSetUpdateMessage() {
 	}
 }
 
+func (l *SelectiveRpcListener) IncRejectedRequests(messageType, reason string) {
+	if l.IncRejectedRequestsCb != nil {
+		l.IncRejectedRequestsCb(messageType, reason)
+	}
+}
+
 func MakeRpcServerMetrics(registry *prometheus.Registry) (EventListener, error) {
 	operatorInitializationsTotal := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -143,6 +151,18 @@ func MakeRpcServerMetrics(registry *prometheus.Registry) (EventListener, error)
 		return nil, fmt.Errorf("error registering lastMessageReceivedTime gauge: %w", err)
 	}
 
+	rejectedRequestsTotal := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: aggregator.AggregatorNamespace,
+			Name:      "rejected_requests_total",
+			Help:      "Total number of signed messages rejected at ingress per message type and reason",
+		},
+		[]string{"message_type", "reason"},
+	)
+	if err := registry.Register(rejectedRequestsTotal); err != nil {
+		return nil, fmt.Errorf("error registering rejectedRequestsTotal counter: %w", err)
+	}
+
 	return &SelectiveRpcListener{
 		IncOperatorInitializationsCb: func(operatorId [32]byte) {
 			operatorInitializationsTotal.WithLabelValues(fmt.Sprintf("%x", operatorId)).Inc()
@@ -159,5 +179,8 @@ func MakeRpcServerMetrics(registry *prometheus.Registry) (EventListener, error)
 		ObserveLastMessageReceivedTimeCb: func(operatorId [32]byte, messageType string) {
 			lastMessageReceivedTime.WithLabelValues(fmt.Sprintf("%x", operatorId), messageType).SetToCurrentTime()
 		},
+		IncRejectedRequestsCb: func(messageType, reason string) {
+			rejectedRequestsTotal.WithLabelValues(messageType, reason).Inc()
+		},
 	}, nil
 }
@@ -1,10 +1,12 @@
 package rpc_server
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/rpc"
 	"strings"
+	"time"
 
 	"github.com/Nuffle-Labs/nffl/aggregator/blsagg"
 	"github.com/Nuffle-Labs/nffl/core"
@@ -28,6 +30,11 @@ var (
 	InvalidSignatureError400                 = errors.New("400. Invalid signature")
 	CallToGetCheckSignaturesIndicesFailed500 = errors.New("500. Failed to get check signatures indices")
 	MessageExpiredError500                   = errors.New("500. Message expired")
+	AggregatorOverloadedError503             = errors.New("503. Aggregator overloaded, try again later")
+	RateLimitedError429                      = errors.New("429. Too many requests")
+	UnknownRollupError400                    = errors.New("400. Unknown rollup")
+	RollupHeadUnavailableError503            = errors.New("503. Rollup head unavailable, try again later")
+	BlockHeightOutOfRangeError400            = errors.New("400. Block height out of range")
 	UnknownError400                          = errors.New("400. Unknown error")
 
 	errorsMap = map[error]error{
@@ -37,9 +44,27 @@ var (
 		aggregator.InvalidSignatureError:          InvalidSignatureError400,
 		aggregator.OperatorNotFoundError:          OperatorNotFoundError400,
 		blsagg.MessageExpiredError:                MessageExpiredError500,
+		blsagg.AggregatorOverloadedError:          AggregatorOverloadedError503,
+		aggregator.UnknownRollupError:             UnknownRollupError400,
+		aggregator.RateLimitedError:               RateLimitedError429,
 	}
 )
 
+const (
+	// Window around the latest polled rollup block in which state root
+	// updates are accepted
+	MAX_STATE_ROOT_UPDATE_BLOCKS_AHEAD  = 100
+	MAX_STATE_ROOT_UPDATE_BLOCKS_BEHIND = 2000
+
+	// How long a signed message request waits for verification and aggregation
+	PROCESS_MESSAGE_TIMEOUT = 30 * time.Second
+
+	RateLimitedReason           = "rate_limited"
+	UnknownRollupReason         = "unknown_rollup"
+	RollupHeadUnavailableReason = "rollup_head_unavailable"
+	BlockHeightOutOfRangeReason = "block_height_out_of_range"
+)
+
 type RpcServer struct {
 	serverIpPortAddr string
 	app              aggregator.RpcAggregatorer
@@ -94,6 +119,42 @@ func mapErrors(err error) error {
 	return mappedErr
 }
 
+// Records requests the aggregator rejected because the operator exceeded its
+// rate limit, which is only charged once the operator's signature is verified
+func (s *RpcServer) recordRateLimited(err error, messageType string) {
+	if errors.Is(err, aggregator.RateLimitedError) {
+		s.listener.IncRejectedRequests(messageType, RateLimitedReason)
+	}
+}
+
+func (s *RpcServer) checkStateRootUpdateBlockHeight(message *messages.StateRootUpdateMessage) error {
+	head, err := s.app.GetRollupHead(message.RollupId)
+	if err != nil {
+		if errors.Is(err, aggregator.UnknownRollupError) {
+			s.listener.IncRejectedRequests(StateRootUpdateMessageLabel, UnknownRollupReason)
+			return UnknownRollupError400
+		}
+		if errors.Is(err, aggregator.RollupHeadStaleError) {
+			s.logger.Warn("Rejecting state root update, rollup head unavailable", "rollupId", message.RollupId)
+			s.listener.IncRejectedRequests(StateRootUpdateMessageLabel, RollupHeadUnavailableReason)
+			return RollupHeadUnavailableError503
+		}
+
+		// The rollup head wasn't polled yet, so there's nothing to check
+		// against until ROLLUP_HEAD_MAX_STALENESS passes
+		return nil
+	}
+
+	if message.BlockHeight > head+MAX_STATE_ROOT_UPDATE_BLOCKS_AHEAD ||
+		(head > MAX_STATE_ROOT_UPDATE_BLOCKS_BEHIND && message.BlockHeight < head-MAX_STATE_ROOT_UPDATE_BLOCKS_BEHIND) {
+		s.logger.Debug("State root update block height out of range", "rollupId", message.RollupId, "blockHeight", message.BlockHeight, "head", head)
+		s.listener.IncRejectedRequests(StateRootUpdateMessageLabel, BlockHeightOutOfRangeReason)
+		return BlockHeightOutOfRangeError400
+	}
+
+	return nil
+}
+
 // rpc endpoint which is called by operator
 // reply doesn't need to be checked. If there are no errors, the task response is accepted
 // rpc framework forces a reply type to exist, so we put bool as a placeholder
@@ -108,13 +169,20 @@ func (s *RpcServer) ProcessSignedCheckpointTaskResponse(signedCheckpointTaskResp
 	s.listener.IncTotalSignedCheckpointTaskResponse()
 	s.listener.ObserveLastMessageReceivedTime(signedCheckpointTaskResponse.OperatorId, CheckpointTaskResponseLabel)
 
-	err = s.app.ProcessSignedCheckpointTaskResponse(signedCheckpointTaskResponse)
+	ctx, cancel := context.WithTimeout(context.Background(), PROCESS_MESSAGE_TIMEOUT)
+	defer cancel()
+
+	err = s.app.ProcessSignedCheckpointTaskResponse(ctx, signedCheckpointTaskResponse)
+	s.recordRateLimited(err, CheckpointTaskResponseLabel)
 	if err != nil {
 		s.listener.IncSignedCheckpointTaskResponse(
 			signedCheckpointTaskResponse.OperatorId,
 			true,
 			strings.Contains(err.Error(), "not initialized"),
 		)
+		if errors.Is(err, aggregator.RateLimitedError) {
+			return RateLimitedError429
+		}
 		return err
 	}
 
@@ -138,7 +206,16 @@ func (s *RpcServer) ProcessSignedStateRootUpdateMessage(signedStateRootUpdateMes
 	operatorId := signedStateRootUpdateMessage.OperatorId
 	rollupId := signedStateRootUpdateMessage.Message.RollupId
 
-	err = s.app.ProcessSignedStateRootUpdateMessage(signedStateRootUpdateMessage)
+	err = s.checkStateRootUpdateBlockHeight(&signedStateRootUpdateMessage.Message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), PROCESS_MESSAGE_TIMEOUT)
+	defer cancel()
+
+	err = s.app.ProcessSignedStateRootUpdateMessage(ctx, signedStateRootUpdateMessage)
+	s.recordRateLimited(err, StateRootUpdateMessageLabel)
 	s.listener.IncSignedStateRootUpdateMessage(operatorId, rollupId, err != nil, hasNearDaCommitment)
 	if err != nil {
 		return mapErrors(err)
@@ -159,7 +236,11 @@ func (s *RpcServer) ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUpdat
 	s.listener.ObserveLastMessageReceivedTime(operatorId, OperatorSetUpdateMessageLabel)
 	s.listener.IncTotalSignedOperatorSetUpdateMessage()
 
-	err = s.app.ProcessSignedOperatorSetUpdateMessage(signedOperatorSetUpdateMessage)
+	ctx, cancel := context.WithTimeout(context.Background(), PROCESS_MESSAGE_TIMEOUT)
+	defer cancel()
+
+	err = s.app.ProcessSignedOperatorSetUpdateMessage(ctx, signedOperatorSetUpdateMessage)
+	s.recordRateLimited(err, OperatorSetUpdateMessageLabel)
 	s.listener.IncSignedOperatorSetUpdateMessage(operatorId, err != nil)
 	if err != nil {
 		return mapErrors(err)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Nuffle-Labs/nffl/aggregator"
 	"github.com/Nuffle-Labs/nffl/aggregator/mocks"
 	"github.com/Nuffle-Labs/nffl/core/types"
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
@@ -140,3 +141,112 @@ func TestGetAggregatedCheckpointMessages_InvalidParams(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestProcessSignedStateRootUpdateMessage_BlockHeightWindow(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	agg := mocks.NewMockRpcAggregatorer(mockCtrl)
+	logger, _ := logging.NewZapLogger(logging.Development)
+
+	rpc := NewRpcServer("localhost:8080", agg, logger)
+
+	var rejected []string
+	rpc.listener = &SelectiveRpcListener{
+		IncRejectedRequestsCb: func(messageType, reason string) { rejected = append(rejected, reason) },
+	}
+
+	head := uint64(10_000)
+	agg.EXPECT().GetRollupHead(uint32(1)).Return(head, nil).AnyTimes()
+	agg.EXPECT().GetRollupHead(uint32(2)).Return(uint64(0), aggregator.UnknownRollupError).AnyTimes()
+	agg.EXPECT().GetRollupHead(uint32(3)).Return(uint64(0), aggregator.RollupHeadUnknownError).AnyTimes()
+	agg.EXPECT().GetRollupHead(uint32(4)).Return(uint64(0), aggregator.RollupHeadStaleError).AnyTimes()
+
+	newMessage := func(rollupId uint32, blockHeight uint64) *messages.SignedStateRootUpdateMessage {
+		return &messages.SignedStateRootUpdateMessage{
+			Message:      messages.StateRootUpdateMessage{RollupId: rollupId, BlockHeight: blockHeight},
+			BlsSignature: *bls.NewZeroSignature(),
+		}
+	}
+
+	var ignore bool
+	t.Run("unknown rollup", func(t *testing.T) {
+		err := rpc.ProcessSignedStateRootUpdateMessage(newMessage(2, head), &ignore)
+
+		assert.Equal(t, UnknownRollupError400, err)
+	})
+
+	t.Run("too far ahead", func(t *testing.T) {
+		err := rpc.ProcessSignedStateRootUpdateMessage(newMessage(1, head+MAX_STATE_ROOT_UPDATE_BLOCKS_AHEAD+1), &ignore)
+
+		assert.Equal(t, BlockHeightOutOfRangeError400, err)
+	})
+
+	t.Run("too far behind", func(t *testing.T) {
+		err := rpc.ProcessSignedStateRootUpdateMessage(newMessage(1, head-MAX_STATE_ROOT_UPDATE_BLOCKS_BEHIND-1), &ignore)
+
+		assert.Equal(t, BlockHeightOutOfRangeError400, err)
+	})
+
+	t.Run("within window", func(t *testing.T) {
+		message := newMessage(1, head+MAX_STATE_ROOT_UPDATE_BLOCKS_AHEAD)
+		agg.EXPECT().ProcessSignedStateRootUpdateMessage(gomock.Any(), message).Return(nil)
+
+		err := rpc.ProcessSignedStateRootUpdateMessage(message, &ignore)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("head not known yet", func(t *testing.T) {
+		message := newMessage(3, 1_000_000)
+		agg.EXPECT().ProcessSignedStateRootUpdateMessage(gomock.Any(), message).Return(nil)
+
+		err := rpc.ProcessSignedStateRootUpdateMessage(message, &ignore)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("head unavailable", func(t *testing.T) {
+		err := rpc.ProcessSignedStateRootUpdateMessage(newMessage(4, 1_000_000), &ignore)
+
+		assert.Equal(t, RollupHeadUnavailableError503, err)
+	})
+
+	assert.Equal(t, []string{UnknownRollupReason, BlockHeightOutOfRangeReason, BlockHeightOutOfRangeReason, RollupHeadUnavailableReason}, rejected)
+}
+
+func TestProcessSignedOperatorSetUpdateMessage_RateLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	agg := mocks.NewMockRpcAggregatorer(mockCtrl)
+	logger, _ := logging.NewZapLogger(logging.Development)
+
+	rpc := NewRpcServer("localhost:8080", agg, logger)
+
+	var rejected int
+	rpc.listener = &SelectiveRpcListener{
+		IncRejectedRequestsCb: func(messageType, reason string) {
+			assert.Equal(t, OperatorSetUpdateMessageLabel, messageType)
+			assert.Equal(t, RateLimitedReason, reason)
+			rejected++
+		},
+	}
+
+	message := &messages.SignedOperatorSetUpdateMessage{
+		BlsSignature: *bls.NewZeroSignature(),
+		OperatorId:   [32]byte{1},
+	}
+
+	var ignore bool
+
+	agg.EXPECT().ProcessSignedOperatorSetUpdateMessage(gomock.Any(), message).Return(aggregator.InvalidSignatureError)
+	err := rpc.ProcessSignedOperatorSetUpdateMessage(message, &ignore)
+	assert.Equal(t, InvalidSignatureError400, err)
+	assert.Equal(t, 0, rejected)
+
+	agg.EXPECT().ProcessSignedOperatorSetUpdateMessage(gomock.Any(), message).Return(aggregator.RateLimitedError)
+	err = rpc.ProcessSignedOperatorSetUpdateMessage(message, &ignore)
+	assert.Equal(t, RateLimitedError429, err)
+	assert.Equal(t, 1, rejected)
+}
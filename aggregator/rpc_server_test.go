@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/binary"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/time/rate"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
@@ -52,7 +55,7 @@ func TestProcessSignedCheckpointTaskResponse(t *testing.T) {
 	)
 	mockOperatorRegistrationsServ.EXPECT().GetOperatorInfoById(ctx, signedCheckpointTaskResponse.OperatorId).Return(eigentypes.OperatorInfo{Pubkeys: MOCK_OPERATOR_PUBKEYS}, true)
 
-	err = aggregator.ProcessSignedCheckpointTaskResponse(signedCheckpointTaskResponse)
+	err = aggregator.ProcessSignedCheckpointTaskResponse(context.Background(), signedCheckpointTaskResponse)
 	assert.Nil(t, err)
 }
 
@@ -80,7 +83,7 @@ func TestProcessSignedStateRootUpdateMessage(t *testing.T) {
 	mockMessageBlsAggServ.EXPECT().ProcessNewSignature(context.Background(), message, &signedMessage.BlsSignature, signedMessage.OperatorId)
 	mockOperatorRegistrationsServ.EXPECT().GetOperatorInfoById(context.Background(), signedMessage.OperatorId).Return(eigentypes.OperatorInfo{Pubkeys: MOCK_OPERATOR_PUBKEYS}, true)
 
-	err = aggregator.ProcessSignedStateRootUpdateMessage(signedMessage)
+	err = aggregator.ProcessSignedStateRootUpdateMessage(context.Background(), signedMessage)
 	assert.Nil(t, err)
 }
 
@@ -106,10 +109,61 @@ func TestProcessInvalidSignedStateRootUpdateMessage(t *testing.T) {
 	signedMessage.BlsSignature = *newInvalidSignature()
 
 	mockOperatorRegistrationsServ.EXPECT().GetOperatorInfoById(context.Background(), signedMessage.OperatorId).Return(eigentypes.OperatorInfo{Pubkeys: MOCK_OPERATOR_PUBKEYS}, true)
-	err = aggregator.ProcessSignedStateRootUpdateMessage(signedMessage)
+	err = aggregator.ProcessSignedStateRootUpdateMessage(context.Background(), signedMessage)
 	assert.Equal(t, err.Error(), "Invalid signature")
 }
 
+func TestProcessSignedStateRootUpdateMessageRateLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aggregator, _, _, _, mockMessageBlsAggServ, _, mockOperatorRegistrationsServ, _, _, _, err := createMockAggregator(mockCtrl, MOCK_OPERATOR_PUBKEY_DICT)
+	assert.Nil(t, err)
+
+	aggregator.clock = core.Clock{Now: func() time.Time { return time.Unix(10_000, 0) }}
+	aggregator.rateLimiter = newOperatorRateLimiter(rate.Every(time.Hour), 2)
+	message := messages.StateRootUpdateMessage{
+		RollupId:    1,
+		BlockHeight: 2,
+		Timestamp:   9_995,
+	}
+
+	signedMessage, err := createMockSignedStateRootUpdateMessage(message, *MOCK_OPERATOR_KEYPAIR)
+	assert.Nil(t, err)
+
+	mockOperatorRegistrationsServ.EXPECT().GetOperatorInfoById(gomock.Any(), signedMessage.OperatorId).Return(eigentypes.OperatorInfo{Pubkeys: MOCK_OPERATOR_PUBKEYS}, true).AnyTimes()
+
+	// Messages failing signature verification aren't charged
+	invalidMessage := *signedMessage
+	invalidMessage.BlsSignature = *newInvalidSignature()
+	for i := 0; i < 3; i++ {
+		err = aggregator.ProcessSignedStateRootUpdateMessage(context.Background(), &invalidMessage)
+		assert.Equal(t, InvalidSignatureError, err)
+	}
+
+	// Concurrent requests can't take more tokens than the bucket holds
+	mockMessageBlsAggServ.EXPECT().InitializeMessageIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	mockMessageBlsAggServ.EXPECT().ProcessNewSignature(gomock.Any(), message, &signedMessage.BlsSignature, signedMessage.OperatorId).Times(2)
+
+	var wg sync.WaitGroup
+	var rateLimited atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := aggregator.ProcessSignedStateRootUpdateMessage(context.Background(), signedMessage)
+			if err == RateLimitedError {
+				rateLimited.Add(1)
+			} else {
+				assert.Nil(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(8), rateLimited.Load())
+}
+
 func TestProcessOperatorSetUpdateMessage(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -136,7 +190,7 @@ func TestProcessOperatorSetUpdateMessage(t *testing.T) {
 	mockMessageBlsAggServ.EXPECT().ProcessNewSignature(ctx, message, &signedMessage.BlsSignature, signedMessage.OperatorId)
 	mockOperatorRegistrationsServ.EXPECT().GetOperatorInfoById(context.Background(), signedMessage.OperatorId).Return(eigentypes.OperatorInfo{Pubkeys: MOCK_OPERATOR_PUBKEYS}, true)
 
-	err = aggregator.ProcessSignedOperatorSetUpdateMessage(signedMessage)
+	err = aggregator.ProcessSignedOperatorSetUpdateMessage(context.Background(), signedMessage)
 	assert.Nil(t, err)
 }
 
@@ -19,6 +19,7 @@ const QUERY_FILTER_FROM_BLOCK = uint64(1)
 const MESSAGE_TTL = 1 * time.Minute
 const MESSAGE_BLS_AGGREGATION_TIMEOUT = 30 * time.Second
 const MESSAGE_SUBMISSION_TIMEOUT = 1 * time.Minute
+const MESSAGE_LATE_SIGNATURE_GRACE_PERIOD = 1 * time.Minute
 
 type OperatorInfo struct {
 	OperatorPubkeys eigentypes.OperatorPubkeys
@@ -35,6 +36,28 @@ type GetOperatorSetUpdateAggregationResponse struct {
 	Aggregation messages.MessageBlsAggregation
 }
 
+type GetOperatorMissedMessagesResponse struct {
+	// Fraction of the operator's latest messages it signed
+	LivenessScore  float64
+	MissedMessages []messages.OperatorParticipation
+	// Cursor of the next page of missed messages, 0 on the last one
+	NextCursor uint64
+}
+
 type GetCheckpointMessagesResponse struct {
 	CheckpointMessages messages.CheckpointMessages
 }
+
+type GetEquivocationEvidenceResponse struct {
+	Evidence []messages.EquivocationEvidence
+}
+
+// Action taken through the admin API
+type AuditLogEntry struct {
+	Timestamp  time.Time
+	Action     string
+	Params     string
+	RemoteAddr string
+	// Set if the action failed
+	Error string
+}
@@ -3,6 +3,7 @@ package chainio
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -22,6 +23,10 @@ import (
 	"github.com/Nuffle-Labs/nffl/core/types/messages"
 )
 
+var (
+	CheckpointTaskNotFoundError = errors.New("Checkpoint task creation event not found")
+)
+
 type AvsReaderer interface {
 	sdkavsregistry.AvsRegistryReader
 
@@ -34,6 +39,11 @@ type AvsReaderer interface {
 	GetOperatorSetUpdateBlock(ctx context.Context, id uint64) (uint32, error)
 	GetNextOperatorSetUpdateId(ctx context.Context) (uint64, error)
 	GetLastCheckpointToTimestamp(ctx context.Context) (uint64, error)
+	GetNextCheckpointTaskNum(ctx context.Context) (uint32, error)
+	GetCheckpointTask(ctx context.Context, taskIndex uint32, fromBlock uint64) (taskmanager.CheckpointTask, error)
+	GetCheckpointTaskHash(ctx context.Context, taskIndex uint32) ([32]byte, error)
+	GetCheckpointTaskResponseHash(ctx context.Context, taskIndex uint32) ([32]byte, error)
+	GetCheckpointTaskResponseWindowBlock(ctx context.Context) (uint32, error)
 }
 
 type AvsReader struct {
@@ -174,3 +184,40 @@ func (r *AvsReader) GetLastCheckpointToTimestamp(ctx context.Context) (uint64, e
 	}
 	return lastCheckpointToTimestamp, nil
 }
+
+func (r *AvsReader) GetNextCheckpointTaskNum(ctx context.Context) (uint32, error) {
+	return r.AvsServiceBindings.TaskManager.NextCheckpointTaskNum(&bind.CallOpts{Context: ctx})
+}
+
+// Returns a task from its creation event, which is looked up from fromBlock
+func (r *AvsReader) GetCheckpointTask(ctx context.Context, taskIndex uint32, fromBlock uint64) (taskmanager.CheckpointTask, error) {
+	iterator, err := r.AvsServiceBindings.TaskManager.FilterCheckpointTaskCreated(&bind.FilterOpts{Start: fromBlock, Context: ctx}, []uint32{taskIndex})
+	if err != nil {
+		return taskmanager.CheckpointTask{}, err
+	}
+	defer iterator.Close()
+
+	if !iterator.Next() {
+		if iterator.Error() != nil {
+			return taskmanager.CheckpointTask{}, iterator.Error()
+		}
+
+		return taskmanager.CheckpointTask{}, CheckpointTaskNotFoundError
+	}
+
+	return iterator.Event.Task, nil
+}
+
+func (r *AvsReader) GetCheckpointTaskHash(ctx context.Context, taskIndex uint32) ([32]byte, error) {
+	return r.AvsServiceBindings.TaskManager.AllCheckpointTaskHashes(&bind.CallOpts{Context: ctx}, taskIndex)
+}
+
+// Returns the hash of the task's response and metadata, or zero if it wasn't
+// responded yet
+func (r *AvsReader) GetCheckpointTaskResponseHash(ctx context.Context, taskIndex uint32) ([32]byte, error) {
+	return r.AvsServiceBindings.TaskManager.AllCheckpointTaskResponses(&bind.CallOpts{Context: ctx}, taskIndex)
+}
+
+func (r *AvsReader) GetCheckpointTaskResponseWindowBlock(ctx context.Context) (uint32, error) {
+	return r.AvsServiceBindings.TaskManager.TASKRESPONSEWINDOWBLOCK(&bind.CallOpts{Context: ctx})
+}
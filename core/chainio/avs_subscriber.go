@@ -1,6 +1,8 @@
 package chainio
 
 import (
+	"context"
+
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
@@ -17,7 +19,7 @@ type AvsSubscriberer interface {
 	avsregistry.AvsRegistrySubscriber
 	SubscribeToNewTasks(checkpointTaskCreatedChan chan *taskmanager.ContractSFFLTaskManagerCheckpointTaskCreated) (event.Subscription, error)
 	SubscribeToTaskResponses(taskResponseLogs chan *taskmanager.ContractSFFLTaskManagerCheckpointTaskResponded) (event.Subscription, error)
-	SubscribeToOperatorSetUpdates(operatorSetUpdateChan chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error)
+	SubscribeToOperatorSetUpdates(ctx context.Context, operatorSetUpdateChan chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error)
 	ParseCheckpointTaskResponded(rawLog types.Log) (*taskmanager.ContractSFFLTaskManagerCheckpointTaskResponded, error)
 }
 
@@ -85,9 +87,9 @@ func (s *AvsSubscriber) ParseCheckpointTaskResponded(rawLog types.Log) (*taskman
 	return s.AvsContractBindings.TaskManager.ContractSFFLTaskManagerFilterer.ParseCheckpointTaskResponded(rawLog)
 }
 
-func (s *AvsSubscriber) SubscribeToOperatorSetUpdates(operatorSetUpdateChan chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error) {
+func (s *AvsSubscriber) SubscribeToOperatorSetUpdates(ctx context.Context, operatorSetUpdateChan chan *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error) {
 	sub, err := s.AvsContractBindings.OperatorSetUpdateRegistry.WatchOperatorSetUpdatedAtBlock(
-		&bind.WatchOpts{}, operatorSetUpdateChan, nil, nil,
+		&bind.WatchOpts{Context: ctx}, operatorSetUpdateChan, nil, nil,
 	)
 	if err != nil {
 		s.logger.Error("Failed to subscribe to OperatorSetUpdatedAtBlock events", "err", err)
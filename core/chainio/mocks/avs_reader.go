@@ -5,6 +5,7 @@
 //
 //	mockgen -destination=./mocks/avs_reader.go -package=mocks github.com/Nuffle-Labs/nffl/core/chainio AvsReaderer
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
@@ -77,6 +78,66 @@ func (mr *MockAvsReadererMockRecorder) GetCheckSignaturesIndices(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckSignaturesIndices", reflect.TypeOf((*MockAvsReaderer)(nil).GetCheckSignaturesIndices), arg0, arg1, arg2, arg3)
 }
 
+// GetCheckpointTask mocks base method.
+func (m *MockAvsReaderer) GetCheckpointTask(arg0 context.Context, arg1 uint32, arg2 uint64) (contractSFFLTaskManager.CheckpointTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpointTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(contractSFFLTaskManager.CheckpointTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpointTask indicates an expected call of GetCheckpointTask.
+func (mr *MockAvsReadererMockRecorder) GetCheckpointTask(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpointTask", reflect.TypeOf((*MockAvsReaderer)(nil).GetCheckpointTask), arg0, arg1, arg2)
+}
+
+// GetCheckpointTaskHash mocks base method.
+func (m *MockAvsReaderer) GetCheckpointTaskHash(arg0 context.Context, arg1 uint32) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpointTaskHash", arg0, arg1)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpointTaskHash indicates an expected call of GetCheckpointTaskHash.
+func (mr *MockAvsReadererMockRecorder) GetCheckpointTaskHash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpointTaskHash", reflect.TypeOf((*MockAvsReaderer)(nil).GetCheckpointTaskHash), arg0, arg1)
+}
+
+// GetCheckpointTaskResponseHash mocks base method.
+func (m *MockAvsReaderer) GetCheckpointTaskResponseHash(arg0 context.Context, arg1 uint32) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpointTaskResponseHash", arg0, arg1)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpointTaskResponseHash indicates an expected call of GetCheckpointTaskResponseHash.
+func (mr *MockAvsReadererMockRecorder) GetCheckpointTaskResponseHash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpointTaskResponseHash", reflect.TypeOf((*MockAvsReaderer)(nil).GetCheckpointTaskResponseHash), arg0, arg1)
+}
+
+// GetCheckpointTaskResponseWindowBlock mocks base method.
+func (m *MockAvsReaderer) GetCheckpointTaskResponseWindowBlock(arg0 context.Context) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpointTaskResponseWindowBlock", arg0)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpointTaskResponseWindowBlock indicates an expected call of GetCheckpointTaskResponseWindowBlock.
+func (mr *MockAvsReadererMockRecorder) GetCheckpointTaskResponseWindowBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpointTaskResponseWindowBlock", reflect.TypeOf((*MockAvsReaderer)(nil).GetCheckpointTaskResponseWindowBlock), arg0)
+}
+
 // GetErc20Mock mocks base method.
 func (m *MockAvsReaderer) GetErc20Mock(arg0 context.Context, arg1 common.Address) (*contractERC20Mock.ContractERC20Mock, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +168,21 @@ func (mr *MockAvsReadererMockRecorder) GetLastCheckpointToTimestamp(arg0 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCheckpointToTimestamp", reflect.TypeOf((*MockAvsReaderer)(nil).GetLastCheckpointToTimestamp), arg0)
 }
 
+// GetNextCheckpointTaskNum mocks base method.
+func (m *MockAvsReaderer) GetNextCheckpointTaskNum(arg0 context.Context) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextCheckpointTaskNum", arg0)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextCheckpointTaskNum indicates an expected call of GetNextCheckpointTaskNum.
+func (mr *MockAvsReadererMockRecorder) GetNextCheckpointTaskNum(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextCheckpointTaskNum", reflect.TypeOf((*MockAvsReaderer)(nil).GetNextCheckpointTaskNum), arg0)
+}
+
 // GetNextOperatorSetUpdateId mocks base method.
 func (m *MockAvsReaderer) GetNextOperatorSetUpdateId(arg0 context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -5,10 +5,12 @@
 //
 //	mockgen -destination=./mocks/avs_subscriber.go -package=mocks github.com/Nuffle-Labs/nffl/core/chainio AvsSubscriberer
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	contractBLSApkRegistry "github.com/Layr-Labs/eigensdk-go/contracts/bindings/BLSApkRegistry"
@@ -90,18 +92,18 @@ func (mr *MockAvsSubscribererMockRecorder) SubscribeToNewTasks(arg0 any) *gomock
 }
 
 // SubscribeToOperatorSetUpdates mocks base method.
-func (m *MockAvsSubscriberer) SubscribeToOperatorSetUpdates(arg0 chan *contractSFFLOperatorSetUpdateRegistry.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error) {
+func (m *MockAvsSubscriberer) SubscribeToOperatorSetUpdates(arg0 context.Context, arg1 chan *contractSFFLOperatorSetUpdateRegistry.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) (event.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubscribeToOperatorSetUpdates", arg0)
+	ret := m.ctrl.Call(m, "SubscribeToOperatorSetUpdates", arg0, arg1)
 	ret0, _ := ret[0].(event.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubscribeToOperatorSetUpdates indicates an expected call of SubscribeToOperatorSetUpdates.
-func (mr *MockAvsSubscribererMockRecorder) SubscribeToOperatorSetUpdates(arg0 any) *gomock.Call {
+func (mr *MockAvsSubscribererMockRecorder) SubscribeToOperatorSetUpdates(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToOperatorSetUpdates", reflect.TypeOf((*MockAvsSubscriberer)(nil).SubscribeToOperatorSetUpdates), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToOperatorSetUpdates", reflect.TypeOf((*MockAvsSubscriberer)(nil).SubscribeToOperatorSetUpdates), arg0, arg1)
 }
 
 // SubscribeToOperatorSocketUpdates mocks base method.
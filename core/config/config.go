@@ -4,11 +4,13 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
@@ -23,17 +25,36 @@ type Config struct {
 	BlsPrivateKey   *bls.PrivateKey   `json:"-"`
 	// we need the url for the eigensdk currently... eventually standardize api so as to
 	// only take an ethclient or an rpcUrl (and build the ethclient at each constructor site)
-	EthHttpRpcUrl                  string                `json:"ethHttpRpcUrl"`
-	EthWsRpcUrl                    string                `json:"ethWsRpcUrl"`
-	RollupsInfo                    map[uint32]RollupInfo `json:"rollupsInfo"`
-	OperatorStateRetrieverAddr     common.Address        `json:"operatorStateRetrieverAddr"`
-	SFFLRegistryCoordinatorAddr    common.Address        `json:"sfflRegistryCoordinatorAddr"`
-	AggregatorServerIpPortAddr     string                `json:"aggregatorServerIpPortAddr"`
-	AggregatorRestServerIpPortAddr string                `json:"aggregatorRestServerIpPortAddr"`
-	AggregatorDatabasePath         string                `json:"aggregatorDatabasePath"`
-	AggregatorCheckpointInterval   time.Duration         `json:"aggregatorCheckpointInterval"`
-	RegisterOperatorOnStartup      bool                  `json:"registerOperatorOnStartup"`
-	AggregatorAddress              common.Address        `json:"aggregatorAddress"`
+	EthHttpRpcUrl                   string                `json:"ethHttpRpcUrl"`
+	EthWsRpcUrl                     string                `json:"ethWsRpcUrl"`
+	EthLogConfirmations             uint64                `json:"ethLogConfirmations"`
+	RollupsInfo                     map[uint32]RollupInfo `json:"rollupsInfo"`
+	OperatorStateRetrieverAddr      common.Address        `json:"operatorStateRetrieverAddr"`
+	SFFLRegistryCoordinatorAddr     common.Address        `json:"sfflRegistryCoordinatorAddr"`
+	AggregatorServerIpPortAddr      string                `json:"aggregatorServerIpPortAddr"`
+	AggregatorRestServerIpPortAddr  string                `json:"aggregatorRestServerIpPortAddr"`
+	AggregatorAdminServerIpPortAddr string                `json:"aggregatorAdminServerIpPortAddr"`
+	AggregatorAdminApiToken         string                `json:"-"`
+	AggregatorDatabasePath          string                `json:"aggregatorDatabasePath"`
+	AggregatorCheckpointInterval    time.Duration         `json:"aggregatorCheckpointInterval"`
+	RegisterOperatorOnStartup       bool                  `json:"registerOperatorOnStartup"`
+	AggregatorAddress               common.Address        `json:"aggregatorAddress"`
+
+	// checkpoint scheduling related, zero values disable each bound
+	AggregatorCheckpointMinMessages              uint64        `json:"aggregatorCheckpointMinMessages"`
+	AggregatorCheckpointMaxStaleness             time.Duration `json:"aggregatorCheckpointMaxStaleness"`
+	AggregatorCheckpointMaxGasPrice              *big.Int      `json:"aggregatorCheckpointMaxGasPrice"`
+	AggregatorCheckpointGasPriceOverrideDeadline time.Duration `json:"aggregatorCheckpointGasPriceOverrideDeadline"`
+
+	// event cursor related. If enabled, events missed while down are replayed
+	// from the database cursor, up to the max replay blocks
+	AggregatorEventCursorEnabled         bool   `json:"aggregatorEventCursorEnabled"`
+	AggregatorEventCursorMaxReplayBlocks uint64 `json:"aggregatorEventCursorMaxReplayBlocks"`
+
+	// message aggregation related, zero values use the defaults
+	AggregatorMaxActiveMessages    int `json:"aggregatorMaxActiveMessages"`
+	AggregatorSignedMessageWorkers int `json:"aggregatorSignedMessageWorkers"`
+	AggregatorResponseShards       int `json:"aggregatorResponseShards"`
 
 	// metrics related
 	EnableMetrics        bool   `json:"enableMetrics"`
@@ -42,16 +63,31 @@ type Config struct {
 
 // These are read from ConfigFileFlag
 type ConfigRaw struct {
-	Environment                    sdklogging.LogLevel `yaml:"environment"`
-	EthRpcUrl                      string              `yaml:"eth_rpc_url"`
-	EthWsUrl                       string              `yaml:"eth_ws_url"`
-	AggregatorServerIpPortAddr     string              `yaml:"aggregator_server_ip_port_address"`
-	AggregatorRestServerIpPortAddr string              `yaml:"aggregator_rest_server_ip_port_address"`
-	AggregatorDatabasePath         string              `yaml:"aggregator_database_path"`
-	AggregatorCheckpointInterval   uint32              `yaml:"aggregator_checkpoint_interval"`
-	RegisterOperatorOnStartup      bool                `yaml:"register_operator_on_startup"`
-	RollupIdsToRpcUrls             map[uint32]string   `yaml:"rollup_ids_to_rpc_urls"`
-	RollupIdsToRegistryAddresses   map[uint32]string   `yaml:"rollup_ids_to_registry_addresses"`
+	Environment                     sdklogging.LogLevel `yaml:"environment"`
+	EthRpcUrl                       string              `yaml:"eth_rpc_url"`
+	EthWsUrl                        string              `yaml:"eth_ws_url"`
+	EthLogConfirmations             uint64              `yaml:"eth_log_confirmations"`
+	AggregatorServerIpPortAddr      string              `yaml:"aggregator_server_ip_port_address"`
+	AggregatorRestServerIpPortAddr  string              `yaml:"aggregator_rest_server_ip_port_address"`
+	AggregatorAdminServerIpPortAddr string              `yaml:"aggregator_admin_server_ip_port_address"`
+	AggregatorDatabasePath          string              `yaml:"aggregator_database_path"`
+	AggregatorCheckpointInterval    uint32              `yaml:"aggregator_checkpoint_interval"`
+	RegisterOperatorOnStartup       bool                `yaml:"register_operator_on_startup"`
+	RollupIdsToRpcUrls              map[uint32]string   `yaml:"rollup_ids_to_rpc_urls"`
+	RollupIdsToRegistryAddresses    map[uint32]string   `yaml:"rollup_ids_to_registry_addresses"`
+	RollupIdsToFailoverRpcUrls      map[uint32][]string `yaml:"rollup_ids_to_failover_rpc_urls"`
+
+	AggregatorCheckpointMinMessages              uint64 `yaml:"aggregator_checkpoint_min_messages"`
+	AggregatorCheckpointMaxStaleness             uint32 `yaml:"aggregator_checkpoint_max_staleness"`
+	AggregatorCheckpointMaxGasPriceGwei          uint64 `yaml:"aggregator_checkpoint_max_gas_price_gwei"`
+	AggregatorCheckpointGasPriceOverrideDeadline uint32 `yaml:"aggregator_checkpoint_gas_price_override_deadline"`
+
+	AggregatorEventCursorEnabled         bool   `yaml:"aggregator_event_cursor_enabled"`
+	AggregatorEventCursorMaxReplayBlocks uint64 `yaml:"aggregator_event_cursor_max_replay_blocks"`
+
+	AggregatorMaxActiveMessages    int `yaml:"aggregator_max_active_messages"`
+	AggregatorSignedMessageWorkers int `yaml:"aggregator_signed_message_workers"`
+	AggregatorResponseShards       int `yaml:"aggregator_response_shards"`
 
 	EnableMetrics        bool   `yaml:"enable_metrics"`
 	MetricsIpPortAddress string `yaml:"metrics_ip_port_address"`
@@ -69,6 +105,8 @@ type SFFLContractsRaw struct {
 type RollupInfo struct {
 	SFFLRegistryRollupAddr common.Address
 	RpcUrl                 string
+	// Endpoints failed over to when RpcUrl is down or lagging
+	FailoverRpcUrls []string
 }
 
 func NewConfigRaw(ctx *cli.Context) (*ConfigRaw, error) {
@@ -101,6 +139,13 @@ func CompileRollupsInfo(configRaw *ConfigRaw) map[uint32]RollupInfo {
 		rollupsInfo[chainId] = RollupInfo{
 			RpcUrl:                 url,
 			SFFLRegistryRollupAddr: common.HexToAddress(registryAddress),
+			FailoverRpcUrls:        configRaw.RollupIdsToFailoverRpcUrls[chainId],
+		}
+	}
+
+	for chainId := range configRaw.RollupIdsToFailoverRpcUrls {
+		if _, exist := rollupsInfo[chainId]; !exist {
+			panic(fmt.Sprintf("Failover RPC URLs set for unknown chainId %d", chainId))
 		}
 	}
 
@@ -142,20 +187,40 @@ func NewConfig(ctx *cli.Context, configRaw ConfigRaw, logger sdklogging.Logger)
 	}
 
 	config := &Config{
-		EcdsaPrivateKey:                ecdsaPrivateKey,
-		EthWsRpcUrl:                    configRaw.EthWsUrl,
-		EthHttpRpcUrl:                  configRaw.EthRpcUrl,
-		OperatorStateRetrieverAddr:     common.HexToAddress(sfflDeploymentRaw.Addresses.OperatorStateRetrieverAddr),
-		SFFLRegistryCoordinatorAddr:    common.HexToAddress(sfflDeploymentRaw.Addresses.RegistryCoordinatorAddr),
-		AggregatorServerIpPortAddr:     configRaw.AggregatorServerIpPortAddr,
-		RegisterOperatorOnStartup:      configRaw.RegisterOperatorOnStartup,
-		AggregatorRestServerIpPortAddr: configRaw.AggregatorRestServerIpPortAddr,
-		AggregatorDatabasePath:         configRaw.AggregatorDatabasePath,
-		AggregatorCheckpointInterval:   time.Duration(configRaw.AggregatorCheckpointInterval) * time.Millisecond,
-		AggregatorAddress:              aggregatorAddr,
-		RollupsInfo:                    rollupsInfo,
-		EnableMetrics:                  configRaw.EnableMetrics,
-		MetricsIpPortAddress:           configRaw.MetricsIpPortAddress,
+		EcdsaPrivateKey:                 ecdsaPrivateKey,
+		EthWsRpcUrl:                     configRaw.EthWsUrl,
+		EthHttpRpcUrl:                   configRaw.EthRpcUrl,
+		EthLogConfirmations:             configRaw.EthLogConfirmations,
+		OperatorStateRetrieverAddr:      common.HexToAddress(sfflDeploymentRaw.Addresses.OperatorStateRetrieverAddr),
+		SFFLRegistryCoordinatorAddr:     common.HexToAddress(sfflDeploymentRaw.Addresses.RegistryCoordinatorAddr),
+		AggregatorServerIpPortAddr:      configRaw.AggregatorServerIpPortAddr,
+		RegisterOperatorOnStartup:       configRaw.RegisterOperatorOnStartup,
+		AggregatorRestServerIpPortAddr:  configRaw.AggregatorRestServerIpPortAddr,
+		AggregatorAdminServerIpPortAddr: configRaw.AggregatorAdminServerIpPortAddr,
+		AggregatorAdminApiToken:         ctx.GlobalString(AggregatorAdminApiTokenFlag.Name),
+		AggregatorDatabasePath:          configRaw.AggregatorDatabasePath,
+		AggregatorCheckpointInterval:    time.Duration(configRaw.AggregatorCheckpointInterval) * time.Millisecond,
+		AggregatorAddress:               aggregatorAddr,
+		RollupsInfo:                     rollupsInfo,
+		EnableMetrics:                   configRaw.EnableMetrics,
+		MetricsIpPortAddress:            configRaw.MetricsIpPortAddress,
+
+		AggregatorCheckpointMinMessages:              configRaw.AggregatorCheckpointMinMessages,
+		AggregatorCheckpointMaxStaleness:             time.Duration(configRaw.AggregatorCheckpointMaxStaleness) * time.Millisecond,
+		AggregatorCheckpointGasPriceOverrideDeadline: time.Duration(configRaw.AggregatorCheckpointGasPriceOverrideDeadline) * time.Millisecond,
+
+		AggregatorEventCursorEnabled:         configRaw.AggregatorEventCursorEnabled,
+		AggregatorEventCursorMaxReplayBlocks: configRaw.AggregatorEventCursorMaxReplayBlocks,
+
+		AggregatorMaxActiveMessages:    configRaw.AggregatorMaxActiveMessages,
+		AggregatorSignedMessageWorkers: configRaw.AggregatorSignedMessageWorkers,
+		AggregatorResponseShards:       configRaw.AggregatorResponseShards,
+	}
+	if configRaw.AggregatorCheckpointMaxGasPriceGwei != 0 {
+		config.AggregatorCheckpointMaxGasPrice = new(big.Int).Mul(
+			new(big.Int).SetUint64(configRaw.AggregatorCheckpointMaxGasPriceGwei),
+			big.NewInt(params.GWei),
+		)
 	}
 	config.validate()
 
@@ -173,6 +238,19 @@ func (c *Config) validate() {
 	if c.MetricsIpPortAddress == "" {
 		panic("Config: MetricsIpPortAddress shall be valid socket addr even if disabled")
 	}
+	if c.AggregatorAdminServerIpPortAddr != "" && c.AggregatorAdminApiToken == "" {
+		panic("Config: AggregatorAdminApiToken is required if the admin server is enabled")
+	}
+	if c.AggregatorMaxActiveMessages < 0 || c.AggregatorSignedMessageWorkers < 0 || c.AggregatorResponseShards < 0 {
+		panic("Config: message aggregation limits can't be negative")
+	}
+	// Without a bound, a checkpoint could be postponed indefinitely
+	if c.AggregatorCheckpointMinMessages > 0 && c.AggregatorCheckpointMaxStaleness == 0 {
+		panic("Config: AggregatorCheckpointMaxStaleness is required if AggregatorCheckpointMinMessages is set")
+	}
+	if c.AggregatorCheckpointMaxGasPrice != nil && c.AggregatorCheckpointGasPriceOverrideDeadline == 0 {
+		panic("Config: AggregatorCheckpointGasPriceOverrideDeadline is required if AggregatorCheckpointMaxGasPrice is set")
+	}
 }
 
 var (
@@ -193,6 +271,12 @@ var (
 		Required: true,
 		EnvVar:   "ECDSA_PRIVATE_KEY",
 	}
+	/* Optional Flags */
+	AggregatorAdminApiTokenFlag = cli.StringFlag{
+		Name:   "admin-api-token",
+		Usage:  "Bearer token for the aggregator admin API",
+		EnvVar: "AGGREGATOR_ADMIN_API_TOKEN",
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -201,7 +285,9 @@ var requiredFlags = []cli.Flag{
 	EcdsaPrivateKeyFlag,
 }
 
-var optionalFlags = []cli.Flag{}
+var optionalFlags = []cli.Flag{
+	AggregatorAdminApiTokenFlag,
+}
 
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
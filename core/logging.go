@@ -0,0 +1,30 @@
+package core
+
+import (
+	"fmt"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"go.uber.org/zap"
+)
+
+// Creates a zap logger the same way as eigensdk's NewZapLogger, also
+// returning its level so that it can be changed at runtime
+func NewZapLoggerWithLevel(env sdklogging.LogLevel) (sdklogging.Logger, zap.AtomicLevel, error) {
+	var config zap.Config
+
+	switch env {
+	case sdklogging.Production:
+		config = zap.NewProductionConfig()
+	case sdklogging.Development:
+		config = zap.NewDevelopmentConfig()
+	default:
+		return nil, zap.AtomicLevel{}, fmt.Errorf("unknown environment, expected %s or %s, received %s", sdklogging.Development, sdklogging.Production, env)
+	}
+
+	logger, err := sdklogging.NewZapLoggerByConfig(config, zap.AddCallerSkip(1))
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	return logger, config.Level, nil
+}
@@ -12,12 +12,12 @@ type Metricable interface {
 	EnableMetrics(registry *prometheus.Registry) error
 }
 
-func CreateEthClientWithCollector(id, url string, enableMetrics bool, registry *prometheus.Registry, logger sdklogging.Logger) (safeclient.SafeClient, error) {
+func CreateEthClientWithCollector(id, url string, enableMetrics bool, registry *prometheus.Registry, logger sdklogging.Logger, opts ...safeclient.SafeEthClientOption) (safeclient.SafeClient, error) {
 	if enableMetrics {
 		// Using url as avsName
 		rpcCallsCollector := rpccalls.NewCollector(id+url, registry)
-		return safeclient.NewSafeEthClient(url, logger, safeclient.WithInstrumentedCreateClient(rpcCallsCollector))
+		opts = append(opts, safeclient.WithInstrumentedCreateClient(rpcCallsCollector))
 	}
 
-	return safeclient.NewSafeEthClient(url, logger)
+	return safeclient.NewSafeEthClient(url, logger, opts...)
 }
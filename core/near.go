@@ -0,0 +1,73 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+const BLOB_COMMITMENT_CHUNK_LEN = 256
+
+// ComputeBlobCommitment computes the NEAR DA commitment of a blob, which is the
+// NEAR merkle root of its data split in BLOB_COMMITMENT_CHUNK_LEN byte chunks
+func ComputeBlobCommitment(data []byte) [32]byte {
+	if len(data) == 0 {
+		return [32]byte{}
+	}
+
+	hashes := make([][32]byte, 0, (len(data)+BLOB_COMMITMENT_CHUNK_LEN-1)/BLOB_COMMITMENT_CHUNK_LEN)
+	for i := 0; i < len(data); i += BLOB_COMMITMENT_CHUNK_LEN {
+		chunk := data[i:min(i+BLOB_COMMITMENT_CHUNK_LEN, len(data))]
+
+		// Leaves are hashes of the borsh-serialized chunks
+		leaf := make([]byte, 4, 4+len(chunk))
+		binary.LittleEndian.PutUint32(leaf, uint32(len(chunk)))
+		leaf = append(leaf, chunk...)
+
+		hashes = append(hashes, sha256.Sum256(leaf))
+	}
+
+	for len(hashes) > 1 {
+		parents := make([][32]byte, 0, (len(hashes)+1)/2)
+		for i := 0; i < len(hashes); i += 2 {
+			if i+1 == len(hashes) {
+				parents = append(parents, hashes[i])
+				continue
+			}
+
+			parents = append(parents, sha256.Sum256(append(hashes[i][:], hashes[i+1][:]...)))
+		}
+
+		hashes = parents
+	}
+
+	return hashes[0]
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Encodes bytes in base58, as used by NEAR for hashes
+func EncodeBase58(input []byte) string {
+	value := new(big.Int).SetBytes(input)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	encoded := make([]byte, 0, len(input)*138/100+1)
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
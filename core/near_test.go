@@ -0,0 +1,45 @@
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeBlobCommitment(t *testing.T) {
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = 3
+	}
+
+	// Vector from near/rollup-data-availability primitives
+	commitment := ComputeBlobCommitment(data)
+	assert.Equal(t, "b56ff9af363fc1afe2bd32a239cd8c27d854c320e95afbceb678309ba6352794", hex.EncodeToString(commitment[:]))
+
+	// Odd leaf counts and partial last chunks, computed with the near-da
+	// merklization over bytes i % 251
+	vectors := map[int]string{
+		1:    "957b88b12730e646e0f33d3618b77dfa579e8231e3c59c7104be7165611c8027",
+		512:  "fc114448ae5f3baf4e164bb151362620b495f72eaa1c3fea1fd325477f5eed93",
+		700:  "cfce8768367df034bcaa06eaec27786846692956d447e7e22dfcd29a3c65bcd6",
+		1300: "50879249ed989708b443132daf87dcc7bda2b5b591cb67262658b45e3a3788f6",
+	}
+	for size, expected := range vectors {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+
+		commitment := ComputeBlobCommitment(data)
+		assert.Equal(t, expected, hex.EncodeToString(commitment[:]), "size %d", size)
+	}
+
+	assert.Equal(t, [32]byte{}, ComputeBlobCommitment(nil))
+	assert.NotEqual(t, commitment, ComputeBlobCommitment(append(data, 3)))
+}
+
+func TestEncodeBase58(t *testing.T) {
+	assert.Equal(t, "StV1DL6CwTryKyV", EncodeBase58([]byte("hello world")))
+	assert.Equal(t, "11111111111111111111111111111111", EncodeBase58(make([]byte, 32)))
+}
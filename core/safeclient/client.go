@@ -2,6 +2,7 @@ package safeclient
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"sync"
 	"time"
@@ -20,6 +21,9 @@ const (
 	BLOCK_MAX_RANGE    = 100
 	LOG_RESUB_INTERVAL = 5 * time.Minute
 	HEADER_TIMEOUT     = 30 * time.Second
+	// Max blocks replayed from a stored cursor, so that a stale cursor doesn't
+	// replay the whole chain
+	CURSOR_MAX_REPLAY_RANGE = 50_000
 )
 
 type SafeClient interface {
@@ -39,9 +43,17 @@ type SafeEthClient struct {
 	logResubInterval time.Duration
 	blockChunkSize   uint64
 	blockMaxRange    uint64
-
-	createClient func(string, logging.Logger) (eth.Client, error)
-	onceClose    sync.Once
+	pollInterval     time.Duration
+	alwaysPoll       bool
+	logConfirmations uint64
+	cursorStore      CursorStore
+	cursorMaxReplay  uint64
+
+	createClient        func(string, logging.Logger) (eth.Client, error)
+	failover            *failoverClient
+	endpointListener    EndpointEventListener
+	endpointLabelPrefix string
+	onceClose           sync.Once
 }
 
 func NewSafeEthClient(rpcUrl string, logger logging.Logger, opts ...SafeEthClientOption) (*SafeEthClient, error) {
@@ -52,8 +64,11 @@ func NewSafeEthClient(rpcUrl string, logger logging.Logger, opts ...SafeEthClien
 		headerTimeout:    HEADER_TIMEOUT,
 		blockChunkSize:   BLOCK_CHUNK_SIZE,
 		blockMaxRange:    BLOCK_MAX_RANGE,
+		pollInterval:     POLL_INTERVAL,
+		cursorMaxReplay:  CURSOR_MAX_REPLAY_RANGE,
 		closeC:           make(chan struct{}),
 		createClient:     createDefaultClient,
+		endpointListener: &SelectiveEndpointListener{},
 	}
 
 	for _, opt := range opts {
@@ -71,6 +86,63 @@ func NewSafeEthClient(rpcUrl string, logger logging.Logger, opts ...SafeEthClien
 	return safeClient, nil
 }
 
+// NewFailoverSafeEthClient creates a SafeEthClient over several RPC endpoints.
+// Calls and subscriptions are routed to the endpoint with the best score, based
+// on latency, error rate and head lag, and subscriptions are resubscribed on
+// the new endpoint when it changes.
+func NewFailoverSafeEthClient(rpcUrls []string, logger logging.Logger, opts ...SafeEthClientOption) (*SafeEthClient, error) {
+	if len(rpcUrls) == 0 {
+		return nil, NoEndpointAvailableError
+	}
+
+	safeClient := &SafeEthClient{
+		logger:           logger,
+		rpcUrl:           rpcUrls[0],
+		logResubInterval: LOG_RESUB_INTERVAL,
+		headerTimeout:    HEADER_TIMEOUT,
+		blockChunkSize:   BLOCK_CHUNK_SIZE,
+		blockMaxRange:    BLOCK_MAX_RANGE,
+		pollInterval:     POLL_INTERVAL,
+		cursorMaxReplay:  CURSOR_MAX_REPLAY_RANGE,
+		closeC:           make(chan struct{}),
+		createClient:     createDefaultClient,
+		endpointListener: &SelectiveEndpointListener{},
+	}
+
+	for _, opt := range opts {
+		opt(safeClient)
+	}
+
+	failover, err := newFailoverClient(rpcUrls, safeClient.endpointLabelPrefix, safeClient.createClient, safeClient.endpointListener, logger)
+	if err != nil {
+		logger.Error("Failed to create failover client", "err", err)
+		return nil, err
+	}
+	safeClient.Client = failover
+	safeClient.failover = failover
+
+	safeClient.wg.Add(1)
+	go func() {
+		defer safeClient.wg.Done()
+		failover.runProbes(safeClient.closeC)
+	}()
+
+	logger.Info("Created new failover SafeEthClient", "numEndpoints", len(rpcUrls))
+	return safeClient, nil
+}
+
+// NewRollupSafeEthClient creates a SafeEthClient for a rollup, which fails
+// over to failoverRpcUrls if any are given. Endpoints are labeled with the
+// rollup ID.
+func NewRollupSafeEthClient(rollupId uint32, rpcUrl string, failoverRpcUrls []string, logger logging.Logger, opts ...SafeEthClientOption) (*SafeEthClient, error) {
+	if len(failoverRpcUrls) == 0 {
+		return NewSafeEthClient(rpcUrl, logger, opts...)
+	}
+
+	opts = append(opts, WithEndpointLabelPrefix(fmt.Sprintf("rollup%d", rollupId)))
+	return NewFailoverSafeEthClient(append([]string{rpcUrl}, failoverRpcUrls...), logger, opts...)
+}
+
 type SafeEthClientOption func(*SafeEthClient)
 
 func WithLogResubInterval(interval time.Duration) SafeEthClientOption {
@@ -92,6 +164,47 @@ func WithLogFilteringParams(chunkSize, maxRange uint64) SafeEthClientOption {
 	}
 }
 
+// Interval for polling heads and logs on endpoints without subscriptions
+func WithPollInterval(interval time.Duration) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.pollInterval = interval
+	}
+}
+
+// Polls heads and logs even if the endpoint supports subscriptions, for
+// endpoints with unreliable WebSocket connections
+func WithAlwaysPoll() SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.alwaysPoll = true
+	}
+}
+
+// Delivers logs only once the given number of blocks were built on top of
+// them, and notifies removal of delivered logs whose blocks get reorged out.
+// Confirmations are checked every poll interval.
+func WithLogConfirmations(confirmations uint64) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.logConfirmations = confirmations
+	}
+}
+
+// Persists how far each log subscription got, so that logs emitted while the
+// process was down are replayed when subscribing again. Subscriptions are told
+// apart by their filter and the name set with WithCursorName.
+func WithCursorStore(store CursorStore) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.cursorStore = store
+	}
+}
+
+// Max blocks replayed from a stored cursor, CURSOR_MAX_REPLAY_RANGE by default.
+// Logs in older blocks are skipped.
+func WithCursorMaxReplayRange(blocks uint64) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.cursorMaxReplay = blocks
+	}
+}
+
 func WithInstrumentedCreateClient(collector *rpccalls.Collector) SafeEthClientOption {
 	return func(c *SafeEthClient) {
 		c.createClient = func(rpcUrl string, logger logging.Logger) (eth.Client, error) {
@@ -100,6 +213,18 @@ func WithInstrumentedCreateClient(collector *rpccalls.Collector) SafeEthClientOp
 	}
 }
 
+func WithEndpointEventListener(listener EndpointEventListener) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.endpointListener = listener
+	}
+}
+
+func WithEndpointLabelPrefix(prefix string) SafeEthClientOption {
+	return func(c *SafeEthClient) {
+		c.endpointLabelPrefix = prefix
+	}
+}
+
 func WithCustomCreateClient(createClient func(string, logging.Logger) (eth.Client, error)) SafeEthClientOption {
 	return func(c *SafeEthClient) {
 		c.createClient = createClient
@@ -147,6 +272,46 @@ func (s *SafeSubscription) SetUnderlyingSub(sub ethereum.Subscription) {
 	s.underlying = sub
 }
 
+// Returns a channel closed when the active endpoint changes, or nil if there's
+// a single endpoint
+func (c *SafeEthClient) endpointSwitchC() <-chan struct{} {
+	if c.failover == nil {
+		return nil
+	}
+
+	return c.failover.getSwitchC()
+}
+
+// Subscribes to logs on the endpoint, falling back to polling if it doesn't
+// support subscriptions
+func (c *SafeEthClient) subscribeFilterLogsOrPoll(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if !c.alwaysPoll {
+		sub, err := c.Client.SubscribeFilterLogs(ctx, q, ch)
+		if !isSubscriptionUnsupported(err) {
+			return sub, err
+		}
+
+		c.logger.Info("Subscriptions unsupported, polling logs instead", "interval", c.pollInterval)
+	}
+
+	return pollFilterLogs(ctx, c.Client, q, ch, c.pollInterval, c.blockChunkSize, c.logger)
+}
+
+// Subscribes to new heads on the endpoint, falling back to polling if it
+// doesn't support subscriptions
+func (c *SafeEthClient) subscribeNewHeadOrPoll(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if !c.alwaysPoll {
+		sub, err := c.Client.SubscribeNewHead(ctx, ch)
+		if !isSubscriptionUnsupported(err) {
+			return sub, err
+		}
+
+		c.logger.Info("Subscriptions unsupported, polling new heads instead", "interval", c.pollInterval)
+	}
+
+	return pollNewHeads(ctx, c.Client, ch, c.pollInterval, c.logger)
+}
+
 func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
 	logCache, err := lru.New[[32]byte, any](100)
 	if err != nil {
@@ -157,9 +322,67 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	tryCacheLog := func(log *types.Log) bool {
 		hash := hashLog(log)
 		ok, _ := logCache.ContainsOrAdd(hash, nil)
+		if !ok {
+			// The chain may flip back and forth between forks, so a log can
+			// be added again after being removed and vice versa
+			flipped := *log
+			flipped.Removed = !log.Removed
+			logCache.Remove(hashLog(&flipped))
+		}
 		return !ok
 	}
 
+	var confirmer *logConfirmer
+	if c.logConfirmations > 0 {
+		confirmer = newLogConfirmer(c.Client, c.logConfirmations, c.logger)
+	}
+
+	var cursor *logCursor
+	if c.cursorStore != nil {
+		cursor = newLogCursor(c.cursorStore, getCursorName(ctx), q, c.logger)
+	}
+
+	// Moves the cursor up to a block, as long as no log up to it is still
+	// waiting for confirmations
+	advanceCursor := func(block uint64) {
+		if cursor == nil {
+			return
+		}
+
+		if confirmer != nil {
+			if oldestBlock, ok := confirmer.getOldestPendingBlock(); ok && oldestBlock <= block {
+				if oldestBlock == 0 {
+					return
+				}
+				block = oldestBlock - 1
+			}
+		}
+
+		cursor.advance(block)
+	}
+
+	// The cursor moves as soon as the log is handed over, so a log the
+	// consumer hadn't handled yet when the process stopped isn't replayed
+	deliverLog := func(log types.Log) {
+		ch <- log
+
+		if !log.Removed && log.BlockNumber > 0 {
+			// Other logs from the same block may still be on their way
+			advanceCursor(log.BlockNumber - 1)
+		}
+	}
+
+	forwardLog := func(log types.Log) {
+		if confirmer == nil {
+			deliverLog(log)
+			return
+		}
+
+		for _, log := range confirmer.add(log) {
+			deliverLog(log)
+		}
+	}
+
 	currentBlock, err := c.Client.BlockNumber(ctx)
 	if err != nil {
 		c.logger.Error("Failed to get current block number", "err", err)
@@ -167,9 +390,24 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	}
 	c.logger.Debug("Got current block number", "block", currentBlock)
 
+	replayFromBlock := currentBlock + 1
+	if cursor != nil {
+		cursorBlock, ok, err := cursor.load()
+		if err != nil {
+			c.logger.Error("Failed to load log cursor", "err", err)
+			return nil, err
+		}
+
+		if ok {
+			replayFromBlock = c.capReplayFromBlock(cursorBlock+1, currentBlock)
+		}
+	}
+
 	proxyC := make(chan types.Log, 100)
 
-	newSub, err := c.Client.SubscribeFilterLogs(ctx, q, proxyC)
+	// Taken before subscribing so that no endpoint switch is missed
+	switchC := c.endpointSwitchC()
+	newSub, err := c.subscribeFilterLogsOrPoll(ctx, q, proxyC)
 	if err != nil {
 		c.logger.Error("Failed to subscribe to logs", "err", err)
 		return nil, err
@@ -179,54 +417,46 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	safeSub := NewSafeSubscription(newSub)
 	lastBlock := currentBlock
 
-	resubFilterLogs := func() ([]types.Log, error) {
+	resubFilterLogs := func() ([]types.Log, uint64, error) {
 		currentBlock, err := c.Client.BlockNumber(ctx)
 		if err != nil {
 			c.logger.Error("Failed to get current block number", "err", err)
-			return nil, err
+			return nil, 0, err
 		}
 		c.logger.Debug("Got current block number for resub", "block", currentBlock)
 
 		if lastBlock >= currentBlock {
-			return nil, nil
+			return nil, currentBlock, nil
 		}
 
 		c.logger.Debug("Comparing last log block with current block", "lastBlock", lastBlock, "currentBlock", currentBlock)
 
-		missedLogs := make([]types.Log, 0)
-
-		rangeStartBlock := currentBlock - c.blockMaxRange
-		if c.blockMaxRange > currentBlock {
-			rangeStartBlock = 0
-		}
-
-		fromBlock := max(lastBlock, rangeStartBlock+1)
+		fromBlock := lastBlock
 
-		for ; fromBlock <= currentBlock; fromBlock += c.blockChunkSize {
-			toBlock := min(fromBlock+c.blockChunkSize-1, currentBlock)
+		// With a persisted cursor every missed block has to be caught up on,
+		// up to the max replay range
+		if cursor != nil {
+			fromBlock = c.capReplayFromBlock(fromBlock, currentBlock)
+		} else {
+			rangeStartBlock := currentBlock - c.blockMaxRange
+			if c.blockMaxRange > currentBlock {
+				rangeStartBlock = 0
+			}
 
-			c.logger.Debug("Getting past logs", "fromBlock", fromBlock, "toBlock", toBlock)
+			fromBlock = max(lastBlock, rangeStartBlock+1)
+		}
 
-			logs, err := c.Client.FilterLogs(ctx, ethereum.FilterQuery{
-				FromBlock: big.NewInt(int64(fromBlock)),
-				ToBlock:   big.NewInt(int64(toBlock)),
-				Addresses: q.Addresses,
-				Topics:    q.Topics,
-			})
-			if err != nil {
-				c.logger.Error("Failed to get missed logs", "err", err)
-				return nil, err
-			} else {
-				c.logger.Info("Got missed logs on resubscribe", "count", len(logs))
-				missedLogs = append(missedLogs, logs...)
-			}
+		missedLogs, err := c.filterLogsInChunks(ctx, q, fromBlock, currentBlock)
+		if err != nil {
+			c.logger.Error("Failed to get missed logs", "err", err)
+			return nil, 0, err
 		}
 
-		return missedLogs, nil
+		return missedLogs, currentBlock, nil
 	}
 
 	resub := func() error {
-		newSub, err := c.Client.SubscribeFilterLogs(ctx, q, proxyC)
+		newSub, err := c.subscribeFilterLogsOrPoll(ctx, q, proxyC)
 		if err != nil {
 			c.logger.Error("Failed to resubscribe to logs", "err", err)
 			return err
@@ -235,7 +465,7 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 
 		safeSub.SetUnderlyingSub(newSub)
 
-		missedLogs, err := resubFilterLogs()
+		missedLogs, currentBlock, err := resubFilterLogs()
 		if err != nil {
 			c.logger.Error("Failed to get missed logs", "err", err)
 			return err
@@ -244,10 +474,34 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 		for _, log := range missedLogs {
 			if tryCacheLog(&log) {
 				lastBlock = max(lastBlock, log.BlockNumber)
-				ch <- log
+				forwardLog(log)
 			}
 		}
 
+		advanceCursor(currentBlock)
+
+		return nil
+	}
+
+	// Replays the logs emitted since the stored cursor before going live
+	replay := func() error {
+		if replayFromBlock <= currentBlock {
+			c.logger.Info("Replaying logs since stored cursor", "fromBlock", replayFromBlock, "toBlock", currentBlock)
+
+			logs, err := c.filterLogsInChunks(ctx, q, replayFromBlock, currentBlock)
+			if err != nil {
+				return err
+			}
+
+			for _, log := range logs {
+				if tryCacheLog(&log) {
+					forwardLog(log)
+				}
+			}
+		}
+
+		advanceCursor(currentBlock)
+
 		return nil
 	}
 
@@ -257,6 +511,26 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	go func() {
 		defer c.wg.Done()
 
+		// Live logs are held back until the replay succeeds, otherwise the
+		// cursor could move past the replayed range
+		for cursor != nil {
+			err := replay()
+			if err == nil {
+				break
+			}
+			c.logger.Error("Failed to replay logs, retrying", "err", err)
+
+			select {
+			case <-time.After(c.pollInterval):
+			case <-c.closeC:
+				safeSub.Unsubscribe()
+				return
+			case <-ctx.Done():
+				safeSub.Unsubscribe()
+				return
+			}
+		}
+
 		ticker := time.NewTicker(c.logResubInterval)
 		defer ticker.Stop()
 
@@ -269,6 +543,14 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 			ticker.Reset(c.logResubInterval)
 		}
 
+		var confirmC <-chan time.Time
+		if confirmer != nil {
+			confirmTicker := time.NewTicker(c.pollInterval)
+			defer confirmTicker.Stop()
+
+			confirmC = confirmTicker.C
+		}
+
 		for {
 			select {
 			case <-safeSub.Err():
@@ -277,7 +559,23 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 			case log := <-proxyC:
 				if tryCacheLog(&log) {
 					lastBlock = max(lastBlock, log.BlockNumber)
-					ch <- log
+					forwardLog(log)
+				}
+			case <-confirmC:
+				logs, err := confirmer.check(ctx)
+				if err != nil {
+					c.logger.Error("Failed to check log confirmations", "err", err)
+					continue
+				}
+
+				for _, log := range logs {
+					// Removals found by checking block hashes haven't been
+					// through the cache yet
+					if log.Removed && !tryCacheLog(&log) {
+						continue
+					}
+
+					deliverLog(log)
 				}
 			case <-ticker.C:
 				c.logger.Debug("Resub ticker fired")
@@ -285,6 +583,10 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 			case <-safeSub.underlying.Err():
 				c.logger.Info("Underlying subscription ended, resubscribing")
 				handleResub()
+			case <-switchC:
+				c.logger.Info("Active endpoint changed, resubscribing to logs")
+				switchC = c.endpointSwitchC()
+				handleResub()
 			case <-c.closeC:
 				c.logger.Info("Received close signal, ending subscription")
 				safeSub.Unsubscribe()
@@ -300,6 +602,33 @@ func (c *SafeEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	return safeSub, nil
 }
 
+// Gets the logs in a block range, splitting it into chunks of at most
+// blockChunkSize blocks
+func (c *SafeEthClient) filterLogsInChunks(ctx context.Context, q ethereum.FilterQuery, fromBlock, toBlock uint64) ([]types.Log, error) {
+	logs := make([]types.Log, 0)
+
+	for ; fromBlock <= toBlock; fromBlock += c.blockChunkSize {
+		chunkToBlock := min(fromBlock+c.blockChunkSize-1, toBlock)
+
+		c.logger.Debug("Getting past logs", "fromBlock", fromBlock, "toBlock", chunkToBlock)
+
+		chunkLogs, err := c.Client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(chunkToBlock),
+			Addresses: q.Addresses,
+			Topics:    q.Topics,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		c.logger.Info("Got past logs", "count", len(chunkLogs))
+		logs = append(logs, chunkLogs...)
+	}
+
+	return logs, nil
+}
+
 func (c *SafeEthClient) Close() {
 	c.onceClose.Do(func() {
 		close(c.closeC)
@@ -311,7 +640,9 @@ func (c *SafeEthClient) Close() {
 func (c *SafeEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
 	proxyC := make(chan *types.Header, 100)
 
-	newSub, err := c.Client.SubscribeNewHead(ctx, proxyC)
+	// Taken before subscribing so that no endpoint switch is missed
+	switchC := c.endpointSwitchC()
+	newSub, err := c.subscribeNewHeadOrPoll(ctx, proxyC)
 	if err != nil {
 		c.logger.Error("Failed to subscribe to new heads", "err", err)
 		return nil, err
@@ -321,7 +652,7 @@ func (c *SafeEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.H
 	safeSub := NewSafeSubscription(newSub)
 
 	resub := func() error {
-		newSub, err := c.Client.SubscribeNewHead(ctx, proxyC)
+		newSub, err := c.subscribeNewHeadOrPoll(ctx, proxyC)
 		if err != nil {
 			c.logger.Error("Failed to resubscribe to new heads", "err", err)
 			return err
@@ -362,6 +693,10 @@ func (c *SafeEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.H
 			case <-safeSub.underlying.Err():
 				c.logger.Info("Underlying subscription to new heads ended, resubscribing")
 				handleResub()
+			case <-switchC:
+				c.logger.Info("Active endpoint changed, resubscribing to new heads")
+				switchC = c.endpointSwitchC()
+				handleResub()
 			case <-headerTicker.C:
 				c.logger.Debug("Header ticker fired")
 				if receivedBlock {
@@ -401,3 +736,16 @@ func (c *SafeEthClient) GetClientAndVersion() (string, error) {
 	c.logger.Info("Got client version", "version", clientVersion)
 	return clientVersion, nil
 }
+
+// Moves fromBlock up so that at most cursorMaxReplay blocks up to currentBlock
+// are replayed
+func (c *SafeEthClient) capReplayFromBlock(fromBlock, currentBlock uint64) uint64 {
+	if c.cursorMaxReplay == 0 || currentBlock < c.cursorMaxReplay || fromBlock > currentBlock-c.cursorMaxReplay {
+		return fromBlock
+	}
+
+	cappedFromBlock := currentBlock - c.cursorMaxReplay + 1
+	c.logger.Warn("Log replay range exceeds max, skipping older blocks", "fromBlock", fromBlock, "cappedFromBlock", cappedFromBlock, "currentBlock", currentBlock)
+
+	return cappedFromBlock
+}
@@ -0,0 +1,196 @@
+package safeclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// Number of blocks after which a delivered log's block hash stops being
+	// checked for reorgs
+	LOG_REORG_TRACKING_DEPTH = 64
+)
+
+// Buffers logs until they reach a number of confirmations and keeps checking
+// the block hashes of delivered ones, so that logs from reorged blocks are
+// either never delivered or followed by a removal notification
+type logConfirmer struct {
+	client        eth.Client
+	confirmations uint64
+	trackingDepth uint64
+	logger        logging.Logger
+
+	pending   []types.Log
+	delivered []types.Log
+}
+
+func newLogConfirmer(client eth.Client, confirmations uint64, logger logging.Logger) *logConfirmer {
+	return &logConfirmer{
+		client:        client,
+		confirmations: confirmations,
+		trackingDepth: max(confirmations, LOG_REORG_TRACKING_DEPTH),
+		logger:        logger,
+	}
+}
+
+// Handles a log from the underlying subscription, returning the logs that
+// should be delivered right away
+func (l *logConfirmer) add(log types.Log) []types.Log {
+	if !log.Removed {
+		l.pending = append(l.pending, log)
+		return nil
+	}
+
+	var found bool
+
+	l.pending, found = removeLog(l.pending, &log)
+	if found {
+		l.logger.Debug("Dropped unconfirmed log from reorged block", "block", log.BlockNumber, "blockHash", log.BlockHash)
+		return nil
+	}
+
+	l.delivered, found = removeLog(l.delivered, &log)
+	if found {
+		return []types.Log{log}
+	}
+
+	return nil
+}
+
+// Returns the pending logs which reached the required confirmations and are
+// still in the canonical chain, as well as removal notifications for the
+// delivered logs which no longer are
+func (l *logConfirmer) check(ctx context.Context) ([]types.Log, error) {
+	if len(l.pending) == 0 && len(l.delivered) == 0 {
+		return nil, nil
+	}
+
+	head, err := l.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	isConfirmed := func(log *types.Log) bool {
+		return head >= log.BlockNumber+l.confirmations
+	}
+	isTracked := func(log *types.Log) bool {
+		return head < log.BlockNumber+l.trackingDepth
+	}
+
+	// Fetched upfront so that a failing call leaves the state untouched
+	blockHashes := make(map[uint64]common.Hash)
+	fetchBlockHash := func(blockNumber uint64) error {
+		if _, ok := blockHashes[blockNumber]; ok {
+			return nil
+		}
+
+		blockHash, err := l.getCanonicalBlockHash(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+
+		blockHashes[blockNumber] = blockHash
+		return nil
+	}
+
+	for i := range l.pending {
+		if isConfirmed(&l.pending[i]) {
+			if err := fetchBlockHash(l.pending[i].BlockNumber); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i := range l.delivered {
+		if isTracked(&l.delivered[i]) {
+			if err := fetchBlockHash(l.delivered[i].BlockNumber); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	logs := make([]types.Log, 0)
+
+	delivered := make([]types.Log, 0, len(l.delivered))
+	for _, log := range l.delivered {
+		if !isTracked(&log) {
+			continue
+		}
+
+		if blockHashes[log.BlockNumber] != log.BlockHash {
+			l.logger.Info("Delivered log was reorged out, notifying removal", "block", log.BlockNumber, "blockHash", log.BlockHash)
+
+			log.Removed = true
+			logs = append(logs, log)
+			continue
+		}
+
+		delivered = append(delivered, log)
+	}
+
+	pending := make([]types.Log, 0, len(l.pending))
+	for _, log := range l.pending {
+		if !isConfirmed(&log) {
+			pending = append(pending, log)
+			continue
+		}
+
+		if blockHashes[log.BlockNumber] != log.BlockHash {
+			l.logger.Debug("Dropped unconfirmed log from reorged block", "block", log.BlockNumber, "blockHash", log.BlockHash)
+			continue
+		}
+
+		logs = append(logs, log)
+		if isTracked(&log) {
+			delivered = append(delivered, log)
+		}
+	}
+
+	l.pending = pending
+	l.delivered = delivered
+
+	return logs, nil
+}
+
+func (l *logConfirmer) getOldestPendingBlock() (uint64, bool) {
+	if len(l.pending) == 0 {
+		return 0, false
+	}
+
+	oldestBlock := l.pending[0].BlockNumber
+	for _, log := range l.pending[1:] {
+		oldestBlock = min(oldestBlock, log.BlockNumber)
+	}
+
+	return oldestBlock, true
+}
+
+// Returns the hash of the canonical block at a height, or an empty hash if
+// there's none anymore
+func (l *logConfirmer) getCanonicalBlockHash(ctx context.Context, blockNumber uint64) (common.Hash, error) {
+	header, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if errors.Is(err, ethereum.NotFound) {
+		return common.Hash{}, nil
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return header.Hash(), nil
+}
+
+func removeLog(logs []types.Log, log *types.Log) ([]types.Log, bool) {
+	for i := range logs {
+		if logs[i].BlockHash == log.BlockHash && logs[i].TxHash == log.TxHash && logs[i].Index == log.Index {
+			return append(logs[:i], logs[i+1:]...), true
+		}
+	}
+
+	return logs, false
+}
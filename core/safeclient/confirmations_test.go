@@ -0,0 +1,166 @@
+package safeclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
+)
+
+type testChain struct {
+	head uint64
+	fork byte
+}
+
+func (c *testChain) header(blockNumber uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(blockNumber), Extra: []byte{c.fork}}
+}
+
+func (c *testChain) log(blockNumber uint64) types.Log {
+	return types.Log{BlockNumber: blockNumber, BlockHash: c.header(blockNumber).Hash()}
+}
+
+func createTestLogConfirmer(mockCtrl *gomock.Controller, chain *testChain, confirmations uint64) *logConfirmer {
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).DoAndReturn(func(ctx context.Context) (uint64, error) {
+		return chain.head, nil
+	}).AnyTimes()
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		return chain.header(number.Uint64()), nil
+	}).AnyTimes()
+
+	return newLogConfirmer(mockClient, confirmations, logging.NewNoopLogger())
+}
+
+func TestLogConfirmerDeliversConfirmedLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	chain := &testChain{head: 10}
+	confirmer := createTestLogConfirmer(mockCtrl, chain, 2)
+
+	log := chain.log(10)
+	assert.Empty(t, confirmer.add(log))
+
+	logs, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+
+	chain.head = 12
+
+	logs, err = confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Log{log}, logs)
+
+	logs, err = confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
+func TestLogConfirmerDropsReorgedPendingLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	chain := &testChain{head: 10}
+	confirmer := createTestLogConfirmer(mockCtrl, chain, 2)
+
+	confirmer.add(chain.log(10))
+
+	chain.fork = 1
+	chain.head = 12
+
+	logs, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+	assert.Empty(t, confirmer.pending)
+}
+
+func TestLogConfirmerNotifiesRemoval(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	chain := &testChain{head: 10}
+	confirmer := createTestLogConfirmer(mockCtrl, chain, 2)
+
+	log := chain.log(10)
+	confirmer.add(log)
+
+	chain.head = 12
+
+	logs, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Log{log}, logs)
+
+	chain.fork = 1
+	chain.head = 13
+
+	logs, err = confirmer.check(context.Background())
+	assert.NoError(t, err)
+
+	removedLog := log
+	removedLog.Removed = true
+	assert.Equal(t, []types.Log{removedLog}, logs)
+	assert.Empty(t, confirmer.delivered)
+}
+
+func TestLogConfirmerStopsTrackingDeepLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	chain := &testChain{head: 10}
+	confirmer := createTestLogConfirmer(mockCtrl, chain, 2)
+
+	confirmer.add(chain.log(10))
+
+	chain.head = 12
+
+	_, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, confirmer.delivered, 1)
+
+	chain.fork = 1
+	chain.head = 10 + LOG_REORG_TRACKING_DEPTH
+
+	logs, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+	assert.Empty(t, confirmer.delivered)
+}
+
+func TestLogConfirmerRemovedLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	chain := &testChain{head: 10}
+	confirmer := createTestLogConfirmer(mockCtrl, chain, 2)
+
+	pendingLog := chain.log(10)
+	confirmer.add(pendingLog)
+
+	removedPendingLog := pendingLog
+	removedPendingLog.Removed = true
+	assert.Empty(t, confirmer.add(removedPendingLog))
+	assert.Empty(t, confirmer.pending)
+
+	deliveredLog := chain.log(11)
+	confirmer.add(deliveredLog)
+
+	chain.head = 13
+
+	logs, err := confirmer.check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Log{deliveredLog}, logs)
+
+	removedDeliveredLog := deliveredLog
+	removedDeliveredLog.Removed = true
+	assert.Equal(t, []types.Log{removedDeliveredLog}, confirmer.add(removedDeliveredLog))
+	assert.Empty(t, confirmer.delivered)
+
+	assert.NotEqual(t, hashLog(&deliveredLog), hashLog(&removedDeliveredLog))
+}
@@ -0,0 +1,214 @@
+package safeclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Min time between two writes of the cursor file, so that a burst of logs
+// doesn't rewrite it once per log
+const FILE_CURSOR_FLUSH_INTERVAL = time.Second
+
+// Persists, per log subscription, the block up to which all logs were
+// delivered, so that subscriptions can replay what was missed while the
+// process was down. A log counts as delivered once it's handed to the
+// subscription channel, so delivery is at-most-once across restarts: logs
+// the consumer received but hadn't handled yet are not replayed.
+type CursorStore interface {
+	FetchCursor(key string) (uint64, bool, error)
+	StoreCursor(key string, block uint64) error
+}
+
+// CursorStore backed by a JSON file. Stored cursors are written out at most
+// once per flush interval, Close writes out the pending ones.
+type FileCursorStore struct {
+	path          string
+	flushInterval time.Duration
+	lock          sync.Mutex
+	cursors       map[string]uint64
+	flushTimer    *time.Timer
+	flushErr      error
+}
+
+var _ CursorStore = (*FileCursorStore)(nil)
+
+func NewFileCursorStore(path string) (*FileCursorStore, error) {
+	store := &FileCursorStore{
+		path:          path,
+		flushInterval: FILE_CURSOR_FLUSH_INTERVAL,
+		cursors:       make(map[string]uint64),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &store.cursors)
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *FileCursorStore) FetchCursor(key string) (uint64, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	block, ok := s.cursors[key]
+	return block, ok, nil
+}
+
+// Stores the cursor in memory and schedules a write of the file. Errors from
+// a previous write are returned here, as writes happen in the background.
+func (s *FileCursorStore) StoreCursor(key string, block uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cursors[key] = block
+
+	if s.flushTimer == nil {
+		s.flushTimer = time.AfterFunc(s.flushInterval, func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			s.flushErr = s.flush()
+		})
+	}
+
+	err := s.flushErr
+	s.flushErr = nil
+	return err
+}
+
+// Writes out the pending cursors
+func (s *FileCursorStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.flushTimer == nil {
+		return nil
+	}
+
+	return s.flush()
+}
+
+// Must be called with the lock held
+func (s *FileCursorStore) flush() error {
+	if s.flushTimer != nil {
+		s.flushTimer.Stop()
+		s.flushTimer = nil
+	}
+
+	data, err := json.Marshal(s.cursors)
+	if err != nil {
+		return err
+	}
+
+	// Written to a temporary file first so that a crash never leaves a
+	// truncated file behind
+	tmpPath := s.path + ".tmp"
+	err = os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.path)
+}
+
+type logCursorNameKey struct{}
+
+// Names the cursors of the log subscriptions made with the returned context.
+// Subscriptions with the same filter on the same client need different names
+// to keep separate cursors.
+func WithCursorName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, logCursorNameKey{}, name)
+}
+
+func getCursorName(ctx context.Context) string {
+	name, _ := ctx.Value(logCursorNameKey{}).(string)
+	return name
+}
+
+// Identifies a log subscription by its name and filter, so that its cursor is
+// found again after a restart
+func getLogCursorKey(name string, q ethereum.FilterQuery) string {
+	data := []byte(name)
+
+	// Separates the name from the filter
+	data = append(data, '|')
+	for _, address := range q.Addresses {
+		data = append(data, address.Bytes()...)
+	}
+
+	for _, topics := range q.Topics {
+		// Separates topic positions, as wildcards are empty
+		data = append(data, '|')
+		for _, topic := range topics {
+			data = append(data, topic.Bytes()...)
+		}
+	}
+
+	return common.Bytes2Hex(crypto.Keccak256(data))
+}
+
+// Tracks the cursor of a single log subscription
+type logCursor struct {
+	store  CursorStore
+	key    string
+	block  uint64
+	logger logging.Logger
+}
+
+func newLogCursor(store CursorStore, name string, q ethereum.FilterQuery, logger logging.Logger) *logCursor {
+	return &logCursor{
+		store:  store,
+		key:    getLogCursorKey(name, q),
+		logger: logger,
+	}
+}
+
+// Returns the stored cursor, if any
+func (c *logCursor) load() (uint64, bool, error) {
+	block, ok, err := c.store.FetchCursor(c.key)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+
+	c.block = block
+	return block, true, nil
+}
+
+// Stores the cursor if it moved forward
+func (c *logCursor) advance(block uint64) {
+	if block <= c.block {
+		return
+	}
+
+	err := c.store.StoreCursor(c.key, block)
+	if err != nil {
+		c.logger.Error("Failed to store log cursor", "key", c.key, "block", block, "err", err)
+		return
+	}
+
+	c.block = block
+}
@@ -0,0 +1,218 @@
+package safeclient
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
+)
+
+func TestFileCursorStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors", "cursors.json")
+
+	store, err := NewFileCursorStore(path)
+	assert.NoError(t, err)
+
+	_, ok, err := store.FetchCursor("key")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	store.flushInterval = time.Hour
+	assert.NoError(t, store.StoreCursor("key", 10))
+	assert.NoFileExists(t, path)
+
+	assert.NoError(t, store.Close())
+
+	store, err = NewFileCursorStore(path)
+	assert.NoError(t, err)
+
+	block, ok, err := store.FetchCursor("key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), block)
+}
+
+func TestFileCursorStoreFlushesInBackground(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	store, err := NewFileCursorStore(path)
+	assert.NoError(t, err)
+	store.flushInterval = 10 * time.Millisecond
+
+	for block := uint64(1); block <= 10; block++ {
+		assert.NoError(t, store.StoreCursor("key", block))
+	}
+
+	assert.Eventually(t, func() bool {
+		store, err := NewFileCursorStore(path)
+		if err != nil {
+			return false
+		}
+
+		block, ok, _ := store.FetchCursor("key")
+		return ok && block == 10
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGetLogCursorKey(t *testing.T) {
+	address := common.Address{1}
+	topicA := common.Hash{2}
+	topicB := common.Hash{3}
+
+	key := getLogCursorKey("", ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{topicA}, {topicB}}})
+
+	assert.Equal(t, key, getLogCursorKey("", ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{topicA}, {topicB}}}))
+	assert.NotEqual(t, key, getLogCursorKey("", ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{topicA, topicB}}}))
+	assert.NotEqual(t, key, getLogCursorKey("", ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{topicA}, {}, {topicB}}}))
+	assert.NotEqual(t, key, getLogCursorKey("name", ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{topicA}, {topicB}}}))
+	assert.NotEqual(t,
+		getLogCursorKey("a", ethereum.FilterQuery{Addresses: []common.Address{address}}),
+		getLogCursorKey("b", ethereum.FilterQuery{Addresses: []common.Address{address}}),
+	)
+}
+
+func TestSubscribeFilterLogsReplaysFromCursor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	q := ethereum.FilterQuery{Addresses: []common.Address{{1}}}
+
+	store, err := NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, store.StoreCursor(getLogCursorKey("", q), 100))
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(350), nil).AnyTimes()
+	mockClient.EXPECT().SubscribeFilterLogs(gomock.Any(), gomock.Any(), gomock.Any()).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil).AnyTimes()
+
+	// Replayed in chunks, well past the resub range cap
+	for _, blocks := range [][2]uint64{{101, 200}, {201, 300}, {301, 350}} {
+		blocks := blocks
+		mockClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fq ethereum.FilterQuery) ([]types.Log, error) {
+			assert.Equal(t, blocks[0], fq.FromBlock.Uint64())
+			assert.Equal(t, blocks[1], fq.ToBlock.Uint64())
+
+			return []types.Log{{Address: common.Address{1}, BlockNumber: blocks[0], TxHash: common.Hash{byte(blocks[0])}}}, nil
+		})
+	}
+
+	client, err := NewSafeEthClient("ws://localhost:8545", logging.NewNoopLogger(),
+		WithCursorStore(store),
+		WithLogFilteringParams(100, 100),
+		WithCustomCreateClient(func(string, logging.Logger) (eth.Client, error) { return mockClient, nil }),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	logsC := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogs(context.Background(), q, logsC)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range []uint64{101, 201, 301} {
+		select {
+		case log := <-logsC:
+			assert.Equal(t, expected, log.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatal("log timed out")
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		block, _, _ := store.FetchCursor(getLogCursorKey("", q))
+		return block == 350
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscribeFilterLogsStoresInitialCursor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	q := ethereum.FilterQuery{Addresses: []common.Address{{1}}}
+
+	store, err := NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json"))
+	assert.NoError(t, err)
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(350), nil).AnyTimes()
+	mockClient.EXPECT().SubscribeFilterLogs(gomock.Any(), gomock.Any(), gomock.Any()).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil).AnyTimes()
+
+	client, err := NewSafeEthClient("ws://localhost:8545", logging.NewNoopLogger(),
+		WithCursorStore(store),
+		WithCustomCreateClient(func(string, logging.Logger) (eth.Client, error) { return mockClient, nil }),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	sub, err := client.SubscribeFilterLogs(context.Background(), q, make(chan types.Log))
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Eventually(t, func() bool {
+		block, ok, _ := store.FetchCursor(getLogCursorKey("", q))
+		return ok && block == 350
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscribeFilterLogsCapsReplayRange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	q := ethereum.FilterQuery{Addresses: []common.Address{{1}}}
+
+	store, err := NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, store.StoreCursor(getLogCursorKey("", q), 100))
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(350), nil).AnyTimes()
+	mockClient.EXPECT().SubscribeFilterLogs(gomock.Any(), gomock.Any(), gomock.Any()).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil).AnyTimes()
+
+	// Only the last 50 blocks are replayed
+	mockClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fq ethereum.FilterQuery) ([]types.Log, error) {
+		assert.Equal(t, uint64(301), fq.FromBlock.Uint64())
+		assert.Equal(t, uint64(350), fq.ToBlock.Uint64())
+
+		return []types.Log{{Address: common.Address{1}, BlockNumber: 301}}, nil
+	})
+
+	client, err := NewSafeEthClient("ws://localhost:8545", logging.NewNoopLogger(),
+		WithCursorStore(store),
+		WithCursorMaxReplayRange(50),
+		WithCustomCreateClient(func(string, logging.Logger) (eth.Client, error) { return mockClient, nil }),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	logsC := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogs(context.Background(), q, logsC)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	select {
+	case log := <-logsC:
+		assert.Equal(t, uint64(301), log.BlockNumber)
+	case <-time.After(time.Second):
+		t.Fatal("log timed out")
+	}
+}
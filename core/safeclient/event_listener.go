@@ -0,0 +1,161 @@
+package safeclient
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const SafeClientSubsystem = "safeclient"
+
+type EndpointEventListener interface {
+	OnEndpointFailure(endpoint string)
+	OnEndpointSwitch(endpoint string)
+	ObserveEndpointStats(endpoint string, stats EndpointStats)
+}
+
+type SelectiveEndpointListener struct {
+	OnEndpointFailureCb    func(endpoint string)
+	OnEndpointSwitchCb     func(endpoint string)
+	ObserveEndpointStatsCb func(endpoint string, stats EndpointStats)
+}
+
+func (l *SelectiveEndpointListener) OnEndpointFailure(endpoint string) {
+	if l.OnEndpointFailureCb != nil {
+		l.OnEndpointFailureCb(endpoint)
+	}
+}
+
+func (l *SelectiveEndpointListener) OnEndpointSwitch(endpoint string) {
+	if l.OnEndpointSwitchCb != nil {
+		l.OnEndpointSwitchCb(endpoint)
+	}
+}
+
+func (l *SelectiveEndpointListener) ObserveEndpointStats(endpoint string, stats EndpointStats) {
+	if l.ObserveEndpointStatsCb != nil {
+		l.ObserveEndpointStatsCb(endpoint, stats)
+	}
+}
+
+// Should be created once per registry and shared by the clients using it
+func MakeEndpointMetrics(registry *prometheus.Registry, namespace string) (EndpointEventListener, error) {
+	numEndpointFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "num_of_endpoint_failures",
+			Help:      "The number of failed calls and probes per RPC endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(numEndpointFailures); err != nil {
+		return nil, fmt.Errorf("error registering numEndpointFailures counter: %w", err)
+	}
+
+	numEndpointSwitches := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "num_of_endpoint_switches",
+			Help:      "The number of times each RPC endpoint became the active one",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(numEndpointSwitches); err != nil {
+		return nil, fmt.Errorf("error registering numEndpointSwitches counter: %w", err)
+	}
+
+	endpointLatency := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "endpoint_latency_ms",
+			Help:      "Average probe latency per RPC endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(endpointLatency); err != nil {
+		return nil, fmt.Errorf("error registering endpointLatency gauge: %w", err)
+	}
+
+	endpointErrorRate := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "endpoint_error_rate",
+			Help:      "Average error rate per RPC endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(endpointErrorRate); err != nil {
+		return nil, fmt.Errorf("error registering endpointErrorRate gauge: %w", err)
+	}
+
+	endpointHeadLag := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "endpoint_head_lag",
+			Help:      "Blocks behind the highest known head per RPC endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(endpointHeadLag); err != nil {
+		return nil, fmt.Errorf("error registering endpointHeadLag gauge: %w", err)
+	}
+
+	endpointScore := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "endpoint_score",
+			Help:      "Health score per RPC endpoint, lower is better",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(endpointScore); err != nil {
+		return nil, fmt.Errorf("error registering endpointScore gauge: %w", err)
+	}
+
+	endpointActive := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: SafeClientSubsystem,
+			Name:      "endpoint_active",
+			Help:      "Whether the RPC endpoint is the one calls are routed to",
+		},
+		[]string{"endpoint"},
+	)
+
+	if err := registry.Register(endpointActive); err != nil {
+		return nil, fmt.Errorf("error registering endpointActive gauge: %w", err)
+	}
+
+	return &SelectiveEndpointListener{
+		OnEndpointFailureCb: func(endpoint string) {
+			numEndpointFailures.WithLabelValues(endpoint).Inc()
+		},
+		OnEndpointSwitchCb: func(endpoint string) {
+			numEndpointSwitches.WithLabelValues(endpoint).Inc()
+		},
+		ObserveEndpointStatsCb: func(endpoint string, stats EndpointStats) {
+			endpointLatency.WithLabelValues(endpoint).Set(stats.LatencyMs)
+			endpointErrorRate.WithLabelValues(endpoint).Set(stats.ErrorRate)
+			endpointHeadLag.WithLabelValues(endpoint).Set(float64(stats.HeadLag))
+			endpointScore.WithLabelValues(endpoint).Set(stats.Score)
+
+			active := 0.0
+			if stats.IsActive {
+				active = 1.0
+			}
+			endpointActive.WithLabelValues(endpoint).Set(active)
+		},
+	}, nil
+}
@@ -0,0 +1,548 @@
+package safeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	ENDPOINT_PROBE_INTERVAL = 5 * time.Second
+	ENDPOINT_PROBE_TIMEOUT  = 5 * time.Second
+	// Weight of new samples in the endpoint latency and error rate averages
+	ENDPOINT_STATS_WEIGHT = 0.2
+	// Score penalties, in milliseconds of latency, for a full error rate and
+	// for each block behind the highest known head
+	ENDPOINT_ERROR_PENALTY_MS    = 5000
+	ENDPOINT_HEAD_LAG_PENALTY_MS = 500
+	// Relative score improvement needed to move away from the active endpoint
+	ENDPOINT_SWITCH_MARGIN = 0.2
+	// Consecutive failed probes after which an endpoint client is recreated,
+	// as e.g. websocket clients don't reconnect by themselves
+	ENDPOINT_RECREATE_FAILURES = 3
+)
+
+var (
+	NoEndpointAvailableError = errors.New("no RPC endpoint available")
+)
+
+type EndpointStats struct {
+	LatencyMs float64
+	ErrorRate float64
+	HeadLag   uint64
+	Score     float64
+	IsActive  bool
+}
+
+type rpcEndpoint struct {
+	url   string
+	label string
+
+	lock      sync.Mutex
+	client    eth.Client
+	latencyMs float64
+	errorRate float64
+	head      uint64
+	headLag   uint64
+	// Consecutive failed probes
+	probeFailures int
+}
+
+func (e *rpcEndpoint) getClient() eth.Client {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.client
+}
+
+func (e *rpcEndpoint) recordResult(failed bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	sample := 0.0
+	if failed {
+		sample = 1.0
+	}
+
+	e.errorRate += ENDPOINT_STATS_WEIGHT * (sample - e.errorRate)
+}
+
+func (e *rpcEndpoint) recordLatency(latency time.Duration) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.latencyMs += ENDPOINT_STATS_WEIGHT * (float64(latency.Milliseconds()) - e.latencyMs)
+}
+
+// Lower is better. Endpoints without a client are never picked.
+func (e *rpcEndpoint) score() float64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.client == nil {
+		return math.Inf(1)
+	}
+
+	return e.latencyMs + e.errorRate*ENDPOINT_ERROR_PENALTY_MS + float64(e.headLag)*ENDPOINT_HEAD_LAG_PENALTY_MS
+}
+
+// failoverClient is an eth.Client which routes each call to the active endpoint,
+// which is periodically reelected as the one with the best score
+type failoverClient struct {
+	endpoints    []*rpcEndpoint
+	createClient func(string, logging.Logger) (eth.Client, error)
+
+	activeLock sync.RWMutex
+	active     int
+	// Closed and replaced whenever the active endpoint changes
+	switchC chan struct{}
+
+	listener EndpointEventListener
+	logger   logging.Logger
+}
+
+var _ eth.Client = (*failoverClient)(nil)
+
+// Endpoints that can't be created are retried on every probe, as long as at
+// least one of them could be created. Endpoint labels are prefixed with
+// labelPrefix if set, so that endpoints of different clients are told apart.
+func newFailoverClient(rpcUrls []string, labelPrefix string, createClient func(string, logging.Logger) (eth.Client, error), listener EndpointEventListener, logger logging.Logger) (*failoverClient, error) {
+	c := &failoverClient{
+		endpoints:    make([]*rpcEndpoint, len(rpcUrls)),
+		createClient: createClient,
+		active:       -1,
+		switchC:      make(chan struct{}),
+		listener:     listener,
+		logger:       logger,
+	}
+
+	for i, rpcUrl := range rpcUrls {
+		c.endpoints[i] = &rpcEndpoint{url: rpcUrl, label: endpointLabel(labelPrefix, i, rpcUrl)}
+
+		client, err := createClient(rpcUrl, logger)
+		if err != nil {
+			logger.Warn("Failed to create endpoint client", "endpoint", c.endpoints[i].label, "err", err)
+			continue
+		}
+
+		c.endpoints[i].client = client
+		if c.active == -1 {
+			c.active = i
+		}
+	}
+
+	if c.active == -1 {
+		return nil, NoEndpointAvailableError
+	}
+
+	return c, nil
+}
+
+// Labels endpoints by index and host only, as paths and credentials may hold
+// API keys. The index keeps labels unique when endpoints share a host.
+func endpointLabel(prefix string, index int, rpcUrl string) string {
+	host := "endpoint"
+	parsedUrl, err := url.Parse(rpcUrl)
+	if err == nil && parsedUrl.Host != "" {
+		host = parsedUrl.Host
+	}
+
+	label := fmt.Sprintf("%d/%s", index, host)
+	if prefix != "" {
+		label = prefix + "/" + label
+	}
+
+	return label
+}
+
+func (c *failoverClient) activeEndpoint() *rpcEndpoint {
+	c.activeLock.RLock()
+	defer c.activeLock.RUnlock()
+
+	return c.endpoints[c.active]
+}
+
+func (c *failoverClient) getSwitchC() <-chan struct{} {
+	c.activeLock.RLock()
+	defer c.activeLock.RUnlock()
+
+	return c.switchC
+}
+
+// Only transport failures count against an endpoint, not RPC level errors such
+// as reverts or missing data
+func isEndpointFailure(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+func (c *failoverClient) recordCallResult(endpoint *rpcEndpoint, err error) {
+	failed := isEndpointFailure(err)
+	endpoint.recordResult(failed)
+
+	if failed {
+		c.listener.OnEndpointFailure(endpoint.label)
+		c.reelect()
+	}
+}
+
+// Switches to the best scored endpoint if it's sufficiently better than the
+// active one, so that similar endpoints don't keep taking turns
+func (c *failoverClient) reelect() {
+	scores := make([]float64, len(c.endpoints))
+	best := 0
+	for i, endpoint := range c.endpoints {
+		scores[i] = endpoint.score()
+		if scores[i] < scores[best] {
+			best = i
+		}
+	}
+
+	c.activeLock.Lock()
+	defer c.activeLock.Unlock()
+
+	if best == c.active || scores[best] >= scores[c.active]*(1-ENDPOINT_SWITCH_MARGIN) {
+		return
+	}
+
+	c.logger.Warn("Switching RPC endpoint",
+		"from", c.endpoints[c.active].label,
+		"to", c.endpoints[best].label,
+		"fromScore", scores[c.active],
+		"toScore", scores[best],
+	)
+	c.listener.OnEndpointSwitch(c.endpoints[best].label)
+
+	c.active = best
+	close(c.switchC)
+	c.switchC = make(chan struct{})
+}
+
+// Measures each endpoint latency and head, recreating clients that couldn't
+// be created before or that keep failing
+func (c *failoverClient) probe(ctx context.Context) {
+	heads := make([]uint64, len(c.endpoints))
+	probed := make([]bool, len(c.endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range c.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *rpcEndpoint) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, ENDPOINT_PROBE_TIMEOUT)
+			defer cancel()
+
+			client := endpoint.getClient()
+			if client == nil {
+				newClient, err := c.createClient(endpoint.url, c.logger)
+				if err != nil {
+					c.logger.Debug("Failed to recreate endpoint client", "endpoint", endpoint.label, "err", err)
+					return
+				}
+
+				endpoint.lock.Lock()
+				endpoint.client = newClient
+				endpoint.lock.Unlock()
+
+				client = newClient
+			}
+
+			start := time.Now()
+			head, err := client.BlockNumber(ctx)
+			endpoint.recordResult(err != nil)
+			if err != nil {
+				c.logger.Debug("Endpoint probe failed", "endpoint", endpoint.label, "err", err)
+				c.listener.OnEndpointFailure(endpoint.label)
+				c.recordProbeFailure(endpoint, client)
+				return
+			}
+
+			endpoint.lock.Lock()
+			endpoint.probeFailures = 0
+			endpoint.lock.Unlock()
+
+			endpoint.recordLatency(time.Since(start))
+			heads[i] = head
+			probed[i] = true
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	maxHead := uint64(0)
+	for _, head := range heads {
+		maxHead = max(maxHead, head)
+	}
+
+	for i, endpoint := range c.endpoints {
+		endpoint.lock.Lock()
+		if probed[i] {
+			endpoint.head = heads[i]
+		}
+		if maxHead > endpoint.head {
+			endpoint.headLag = maxHead - endpoint.head
+		} else {
+			endpoint.headLag = 0
+		}
+		endpoint.lock.Unlock()
+	}
+
+	c.reelect()
+	c.reportStats()
+}
+
+// Drops the endpoint client after ENDPOINT_RECREATE_FAILURES failed probes and
+// creates a new one. If that fails, creation is retried on the next probes.
+func (c *failoverClient) recordProbeFailure(endpoint *rpcEndpoint, client eth.Client) {
+	endpoint.lock.Lock()
+	endpoint.probeFailures++
+	if endpoint.probeFailures < ENDPOINT_RECREATE_FAILURES || endpoint.client != client {
+		endpoint.lock.Unlock()
+		return
+	}
+	endpoint.probeFailures = 0
+	endpoint.client = nil
+	endpoint.lock.Unlock()
+
+	if closer, ok := client.(interface{ Close() }); ok {
+		closer.Close()
+	}
+
+	c.logger.Warn("Recreating failing endpoint client", "endpoint", endpoint.label)
+
+	newClient, err := c.createClient(endpoint.url, c.logger)
+	if err != nil {
+		c.logger.Debug("Failed to recreate endpoint client", "endpoint", endpoint.label, "err", err)
+		return
+	}
+
+	endpoint.lock.Lock()
+	endpoint.client = newClient
+	endpoint.lock.Unlock()
+}
+
+func (c *failoverClient) reportStats() {
+	active := c.activeEndpoint()
+
+	for _, endpoint := range c.endpoints {
+		score := endpoint.score()
+
+		endpoint.lock.Lock()
+		stats := EndpointStats{
+			LatencyMs: endpoint.latencyMs,
+			ErrorRate: endpoint.errorRate,
+			HeadLag:   endpoint.headLag,
+			Score:     score,
+			IsActive:  endpoint == active,
+		}
+		endpoint.lock.Unlock()
+
+		c.listener.ObserveEndpointStats(endpoint.label, stats)
+	}
+}
+
+func (c *failoverClient) runProbes(closeC <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-closeC
+		cancel()
+	}()
+
+	ticker := time.NewTicker(ENDPOINT_PROBE_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closeC:
+			return
+		case <-ticker.C:
+			c.probe(ctx)
+		}
+	}
+}
+
+func route[T any](c *failoverClient, call func(eth.Client) (T, error)) (T, error) {
+	endpoint := c.activeEndpoint()
+
+	client := endpoint.getClient()
+	if client == nil {
+		var zero T
+		return zero, NoEndpointAvailableError
+	}
+
+	result, err := call(client)
+	c.recordCallResult(endpoint, err)
+
+	return result, err
+}
+
+func (c *failoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.ChainID(ctx) })
+}
+
+func (c *failoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.BalanceAt(ctx, account, blockNumber) })
+}
+
+func (c *failoverClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return route(c, func(client eth.Client) (*types.Block, error) { return client.BlockByHash(ctx, hash) })
+}
+
+func (c *failoverClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return route(c, func(client eth.Client) (*types.Block, error) { return client.BlockByNumber(ctx, number) })
+}
+
+func (c *failoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return route(c, func(client eth.Client) (uint64, error) { return client.BlockNumber(ctx) })
+}
+
+func (c *failoverClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.CallContract(ctx, msg, blockNumber) })
+}
+
+func (c *failoverClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.CallContractAtHash(ctx, msg, blockHash) })
+}
+
+func (c *failoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.CodeAt(ctx, account, blockNumber) })
+}
+
+func (c *failoverClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return route(c, func(client eth.Client) (uint64, error) { return client.EstimateGas(ctx, msg) })
+}
+
+func (c *failoverClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return route(c, func(client eth.Client) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+func (c *failoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return route(c, func(client eth.Client) ([]types.Log, error) { return client.FilterLogs(ctx, q) })
+}
+
+func (c *failoverClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return route(c, func(client eth.Client) (*types.Header, error) { return client.HeaderByHash(ctx, hash) })
+}
+
+func (c *failoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return route(c, func(client eth.Client) (*types.Header, error) { return client.HeaderByNumber(ctx, number) })
+}
+
+func (c *failoverClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.NetworkID(ctx) })
+}
+
+func (c *failoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return route(c, func(client eth.Client) (uint64, error) { return client.NonceAt(ctx, account, blockNumber) })
+}
+
+func (c *failoverClient) PeerCount(ctx context.Context) (uint64, error) {
+	return route(c, func(client eth.Client) (uint64, error) { return client.PeerCount(ctx) })
+}
+
+func (c *failoverClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.PendingBalanceAt(ctx, account) })
+}
+
+func (c *failoverClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.PendingCallContract(ctx, msg) })
+}
+
+func (c *failoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.PendingCodeAt(ctx, account) })
+}
+
+func (c *failoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return route(c, func(client eth.Client) (uint64, error) { return client.PendingNonceAt(ctx, account) })
+}
+
+func (c *failoverClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.PendingStorageAt(ctx, account, key) })
+}
+
+func (c *failoverClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	return route(c, func(client eth.Client) (uint, error) { return client.PendingTransactionCount(ctx) })
+}
+
+func (c *failoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := route(c, func(client eth.Client) (struct{}, error) { return struct{}{}, client.SendTransaction(ctx, tx) })
+	return err
+}
+
+func (c *failoverClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return route(c, func(client eth.Client) ([]byte, error) { return client.StorageAt(ctx, account, key, blockNumber) })
+}
+
+func (c *failoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return route(c, func(client eth.Client) (ethereum.Subscription, error) { return client.SubscribeFilterLogs(ctx, q, ch) })
+}
+
+func (c *failoverClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return route(c, func(client eth.Client) (ethereum.Subscription, error) { return client.SubscribeNewHead(ctx, ch) })
+}
+
+func (c *failoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.SuggestGasPrice(ctx) })
+}
+
+func (c *failoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return route(c, func(client eth.Client) (*big.Int, error) { return client.SuggestGasTipCap(ctx) })
+}
+
+func (c *failoverClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return route(c, func(client eth.Client) (*ethereum.SyncProgress, error) { return client.SyncProgress(ctx) })
+}
+
+func (c *failoverClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+
+	r, err := route(c, func(client eth.Client) (result, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+
+	return r.tx, r.isPending, err
+}
+
+func (c *failoverClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return route(c, func(client eth.Client) (uint, error) { return client.TransactionCount(ctx, blockHash) })
+}
+
+func (c *failoverClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return route(c, func(client eth.Client) (*types.Transaction, error) {
+		return client.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+func (c *failoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return route(c, func(client eth.Client) (*types.Receipt, error) { return client.TransactionReceipt(ctx, txHash) })
+}
+
+func (c *failoverClient) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	return route(c, func(client eth.Client) (common.Address, error) {
+		return client.TransactionSender(ctx, tx, block, index)
+	})
+}
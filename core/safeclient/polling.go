@@ -0,0 +1,144 @@
+package safeclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	POLL_INTERVAL = 2 * time.Second
+	// Max headers fetched per poll. After a long outage, headers are caught up
+	// over consecutive polls without waiting for the interval.
+	POLL_MAX_HEADERS = 100
+)
+
+// Whether a subscription should be replaced by polling, which is the case for
+// HTTP endpoints
+func isSubscriptionUnsupported(err error) bool {
+	return errors.Is(err, rpc.ErrNotificationsUnsupported)
+}
+
+// Polls new heads through eth_blockNumber and eth_getBlockByNumber, always
+// continuing from the last delivered header. Failed polls are retried on the
+// next tick, so only unsubscribing ends it.
+func pollNewHeads(ctx context.Context, client eth.Client, ch chan<- *types.Header, interval time.Duration, logger logging.Logger) (ethereum.Subscription, error) {
+	lastBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		caughtUp := true
+		for {
+			if caughtUp {
+				select {
+				case <-quit:
+					return nil
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+
+			currentBlock, err := client.BlockNumber(ctx)
+			if err != nil {
+				logger.Warn("Failed to poll block number", "err", err)
+				caughtUp = true
+				continue
+			}
+
+			toBlock := currentBlock
+			if currentBlock > lastBlock+POLL_MAX_HEADERS {
+				logger.Info("Catching up polled headers", "lastBlock", lastBlock, "currentBlock", currentBlock)
+				toBlock = lastBlock + POLL_MAX_HEADERS
+			}
+
+			caughtUp = true
+			for blockNumber := lastBlock + 1; blockNumber <= toBlock; blockNumber++ {
+				header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+				if err != nil {
+					logger.Warn("Failed to poll header", "blockNumber", blockNumber, "err", err)
+					caughtUp = true
+					break
+				}
+
+				select {
+				case ch <- header:
+				case <-quit:
+					return nil
+				}
+
+				lastBlock = blockNumber
+				caughtUp = lastBlock >= currentBlock
+			}
+		}
+	}), nil
+}
+
+// Polls logs through eth_blockNumber and eth_getLogs, in ranges of up to
+// chunkSize blocks. Failed polls are retried on the next tick from the last
+// polled block, so only unsubscribing ends it.
+func pollFilterLogs(ctx context.Context, client eth.Client, q ethereum.FilterQuery, ch chan<- types.Log, interval time.Duration, chunkSize uint64, logger logging.Logger) (ethereum.Subscription, error) {
+	lastBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			currentBlock, err := client.BlockNumber(ctx)
+			if err != nil {
+				logger.Warn("Failed to poll block number", "err", err)
+				continue
+			}
+
+			for fromBlock := lastBlock + 1; fromBlock <= currentBlock; fromBlock += chunkSize {
+				toBlock := min(fromBlock+chunkSize-1, currentBlock)
+
+				logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+					FromBlock: new(big.Int).SetUint64(fromBlock),
+					ToBlock:   new(big.Int).SetUint64(toBlock),
+					Addresses: q.Addresses,
+					Topics:    q.Topics,
+				})
+				if err != nil {
+					logger.Warn("Failed to poll logs", "fromBlock", fromBlock, "toBlock", toBlock, "err", err)
+					break
+				}
+
+				for _, log := range logs {
+					select {
+					case ch <- log:
+					case <-quit:
+						return nil
+					}
+				}
+
+				lastBlock = toBlock
+			}
+		}
+	}), nil
+}
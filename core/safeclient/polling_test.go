@@ -0,0 +1,158 @@
+package safeclient
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
+)
+
+// Mocks a chain whose head advances by one block on every block number call
+func expectAdvancingBlockNumber(mockClient *mocks.MockClient, startBlock uint64) {
+	var blockNumber atomic.Uint64
+	blockNumber.Store(startBlock)
+
+	mockClient.EXPECT().BlockNumber(gomock.Any()).DoAndReturn(func(ctx context.Context) (uint64, error) {
+		return blockNumber.Add(1) - 1, nil
+	}).AnyTimes()
+}
+
+func TestPollNewHeads(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	expectAdvancingBlockNumber(mockClient, 10)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		return &types.Header{Number: number}, nil
+	}).AnyTimes()
+
+	headersC := make(chan *types.Header)
+	sub, err := pollNewHeads(context.Background(), mockClient, headersC, time.Millisecond, logging.NewNoopLogger())
+	assert.NoError(t, err)
+
+	for _, expected := range []uint64{11, 12, 13} {
+		select {
+		case header := <-headersC:
+			assert.Equal(t, expected, header.Number.Uint64())
+		case <-time.After(time.Second):
+			t.Fatal("header timed out")
+		}
+	}
+
+	sub.Unsubscribe()
+
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestPollFilterLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	address := common.Address{1}
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	expectAdvancingBlockNumber(mockClient, 10)
+	mockClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+		assert.Equal(t, []common.Address{address}, q.Addresses)
+
+		logs := make([]types.Log, 0)
+		for blockNumber := q.FromBlock.Uint64(); blockNumber <= q.ToBlock.Uint64(); blockNumber++ {
+			logs = append(logs, types.Log{Address: address, BlockNumber: blockNumber})
+		}
+
+		return logs, nil
+	}).AnyTimes()
+
+	logsC := make(chan types.Log)
+	sub, err := pollFilterLogs(context.Background(), mockClient, ethereum.FilterQuery{Addresses: []common.Address{address}}, logsC, time.Millisecond, BLOCK_CHUNK_SIZE, logging.NewNoopLogger())
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range []uint64{11, 12, 13} {
+		select {
+		case log := <-logsC:
+			assert.Equal(t, expected, log.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatal("log timed out")
+		}
+	}
+}
+
+func TestSubscribeNewHeadPollingFallback(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).Return(nil, rpc.ErrNotificationsUnsupported).AnyTimes()
+	expectAdvancingBlockNumber(mockClient, 10)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		return &types.Header{Number: number}, nil
+	}).AnyTimes()
+
+	client, err := NewSafeEthClient("http://localhost:8545", logging.NewNoopLogger(),
+		WithPollInterval(time.Millisecond),
+		WithCustomCreateClient(func(string, logging.Logger) (eth.Client, error) { return mockClient, nil }),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	headersC := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(context.Background(), headersC)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	select {
+	case header := <-headersC:
+		assert.Equal(t, uint64(11), header.Number.Uint64())
+	case <-time.After(time.Second):
+		t.Fatal("header timed out")
+	}
+}
+
+func TestPollNewHeadsCatchesUp(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var blockNumber atomic.Uint64
+	blockNumber.Store(10)
+
+	mockClient := mocks.NewMockClient(mockCtrl)
+	mockClient.EXPECT().BlockNumber(gomock.Any()).DoAndReturn(func(ctx context.Context) (uint64, error) {
+		return blockNumber.Load(), nil
+	}).AnyTimes()
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		return &types.Header{Number: number}, nil
+	}).AnyTimes()
+
+	headersC := make(chan *types.Header)
+	sub, err := pollNewHeads(context.Background(), mockClient, headersC, time.Millisecond, logging.NewNoopLogger())
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// The head jumps beyond the poll max, and no header is skipped
+	lastBlock := uint64(10 + 2*POLL_MAX_HEADERS + 5)
+	blockNumber.Store(lastBlock)
+
+	for expected := uint64(11); expected <= lastBlock; expected++ {
+		select {
+		case header := <-headersC:
+			assert.Equal(t, expected, header.Number.Uint64())
+		case <-time.After(time.Second):
+			t.Fatalf("header %d timed out", expected)
+		}
+	}
+}
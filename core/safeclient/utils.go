@@ -38,5 +38,10 @@ func hashLog(log *types.Log) [32]byte {
 	h.Write(log.TxHash.Bytes())
 	h.Write(new(big.Int).SetUint64(uint64(log.Index)).Bytes())
 
+	// Removal notifications must not be deduplicated against the log itself
+	if log.Removed {
+		h.Write([]byte{1})
+	}
+
 	return [32]byte(h.Sum(nil))
 }
@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+var (
+	NonConflictingDigestsError    = errors.New("Evidence digests do not conflict")
+	InvalidEvidenceSignatureError = errors.New("Evidence signature verification failed")
+	EvidenceMessageMismatchError  = errors.New("Evidence messages do not match their key and digests")
+	UnknownMessageTypeError       = errors.New("Unknown message type")
+)
+
+// Message signed by operators, of one of the message types
+type Message interface {
+	Digest() (coretypes.MessageDigest, error)
+	Key() coretypes.MessageKey
+}
+
+// Decodes a JSON-encoded message of a message type
+func DecodeMessage(messageType MessageType, data []byte) (Message, error) {
+	var message Message
+	var err error
+
+	switch messageType {
+	case CheckpointTaskResponseMessageType:
+		var taskResponse CheckpointTaskResponse
+		err = json.Unmarshal(data, &taskResponse)
+		message = taskResponse
+	case StateRootUpdateMessageType:
+		var stateRootUpdate StateRootUpdateMessage
+		err = json.Unmarshal(data, &stateRootUpdate)
+		message = stateRootUpdate
+	case OperatorSetUpdateMessageType:
+		var operatorSetUpdate OperatorSetUpdateMessage
+		err = json.Unmarshal(data, &operatorSetUpdate)
+		message = operatorSetUpdate
+	default:
+		return nil, fmt.Errorf("%w: %s", UnknownMessageTypeError, messageType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Proof that an operator signed two different messages with the same key,
+// which can back freezing or slashing it. Both messages are kept
+// JSON-encoded, so that the digests can be recomputed from them.
+type EquivocationEvidence struct {
+	MessageType     MessageType
+	MessageKey      coretypes.MessageKey
+	OperatorId      eigentypes.OperatorId
+	EthBlockNumber  coretypes.BlockNumber
+	FirstMessage    json.RawMessage
+	FirstDigest     coretypes.MessageDigest
+	FirstSignature  *bls.Signature
+	SecondMessage   json.RawMessage
+	SecondDigest    coretypes.MessageDigest
+	SecondSignature *bls.Signature
+}
+
+// Checks that both messages have the evidence key and digests, that the
+// digests differ, and that both signatures are valid for the operator's pubkey
+func (evidence EquivocationEvidence) Verify(operatorG2Pubkey *bls.G2Point) error {
+	if evidence.FirstDigest == evidence.SecondDigest {
+		return NonConflictingDigestsError
+	}
+
+	for _, signed := range []struct {
+		message   json.RawMessage
+		digest    coretypes.MessageDigest
+		signature *bls.Signature
+	}{
+		{evidence.FirstMessage, evidence.FirstDigest, evidence.FirstSignature},
+		{evidence.SecondMessage, evidence.SecondDigest, evidence.SecondSignature},
+	} {
+		message, err := DecodeMessage(evidence.MessageType, signed.message)
+		if err != nil {
+			return err
+		}
+
+		digest, err := message.Digest()
+		if err != nil || digest != signed.digest || message.Key() != evidence.MessageKey {
+			return EvidenceMessageMismatchError
+		}
+
+		if signed.signature == nil {
+			return InvalidEvidenceSignatureError
+		}
+
+		ok, err := signed.signature.Verify(operatorG2Pubkey, signed.digest)
+		if err != nil || !ok {
+			return InvalidEvidenceSignatureError
+		}
+	}
+
+	return nil
+}
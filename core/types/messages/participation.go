@@ -0,0 +1,26 @@
+package messages
+
+import (
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+type MessageType string
+
+const (
+	CheckpointTaskResponseMessageType MessageType = "checkpoint_task_response"
+	StateRootUpdateMessageType        MessageType = "state_root_update"
+	OperatorSetUpdateMessageType      MessageType = "operator_set_update"
+)
+
+// Whether an operator signed a finished message. RollupId is only set for
+// state root updates.
+type OperatorParticipation struct {
+	MessageKey     coretypes.MessageKey
+	MessageType    MessageType
+	RollupId       uint32
+	OperatorId     eigentypes.OperatorId
+	EthBlockNumber coretypes.BlockNumber
+	Signed         bool
+}
@@ -0,0 +1,184 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// Blocks with transactions and, optionally, receipts. Legacy payloads
+	// have no version byte and are an RLP list of header-only blocks.
+	ROLLUP_BLOCKS_FORMAT_V1 byte = 0x01
+	// Compressed payloads, wrapping a legacy or V1 payload
+	ROLLUP_BLOCKS_FORMAT_ZSTD   byte = 0x02
+	ROLLUP_BLOCKS_FORMAT_BROTLI byte = 0x03
+
+	ROLLUP_BLOCKS_COMPRESSION_NONE   = ""
+	ROLLUP_BLOCKS_COMPRESSION_ZSTD   = "zstd"
+	ROLLUP_BLOCKS_COMPRESSION_BROTLI = "brotli"
+
+	// Limits decompressed payloads, so that small blobs can't expand
+	// unboundedly
+	MAX_DECOMPRESSED_ROLLUP_BLOCKS_SIZE = 64 * 1024 * 1024
+
+	// RLP lists always start with a byte of at least this value, which no
+	// version byte reaches
+	rlpListPrefix byte = 0xc0
+)
+
+var (
+	EmptyRollupBlocksError         = errors.New("Empty rollup blocks payload")
+	UnknownRollupBlocksFormatError = errors.New("Unknown rollup blocks format")
+	UnknownCompressionError        = errors.New("Unknown rollup blocks compression")
+	DecompressedSizeExceededError  = errors.New("Decompressed rollup blocks payload too large")
+)
+
+// Rollup block as submitted to NEAR DA
+type RollupBlock struct {
+	Header       *ethtypes.Header
+	Transactions []*ethtypes.Transaction
+	Receipts     []*ethtypes.Receipt `rlp:"optional"`
+}
+
+func NewRollupBlock(block *ethtypes.Block, receipts []*ethtypes.Receipt) RollupBlock {
+	return RollupBlock{
+		Header:       block.Header(),
+		Transactions: block.Transactions(),
+		Receipts:     receipts,
+	}
+}
+
+func (b *RollupBlock) Block() *ethtypes.Block {
+	return ethtypes.NewBlockWithHeader(b.Header).WithBody(b.Transactions, nil)
+}
+
+// Encodes blocks in the legacy format, which only keeps the headers
+func EncodeLegacyRollupBlocks(blocks []RollupBlock) ([]byte, error) {
+	legacyBlocks := make([]*ethtypes.Block, len(blocks))
+	for i, block := range blocks {
+		legacyBlocks[i] = ethtypes.NewBlockWithHeader(block.Header)
+	}
+
+	return rlp.EncodeToBytes(legacyBlocks)
+}
+
+func EncodeRollupBlocks(blocks []RollupBlock) ([]byte, error) {
+	data, err := rlp.EncodeToBytes(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{ROLLUP_BLOCKS_FORMAT_V1}, data...), nil
+}
+
+// Compresses an encoded payload, prefixing it with the compression format
+// byte. Without compression, the payload is returned as is.
+func CompressRollupBlocks(data []byte, compression string) ([]byte, error) {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+
+	switch compression {
+	case ROLLUP_BLOCKS_COMPRESSION_NONE:
+		return data, nil
+	case ROLLUP_BLOCKS_COMPRESSION_ZSTD:
+		buf.WriteByte(ROLLUP_BLOCKS_FORMAT_ZSTD)
+
+		zstdWriter, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+
+		writer = zstdWriter
+	case ROLLUP_BLOCKS_COMPRESSION_BROTLI:
+		buf.WriteByte(ROLLUP_BLOCKS_FORMAT_BROTLI)
+		writer = brotli.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("%w: %s", UnknownCompressionError, compression)
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decodes blocks in either the legacy or a versioned format, which may be
+// compressed
+func DecodeRollupBlocks(data []byte) ([]RollupBlock, error) {
+	if len(data) == 0 {
+		return nil, EmptyRollupBlocksError
+	}
+
+	switch data[0] {
+	case ROLLUP_BLOCKS_FORMAT_ZSTD:
+		reader, err := zstd.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return decodeCompressedRollupBlocks(reader)
+	case ROLLUP_BLOCKS_FORMAT_BROTLI:
+		return decodeCompressedRollupBlocks(brotli.NewReader(bytes.NewReader(data[1:])))
+	default:
+		return decodeUncompressedRollupBlocks(data)
+	}
+}
+
+func decodeCompressedRollupBlocks(reader io.Reader) ([]RollupBlock, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, MAX_DECOMPRESSED_ROLLUP_BLOCKS_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MAX_DECOMPRESSED_ROLLUP_BLOCKS_SIZE {
+		return nil, DecompressedSizeExceededError
+	}
+
+	if len(data) == 0 {
+		return nil, EmptyRollupBlocksError
+	}
+
+	return decodeUncompressedRollupBlocks(data)
+}
+
+func decodeUncompressedRollupBlocks(data []byte) ([]RollupBlock, error) {
+	if data[0] >= rlpListPrefix {
+		var legacyBlocks []*ethtypes.Block
+		if err := rlp.DecodeBytes(data, &legacyBlocks); err != nil {
+			return nil, err
+		}
+
+		blocks := make([]RollupBlock, len(legacyBlocks))
+		for i, block := range legacyBlocks {
+			blocks[i] = NewRollupBlock(block, nil)
+		}
+
+		return blocks, nil
+	}
+
+	switch data[0] {
+	case ROLLUP_BLOCKS_FORMAT_V1:
+		var blocks []RollupBlock
+		if err := rlp.DecodeBytes(data[1:], &blocks); err != nil {
+			return nil, err
+		}
+
+		return blocks, nil
+	default:
+		return nil, UnknownRollupBlocksFormatError
+	}
+}
@@ -0,0 +1,131 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+func createTestBlock() (*ethtypes.Block, []*ethtypes.Receipt) {
+	tx := ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       &common.Address{1},
+		Value:    big.NewInt(1),
+	})
+
+	receipts := []*ethtypes.Receipt{{
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs:              []*ethtypes.Log{},
+	}}
+
+	block := ethtypes.NewBlockWithHeader(&ethtypes.Header{Number: big.NewInt(10)}).WithBody([]*ethtypes.Transaction{tx}, nil)
+
+	return block, receipts
+}
+
+func TestRollupBlocksRoundTrip(t *testing.T) {
+	block, receipts := createTestBlock()
+
+	data, err := coretypes.EncodeRollupBlocks([]coretypes.RollupBlock{coretypes.NewRollupBlock(block, receipts)})
+	assert.NoError(t, err)
+	assert.Equal(t, coretypes.ROLLUP_BLOCKS_FORMAT_V1, data[0])
+
+	blocks, err := coretypes.DecodeRollupBlocks(data)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+	assert.Equal(t, block.Hash(), blocks[0].Header.Hash())
+	assert.Equal(t, block.Transactions()[0].Hash(), blocks[0].Transactions[0].Hash())
+	assert.Equal(t, receipts[0].CumulativeGasUsed, blocks[0].Receipts[0].CumulativeGasUsed)
+	assert.Equal(t, block.Hash(), blocks[0].Block().Hash())
+}
+
+func TestRollupBlocksWithoutReceipts(t *testing.T) {
+	block, _ := createTestBlock()
+
+	data, err := coretypes.EncodeRollupBlocks([]coretypes.RollupBlock{coretypes.NewRollupBlock(block, nil)})
+	assert.NoError(t, err)
+
+	blocks, err := coretypes.DecodeRollupBlocks(data)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+	assert.Empty(t, blocks[0].Receipts)
+}
+
+func TestDecodeLegacyRollupBlocks(t *testing.T) {
+	block, _ := createTestBlock()
+
+	data, err := rlp.EncodeToBytes([]*ethtypes.Block{ethtypes.NewBlockWithHeader(block.Header())})
+	assert.NoError(t, err)
+
+	blocks, err := coretypes.DecodeRollupBlocks(data)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+	assert.Equal(t, block.Hash(), blocks[0].Header.Hash())
+	assert.Empty(t, blocks[0].Transactions)
+
+	legacyData, err := coretypes.EncodeLegacyRollupBlocks(blocks)
+	assert.NoError(t, err)
+	assert.Equal(t, data, legacyData)
+}
+
+func TestDecodeRollupBlocksUnknownFormat(t *testing.T) {
+	_, err := coretypes.DecodeRollupBlocks(nil)
+	assert.ErrorIs(t, err, coretypes.EmptyRollupBlocksError)
+
+	_, err = coretypes.DecodeRollupBlocks([]byte{0x7f})
+	assert.ErrorIs(t, err, coretypes.UnknownRollupBlocksFormatError)
+}
+
+func TestCompressedRollupBlocksRoundTrip(t *testing.T) {
+	block, receipts := createTestBlock()
+
+	data, err := coretypes.EncodeRollupBlocks([]coretypes.RollupBlock{coretypes.NewRollupBlock(block, receipts)})
+	assert.NoError(t, err)
+
+	for compression, format := range map[string]byte{
+		coretypes.ROLLUP_BLOCKS_COMPRESSION_ZSTD:   coretypes.ROLLUP_BLOCKS_FORMAT_ZSTD,
+		coretypes.ROLLUP_BLOCKS_COMPRESSION_BROTLI: coretypes.ROLLUP_BLOCKS_FORMAT_BROTLI,
+	} {
+		compressed, err := coretypes.CompressRollupBlocks(data, compression)
+		assert.NoError(t, err)
+		assert.Equal(t, format, compressed[0])
+
+		blocks, err := coretypes.DecodeRollupBlocks(compressed)
+		assert.NoError(t, err)
+		assert.Len(t, blocks, 1)
+		assert.Equal(t, block.Hash(), blocks[0].Header.Hash())
+		assert.Equal(t, block.Transactions()[0].Hash(), blocks[0].Transactions[0].Hash())
+	}
+
+	uncompressed, err := coretypes.CompressRollupBlocks(data, coretypes.ROLLUP_BLOCKS_COMPRESSION_NONE)
+	assert.NoError(t, err)
+	assert.Equal(t, data, uncompressed)
+
+	_, err = coretypes.CompressRollupBlocks(data, "gzip")
+	assert.ErrorIs(t, err, coretypes.UnknownCompressionError)
+}
+
+func TestDecodeCompressedRollupBlocksRejectsNesting(t *testing.T) {
+	block, _ := createTestBlock()
+
+	data, err := coretypes.EncodeRollupBlocks([]coretypes.RollupBlock{coretypes.NewRollupBlock(block, nil)})
+	assert.NoError(t, err)
+
+	compressed, err := coretypes.CompressRollupBlocks(data, coretypes.ROLLUP_BLOCKS_COMPRESSION_ZSTD)
+	assert.NoError(t, err)
+
+	nested, err := coretypes.CompressRollupBlocks(compressed, coretypes.ROLLUP_BLOCKS_COMPRESSION_BROTLI)
+	assert.NoError(t, err)
+
+	_, err = coretypes.DecodeRollupBlocks(nested)
+	assert.ErrorIs(t, err, coretypes.UnknownRollupBlocksFormatError)
+}
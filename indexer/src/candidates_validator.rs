@@ -124,6 +124,7 @@ impl CandidatesValidator {
                     },
                     cx: PublisherContext {
                         block_hash: candidate_data.transaction.outcome.execution_outcome.block_hash,
+                        rollup_id: candidate_data.rollup_id,
                     },
                     payload: PublishPayload {
                         transaction_id: candidate_data.transaction.transaction.hash,
@@ -53,6 +53,9 @@ pub(crate) struct RunConfigArgs {
     /// Metrics socket addr
     #[clap(long)]
     pub metrics_ip_port_address: Option<SocketAddr>,
+    /// Socket addr of the submissions long-poll server, disabled if not set
+    #[clap(long)]
+    pub submissions_ip_port_address: Option<SocketAddr>,
     /// Address of fastnear block producer.
     #[clap(long, default_value = "https://testnet.neardata.xyz/v0/last_block/final")]
     pub fastnear_address: String,    
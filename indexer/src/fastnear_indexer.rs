@@ -175,6 +175,7 @@ impl FastNearIndexer {
                 },
                 cx: PublisherContext {
                     block_hash: candidate_data.block_hash,
+                    rollup_id: candidate_data.rollup_id,
                 },
                 payload: PublishPayload {
                     transaction_id: candidate_data.tx_hash,
@@ -6,6 +6,7 @@ use tracing::{error, info};
 use crate::{
     candidates_validator::CandidatesValidator, configs::RunConfigArgs, errors::Error, errors::Result,
     indexer_wrapper::IndexerWrapper, metrics::Metricable, metrics_server::MetricsServer, rmq_publisher::RmqPublisher,
+    submissions_server::{SubmissionsServer, SubmissionsStore},
 };
 use crate::fastnear_indexer::FastNearIndexer;
 use crate::metrics::INDEXER_NAMESPACE;
@@ -18,6 +19,7 @@ mod indexer_wrapper;
 mod metrics;
 mod metrics_server;
 mod rmq_publisher;
+mod submissions_server;
 mod types;
 mod fastnear_indexer;
 
@@ -37,6 +39,18 @@ fn run(home_dir: std::path::PathBuf, config: RunConfigArgs) -> Result<()> {
         None
     };
 
+    let submissions_store = config.submissions_ip_port_address.map(|_| SubmissionsStore::new());
+    let submissions_server_handle = if let (Some(submissions_addr), Some(store)) =
+        (config.submissions_ip_port_address, submissions_store.clone())
+    {
+        let submissions_server = SubmissionsServer::new(submissions_addr, store);
+        info!(target: INDEXER, "Submissions server has started: {}", submissions_addr);
+        Some(system.runtime().spawn(submissions_server.run()))
+    } else {
+        info!(target: INDEXER, "Submissions server has been disabled");
+        None
+    };
+
     let block_res = system.block_on(async move {
         let mut rmq_publisher = RmqPublisher::new(&config.rmq_address)?;
         if config.metrics_ip_port_address.is_some() {
@@ -45,7 +59,10 @@ fn run(home_dir: std::path::PathBuf, config: RunConfigArgs) -> Result<()> {
 
         if cfg!(feature = "use_fastnear") {
             let fastnear_indexer = FastNearIndexer::new(fastnear_addr, addr_to_rollup_ids, config.channel_width);
-            let validated_stream = fastnear_indexer.run();
+            let mut validated_stream = fastnear_indexer.run();
+            if let Some(store) = &submissions_store {
+                validated_stream = store.record(validated_stream);
+            }
 
             rmq_publisher.run(validated_stream);
 
@@ -70,7 +87,10 @@ fn run(home_dir: std::path::PathBuf, config: RunConfigArgs) -> Result<()> {
                 candidates_validator.enable_metrics(registry.clone())?;
             }
 
-            let validated_stream = candidates_validator.run(candidates_stream);
+            let mut validated_stream = candidates_validator.run(candidates_stream);
+            if let Some(store) = &submissions_store {
+                validated_stream = store.record(validated_stream);
+            }
 
             rmq_publisher.run(validated_stream);
 
@@ -85,6 +105,10 @@ fn run(home_dir: std::path::PathBuf, config: RunConfigArgs) -> Result<()> {
     // Run until publishing finished
     system.run()?;
 
+    if let Some(handle) = submissions_server_handle {
+        handle.abort();
+    }
+
     block_res.map_err(|err| {
         error!(target: INDEXER, "Indexer Error: {}", err);
         err
@@ -53,6 +53,7 @@ impl Default for PublishOptions {
 #[derive(Clone, Debug)]
 pub struct PublisherContext {
     pub block_hash: CryptoHash,
+    pub rollup_id: u32,
 }
 
 #[derive(Clone, Debug, BorshDeserialize, BorshSerialize)]
@@ -0,0 +1,274 @@
+use actix_web::{web, App, HttpResponse, HttpServer};
+use base64::{engine::general_purpose::STANDARD as BASE64, Engine};
+use borsh::BorshSerialize;
+use serde::{Deserialize, Serialize, Serializer};
+use std::{
+    collections::{HashMap, VecDeque},
+    net::SocketAddr,
+    sync::{Arc, Mutex},
+    time::{Duration, SystemTime, UNIX_EPOCH},
+};
+use tokio::sync::{mpsc, Notify};
+use tracing::{info, warn};
+
+use crate::{errors::Result, rmq_publisher::PublishData};
+
+const SUBMISSIONS: &str = "submissions";
+// Submissions kept in memory per rollup for pollers to catch up
+const SUBMISSIONS_CAPACITY: usize = 1024;
+const DEFAULT_POLL_TIMEOUT: Duration = Duration::from_secs(30);
+const MAX_POLL_TIMEOUT: Duration = Duration::from_secs(60);
+const CHANNEL_SIZE: usize = 1000;
+
+fn serialize_base64<S: Serializer>(data: &[u8], serializer: S) -> Result<S::Ok, S::Error> {
+    serializer.serialize_str(&BASE64.encode(data))
+}
+
+// Matches consumer.HttpSubmission on the operator side, where payload is a
+// []byte and so is encoded as base64
+#[derive(Clone, Debug, Serialize)]
+pub struct Submission {
+    pub seq: u64,
+    #[serde(serialize_with = "serialize_base64")]
+    pub payload: Vec<u8>,
+}
+
+#[derive(Debug, Serialize)]
+pub struct SubmissionsResponse {
+    pub submissions: Vec<Submission>,
+}
+
+#[derive(Debug, Deserialize)]
+pub struct SubmissionsQuery {
+    pub after: Option<u64>,
+    pub timeout_ms: Option<u64>,
+}
+
+struct RollupSubmissions {
+    next_seq: u64,
+    submissions: VecDeque<Submission>,
+}
+
+/// In-memory log of the latest published submissions per rollup, served to
+/// operators long-polling `/v1/rollups/{id}/submissions`.
+///
+/// Sequence numbers start at the startup time in microseconds, so they keep
+/// increasing across restarts and pollers don't get stuck on a stale cursor.
+#[derive(Clone)]
+pub struct SubmissionsStore {
+    rollups: Arc<Mutex<HashMap<u32, RollupSubmissions>>>,
+    notify: Arc<Notify>,
+    initial_seq: u64,
+}
+
+impl SubmissionsStore {
+    pub fn new() -> Self {
+        let initial_seq = SystemTime::now()
+            .duration_since(UNIX_EPOCH)
+            .map(|d| d.as_micros() as u64)
+            .unwrap_or(1);
+
+        Self {
+            rollups: Arc::new(Mutex::new(HashMap::new())),
+            notify: Arc::new(Notify::new()),
+            initial_seq,
+        }
+    }
+
+    pub fn push(&self, rollup_id: u32, payload: Vec<u8>) -> u64 {
+        let seq = {
+            let mut rollups = self.rollups.lock().unwrap();
+            let rollup = rollups.entry(rollup_id).or_insert_with(|| RollupSubmissions {
+                next_seq: self.initial_seq,
+                submissions: VecDeque::with_capacity(SUBMISSIONS_CAPACITY),
+            });
+
+            let seq = rollup.next_seq;
+            rollup.next_seq += 1;
+
+            if rollup.submissions.len() == SUBMISSIONS_CAPACITY {
+                rollup.submissions.pop_front();
+            }
+            rollup.submissions.push_back(Submission { seq, payload });
+
+            seq
+        };
+
+        self.notify.notify_waiters();
+        seq
+    }
+
+    fn last_seq(&self, rollup_id: u32) -> u64 {
+        let rollups = self.rollups.lock().unwrap();
+        rollups
+            .get(&rollup_id)
+            .map(|rollup| rollup.next_seq - 1)
+            .unwrap_or(self.initial_seq - 1)
+    }
+
+    fn get_after(&self, rollup_id: u32, after: u64) -> Vec<Submission> {
+        let rollups = self.rollups.lock().unwrap();
+        rollups
+            .get(&rollup_id)
+            .map(|rollup| {
+                rollup
+                    .submissions
+                    .iter()
+                    .filter(|submission| submission.seq > after)
+                    .cloned()
+                    .collect()
+            })
+            .unwrap_or_default()
+    }
+
+    /// Returns the submissions after `after`, or after the latest one if not
+    /// set, waiting up to `timeout` for new ones. An empty list means the
+    /// timeout was reached.
+    pub async fn wait_after(&self, rollup_id: u32, after: Option<u64>, timeout: Duration) -> Vec<Submission> {
+        let after = after.unwrap_or_else(|| self.last_seq(rollup_id));
+        let deadline = tokio::time::Instant::now() + timeout;
+
+        loop {
+            // Registered before checking so pushes in between aren't missed
+            let mut notified = std::pin::pin!(self.notify.notified());
+            notified.as_mut().enable();
+
+            let submissions = self.get_after(rollup_id, after);
+            if !submissions.is_empty() {
+                return submissions;
+            }
+
+            if tokio::time::timeout_at(deadline, notified).await.is_err() {
+                return Vec::new();
+            }
+        }
+    }
+
+    /// Records every publish data from the stream and forwards it unchanged.
+    pub fn record(&self, mut receiver: mpsc::Receiver<PublishData>) -> mpsc::Receiver<PublishData> {
+        let (sender, forward_receiver) = mpsc::channel(CHANNEL_SIZE);
+        let store = self.clone();
+
+        actix::spawn(async move {
+            while let Some(publish_data) = receiver.recv().await {
+                let mut payload: Vec<u8> = Vec::new();
+                match publish_data.payload.serialize(&mut payload) {
+                    Ok(_) => {
+                        let seq = store.push(publish_data.cx.rollup_id, payload);
+                        info!(target: SUBMISSIONS, "recorded tx: {}, rollup_id: {}, seq: {}", publish_data.payload.transaction_id, publish_data.cx.rollup_id, seq);
+                    }
+                    Err(err) => warn!(target: SUBMISSIONS, "couldn't serialize publish payload {}", err.to_string()),
+                }
+
+                if sender.send(publish_data).await.is_err() {
+                    return;
+                }
+            }
+        });
+
+        forward_receiver
+    }
+}
+
+pub struct SubmissionsServer {
+    addr: SocketAddr,
+    store: SubmissionsStore,
+}
+
+impl SubmissionsServer {
+    pub fn new(addr: SocketAddr, store: SubmissionsStore) -> Self {
+        Self { addr, store }
+    }
+
+    async fn submissions(
+        store: web::Data<SubmissionsStore>,
+        rollup_id: web::Path<u32>,
+        query: web::Query<SubmissionsQuery>,
+    ) -> HttpResponse {
+        let timeout = query
+            .timeout_ms
+            .map(Duration::from_millis)
+            .unwrap_or(DEFAULT_POLL_TIMEOUT)
+            .min(MAX_POLL_TIMEOUT);
+
+        let submissions = store.wait_after(rollup_id.into_inner(), query.after, timeout).await;
+        HttpResponse::Ok().json(SubmissionsResponse { submissions })
+    }
+
+    pub async fn run(self) -> Result<()> {
+        let store_data = web::Data::new(self.store);
+        let server = HttpServer::new(move || {
+            App::new().app_data(store_data.clone()).service(
+                web::resource("/v1/rollups/{rollup_id}/submissions").route(web::get().to(Self::submissions)),
+            )
+        })
+        .bind(self.addr)?;
+
+        info!(target: SUBMISSIONS, "Submissions server listening on {}", self.addr);
+        server.run().await?;
+
+        Ok(())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[actix::test]
+    async fn test_wait_after_returns_new_submissions() {
+        let store = SubmissionsStore::new();
+        let first = store.push(1, vec![1]);
+        store.push(2, vec![2]);
+
+        let submissions = store.wait_after(1, Some(first - 1), Duration::from_millis(10)).await;
+        assert_eq!(submissions.len(), 1);
+        assert_eq!(submissions[0].seq, first);
+        assert_eq!(submissions[0].payload, vec![1]);
+
+        // Without a cursor only later submissions are returned
+        let waiter = {
+            let store = store.clone();
+            actix::spawn(async move { store.wait_after(1, None, Duration::from_secs(5)).await })
+        };
+        tokio::time::sleep(Duration::from_millis(10)).await;
+        let second = store.push(1, vec![3]);
+
+        let submissions = waiter.await.unwrap();
+        assert_eq!(submissions.len(), 1);
+        assert_eq!(submissions[0].seq, second);
+    }
+
+    #[actix::test]
+    async fn test_wait_after_times_out() {
+        let store = SubmissionsStore::new();
+        let seq = store.push(1, vec![1]);
+
+        let submissions = store.wait_after(1, Some(seq), Duration::from_millis(10)).await;
+        assert!(submissions.is_empty());
+    }
+
+    #[test]
+    fn test_store_is_bounded() {
+        let store = SubmissionsStore::new();
+        for i in 0..SUBMISSIONS_CAPACITY + 10 {
+            store.push(1, vec![i as u8]);
+        }
+
+        let submissions = store.get_after(1, 0);
+        assert_eq!(submissions.len(), SUBMISSIONS_CAPACITY);
+        assert_eq!(submissions[0].payload, vec![10]);
+    }
+
+    #[test]
+    fn test_payload_serialized_as_base64() {
+        let response = SubmissionsResponse {
+            submissions: vec![Submission { seq: 7, payload: vec![1, 2, 3] }],
+        };
+
+        assert_eq!(
+            serde_json::to_string(&response).unwrap(),
+            r#"{"submissions":[{"seq":7,"payload":"AQID"}]}"#
+        );
+    }
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
@@ -24,6 +27,12 @@ import (
 const (
 	MQ_WAIT_TIMEOUT        = 30 * time.Second
 	MQ_REBROADCAST_TIMEOUT = 15 * time.Second
+	BACKFILL_INTERVAL      = 100 * time.Millisecond
+	// Max heights backfilled per received header, larger gaps are backfilled
+	// over the next headers
+	BACKFILL_CHUNK_SIZE = 1000
+	// Attempts to fetch a height before leaving it for the next backfill
+	BACKFILL_ATTEMPTS = 3
 )
 
 var (
@@ -36,20 +45,33 @@ type Attestorer interface {
 	Start(ctx context.Context) error
 	Close() error
 	GetSignedRootC() <-chan messages.SignedStateRootUpdateMessage
+	GetStatus() Status
 }
 
 // Attestor subscribes for RPCs block updates
-// Also subscribes for MQ blocks from Consumer
+// Also subscribes for MQ blocks from the configured DaBlockSource
 // Each block from RPC waits for MQ_WAIT_TIMEOUT for MQ block
 // In case same block doesn't arrive from MQ block is signed and sent
 // If it arrives it is compared and then sent to Aggregator
+// Heights skipped by the RPC subscription are backfilled in chunks alongside
+// live headers, heights that can't be fetched are kept and retried on the
+// next header
+// If quorum RPCs are configured, a header is only signed once enough of
+// them agree on its state root
 type Attestor struct {
 	signedRootC        chan messages.SignedStateRootUpdateMessage
 	rollupIdsToUrls    map[uint32]string
 	clients            map[uint32]safeclient.SafeClient
+	quorumClients      map[uint32][]safeclient.SafeClient
+	quorumThresholds   map[uint32]uint32
 	rpcCallsCollectors map[uint32]*rpccalls.Collector
 	notifier           Notifier
-	consumer           *consumer.Consumer
+	blockSource        consumer.DaBlockSource
+	backfillInterval   time.Duration
+	quorumWaitTimeout  time.Duration
+
+	statusLock     sync.RWMutex
+	rollupStatuses map[uint32]RollupStatus
 
 	config     *optypes.NodeConfig
 	blsKeypair *bls.KeyPair
@@ -64,19 +86,38 @@ type Attestor struct {
 var _ core.Metricable = (*Attestor)(nil)
 
 func NewAttestor(config *optypes.NodeConfig, blsKeypair *bls.KeyPair, operatorId eigentypes.OperatorId, registry *prometheus.Registry, logger sdklogging.Logger) (*Attestor, error) {
-	consumer := consumer.NewConsumer(consumer.ConsumerConfig{
-		RollupIds: config.NearDaIndexerRollupIds,
-		Id:        hex.EncodeToString(operatorId[:]),
-	}, logger)
+	err := validateQuorumThresholds(config.RollupIdsToRpcQuorumThresholds, config.RollupIdsToQuorumRpcUrls)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobVerifier consumer.BlobVerifier
+	if config.NearDaVerifierRpcUrl != "" {
+		blobVerifier = consumer.NewNearDaVerifier(consumer.NearDaVerifierConfig{
+			RpcUrl:                  config.NearDaVerifierRpcUrl,
+			RollupIdsToContractIds:  config.NearDaRollupIdsToContractIds,
+			RollupIdsToSubmitterIds: config.NearDaRollupIdsToSubmitterIds,
+		}, logger)
+	}
+
+	blockSource, err := newDaBlockSource(config, operatorId, blobVerifier, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	attestor := Attestor{
 		signedRootC:        make(chan messages.SignedStateRootUpdateMessage),
 		rollupIdsToUrls:    make(map[uint32]string),
 		clients:            make(map[uint32]safeclient.SafeClient),
+		quorumClients:      make(map[uint32][]safeclient.SafeClient),
+		quorumThresholds:   make(map[uint32]uint32),
 		rpcCallsCollectors: make(map[uint32]*rpccalls.Collector),
 		logger:             logger,
 		notifier:           NewNotifier(),
-		consumer:           consumer,
+		blockSource:        blockSource,
+		backfillInterval:   BACKFILL_INTERVAL,
+		quorumWaitTimeout:  QUORUM_WAIT_TIMEOUT,
+		rollupStatuses:     make(map[uint32]RollupStatus),
 		blsKeypair:         blsKeypair,
 		operatorId:         operatorId,
 		registry:           registry,
@@ -84,7 +125,16 @@ func NewAttestor(config *optypes.NodeConfig, blsKeypair *bls.KeyPair, operatorId
 		config:             config,
 	}
 
-	for rollupId, url := range config.RollupIdsToRpcUrls {
+	var endpointListener safeclient.EndpointEventListener = &safeclient.SelectiveEndpointListener{}
+	if config.EnableMetrics && len(config.RollupIdsToFailoverRpcUrls) != 0 {
+		endpointListener, err = safeclient.MakeEndpointMetrics(registry, OperatorNamespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Creates a failover client if fallback URLs are given
+	createClient := func(rollupId uint32, url string, fallbackUrls ...string) (safeclient.SafeClient, *rpccalls.Collector, error) {
 		var rpcCallsCollector *rpccalls.Collector
 		if config.EnableMetrics {
 			id := config.OperatorAddress + AttestorSubsystem
@@ -96,7 +146,29 @@ func NewAttestor(config *optypes.NodeConfig, blsKeypair *bls.KeyPair, operatorId
 			clientOpts = append(clientOpts, safeclient.WithInstrumentedCreateClient(rpcCallsCollector))
 		}
 
-		client, err := safeclient.NewSafeEthClient(url, logger, clientOpts...)
+		if config.RollupIdsToAlwaysPoll[rollupId] {
+			clientOpts = append(clientOpts, safeclient.WithAlwaysPoll())
+		}
+		if pollIntervalMs, ok := config.RollupIdsToPollIntervalMs[rollupId]; ok && pollIntervalMs != 0 {
+			clientOpts = append(clientOpts, safeclient.WithPollInterval(time.Duration(pollIntervalMs)*time.Millisecond))
+		}
+		clientOpts = append(clientOpts, safeclient.WithEndpointEventListener(endpointListener))
+		client, err := safeclient.NewRollupSafeEthClient(rollupId, url, fallbackUrls, logger, clientOpts...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return client, rpcCallsCollector, nil
+	}
+
+	for rollupId := range config.RollupIdsToFailoverRpcUrls {
+		if _, exists := config.RollupIdsToRpcUrls[rollupId]; !exists {
+			return nil, fmt.Errorf("failover RPCs set for rollup %d without a subscription RPC", rollupId)
+		}
+	}
+
+	for rollupId, url := range config.RollupIdsToRpcUrls {
+		client, rpcCallsCollector, err := createClient(rollupId, url, config.RollupIdsToFailoverRpcUrls[rollupId]...)
 		if err != nil {
 			return nil, err
 		}
@@ -112,9 +184,63 @@ func NewAttestor(config *optypes.NodeConfig, blsKeypair *bls.KeyPair, operatorId
 		)
 	}
 
+	for rollupId, urls := range config.RollupIdsToQuorumRpcUrls {
+		if _, exists := attestor.clients[rollupId]; !exists {
+			return nil, fmt.Errorf("quorum RPCs set for rollup %d without a subscription RPC", rollupId)
+		}
+
+		for _, url := range urls {
+			client, _, err := createClient(rollupId, url)
+			if err != nil {
+				return nil, err
+			}
+
+			attestor.quorumClients[rollupId] = append(attestor.quorumClients[rollupId], client)
+		}
+
+		numRpcs := len(urls) + 1
+		threshold, exists := config.RollupIdsToRpcQuorumThresholds[rollupId]
+		if !exists {
+			threshold = defaultQuorumThreshold(numRpcs)
+		}
+
+		if threshold == 0 || threshold > uint32(numRpcs) {
+			return nil, fmt.Errorf("invalid RPC quorum threshold %d for rollup %d with %d RPCs", threshold, rollupId, numRpcs)
+		}
+
+		attestor.quorumThresholds[rollupId] = threshold
+
+		logger.Debug("RPC quorum for rollup",
+			"rollupId", rollupId,
+			"numRpcs", numRpcs,
+			"threshold", threshold,
+		)
+	}
+
 	return &attestor, nil
 }
 
+// Creates the NEAR DA block source selected in the config, defaulting to RMQ
+func newDaBlockSource(config *optypes.NodeConfig, operatorId eigentypes.OperatorId, blobVerifier consumer.BlobVerifier, logger sdklogging.Logger) (consumer.DaBlockSource, error) {
+	switch config.NearDaIndexerSource {
+	case "", consumer.RMQ_BLOCK_SOURCE:
+		return consumer.NewConsumer(consumer.ConsumerConfig{
+			Addr:         config.NearDaIndexerRmqIpPortAddress,
+			RollupIds:    config.NearDaIndexerRollupIds,
+			Id:           hex.EncodeToString(operatorId[:]),
+			BlobVerifier: blobVerifier,
+		}, logger), nil
+	case consumer.HTTP_BLOCK_SOURCE:
+		return consumer.NewHttpBlockSource(consumer.HttpBlockSourceConfig{
+			Url:          config.NearDaIndexerHttpUrl,
+			RollupIds:    config.NearDaIndexerRollupIds,
+			BlobVerifier: blobVerifier,
+		}, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown NEAR DA indexer source: %s", config.NearDaIndexerSource)
+	}
+}
+
 func (attestor *Attestor) EnableMetrics(registry *prometheus.Registry) error {
 	listener, err := MakeAttestorMetrics(registry)
 	if err != nil {
@@ -122,7 +248,7 @@ func (attestor *Attestor) EnableMetrics(registry *prometheus.Registry) error {
 	}
 	attestor.listener = listener
 
-	if err = attestor.consumer.EnableMetrics(registry); err != nil {
+	if err = attestor.blockSource.EnableMetrics(registry); err != nil {
 		return err
 	}
 
@@ -130,10 +256,13 @@ func (attestor *Attestor) EnableMetrics(registry *prometheus.Registry) error {
 }
 
 func (attestor *Attestor) Start(ctx context.Context) error {
-	go attestor.consumer.Start(ctx, attestor.config.NearDaIndexerRmqIpPortAddress)
+	if err := attestor.blockSource.Start(ctx); err != nil {
+		return err
+	}
 
 	subscriptions := make(map[uint32]ethereum.Subscription)
 	headersCs := make(map[uint32]chan *ethtypes.Header)
+	initialHeights := make(map[uint32]uint64)
 
 	for rollupId, client := range attestor.clients {
 		headersC := make(chan *ethtypes.Header, 100)
@@ -151,14 +280,17 @@ func (attestor *Attestor) Start(ctx context.Context) error {
 
 		attestor.listener.ObserveInitializationInitialBlockNumber(rollupId, blockNumber)
 
+		attestor.setRollupSubscribed(rollupId, true)
+
 		subscriptions[rollupId] = subscription
 		headersCs[rollupId] = headersC
+		initialHeights[rollupId] = blockNumber
 	}
 
 	go attestor.processMQBlocks(ctx)
 
 	for rollupId := range attestor.clients {
-		go attestor.processRollupHeaders(rollupId, headersCs[rollupId], subscriptions[rollupId], ctx)
+		go attestor.processRollupHeaders(rollupId, initialHeights[rollupId], headersCs[rollupId], subscriptions[rollupId], ctx)
 	}
 
 	return nil
@@ -166,7 +298,7 @@ func (attestor *Attestor) Start(ctx context.Context) error {
 
 // Receives MQ blocks and broadcasts them for a particular rollup
 func (attestor *Attestor) processMQBlocks(ctx context.Context) {
-	mqBlockC := attestor.consumer.GetBlockStream()
+	mqBlockC := attestor.blockSource.GetBlockStream()
 
 	for {
 		select {
@@ -199,12 +331,63 @@ func (attestor *Attestor) processMQBlocks(ctx context.Context) {
 	}
 }
 
+// Range of heights, both ends included
+type heightRange struct {
+	from uint64
+	to   uint64
+}
+
+// Gaps found by the header loop and not yet picked up by the rollup's
+// backfill routine
+type backfillQueue struct {
+	gaps    []heightRange
+	lock    sync.Mutex
+	notifyC chan struct{}
+}
+
+func newBackfillQueue() *backfillQueue {
+	return &backfillQueue{notifyC: make(chan struct{}, 1)}
+}
+
+func (q *backfillQueue) push(gap heightRange) {
+	q.lock.Lock()
+	q.gaps = append(q.gaps, gap)
+	q.lock.Unlock()
+}
+
+// Wakes the backfill routine up without blocking the header loop
+func (q *backfillQueue) notify() {
+	select {
+	case q.notifyC <- struct{}{}:
+	default:
+	}
+}
+
+func (q *backfillQueue) take() []heightRange {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	gaps := q.gaps
+	q.gaps = nil
+	return gaps
+}
+
 // Spawns routines for new headers that die in one minute
-func (attestor *Attestor) processRollupHeaders(rollupId uint32, headersC chan *ethtypes.Header, subscription ethereum.Subscription, ctx context.Context) {
+// Tracks the last processed height and queues any gap left by the
+// subscription to be backfilled by a separate routine, so live headers
+// aren't held back by it
+func (attestor *Attestor) processRollupHeaders(rollupId uint32, lastHeight uint64, headersC chan *ethtypes.Header, subscription ethereum.Subscription, ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := newBackfillQueue()
+	go attestor.backfillRollupHeaders(rollupId, queue, ctx)
+
 	for {
 		select {
 		case err := <-subscription.Err():
 			attestor.logger.Error("Header subscription error", "rollupId", rollupId, "err", err)
+			attestor.setRollupSubscribed(rollupId, false)
 			subscription.Unsubscribe()
 			close(headersC)
 			return
@@ -213,6 +396,17 @@ func (attestor *Attestor) processRollupHeaders(rollupId uint32, headersC chan *e
 				return
 			}
 
+			attestor.markRollupHeaderReceived(rollupId)
+
+			height := header.Number.Uint64()
+			if height > lastHeight+1 {
+				queue.push(heightRange{from: lastHeight + 1, to: height - 1})
+			}
+			lastHeight = max(lastHeight, height)
+
+			// Gaps left from a previous backfill are resumed on every header
+			queue.notify()
+
 			go attestor.processHeader(rollupId, header, ctx)
 		case <-ctx.Done():
 			subscription.Unsubscribe()
@@ -223,6 +417,89 @@ func (attestor *Attestor) processRollupHeaders(rollupId uint32, headersC chan *e
 	}
 }
 
+// Backfills the queued gaps of a rollup a chunk at a time, each time the
+// header loop notifies it
+func (attestor *Attestor) backfillRollupHeaders(rollupId uint32, queue *backfillQueue, ctx context.Context) {
+	var gaps []heightRange
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-queue.notifyC:
+		}
+
+		gaps = append(gaps, queue.take()...)
+		if len(gaps) != 0 {
+			gaps = attestor.backfillHeaders(rollupId, gaps, ctx)
+		}
+	}
+}
+
+// Fetches up to BACKFILL_CHUNK_SIZE of the gaps' headers in order at a bounded
+// rate and processes them as if they were received from the subscription.
+// Returns the gaps left, starting at the first height not yet fetched.
+func (attestor *Attestor) backfillHeaders(rollupId uint32, gaps []heightRange, ctx context.Context) []heightRange {
+	client := attestor.clients[rollupId]
+	ticker := time.NewTicker(attestor.backfillInterval)
+	defer ticker.Stop()
+
+	wait := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			return true
+		}
+	}
+
+	budget := BACKFILL_CHUNK_SIZE
+	for len(gaps) != 0 {
+		gap := &gaps[0]
+		attestor.logger.Info("Backfilling headers", "rollupId", rollupId, "fromHeight", gap.from, "toHeight", gap.to)
+
+		for gap.from <= gap.to {
+			if budget == 0 {
+				attestor.logger.Info("Backfill chunk done, continuing on next header", "rollupId", rollupId, "nextHeight", gap.from)
+				return gaps
+			}
+
+			var header *ethtypes.Header
+			var err error
+			for attempt := 0; attempt < BACKFILL_ATTEMPTS; attempt++ {
+				header, err = client.HeaderByNumber(ctx, new(big.Int).SetUint64(gap.from))
+				if err == nil {
+					break
+				}
+
+				attestor.logger.Warn("Failed to backfill header", "rollupId", rollupId, "height", gap.from, "attempt", attempt, "err", err)
+				if !wait() {
+					return gaps
+				}
+			}
+			if err != nil {
+				attestor.logger.Error("Giving up backfilling header until next header", "rollupId", rollupId, "height", gap.from, "err", err)
+				attestor.listener.OnBackfillFailure(rollupId)
+				return gaps
+			}
+
+			attestor.listener.OnBlockBackfilled(rollupId)
+			go attestor.processHeader(rollupId, header, ctx)
+
+			gap.from++
+			budget--
+
+			if !wait() {
+				return gaps
+			}
+		}
+
+		gaps = gaps[1:]
+	}
+
+	return nil
+}
+
 // Waits for MQ block for 1 minute. Then signs off and sends
 // Filters until receives one having same height
 func (attestor *Attestor) processHeader(rollupId uint32, rollupHeader *ethtypes.Header, ctx context.Context) {
@@ -232,6 +509,13 @@ func (attestor *Attestor) processHeader(rollupId uint32, rollupHeader *ethtypes.
 	attestor.listener.ObserveLastBlockReceivedTimestamp(rollupId, uint64(rollupHeader.Time))
 	attestor.listener.OnBlockReceived(rollupId)
 
+	agreedHeader, err := attestor.reachHeaderQuorum(ctx, rollupId, rollupHeader)
+	if err != nil {
+		attestor.logger.Warn("Header not signed, RPC quorum not reached", "rollupId", rollupId, "height", rollupHeader.Number.Uint64(), "err", err)
+		return
+	}
+	rollupHeader = agreedHeader
+
 	predicate := func(mqBlock consumer.BlockData) bool {
 		if mqBlock.RollupId != rollupId {
 			attestor.logger.Warn("Subscriber rollupId mismatch", "expected", rollupId, "actual", mqBlock.RollupId)
@@ -319,7 +603,7 @@ func (attestor *Attestor) GetSignedRootC() <-chan messages.SignedStateRootUpdate
 }
 
 func (attestor *Attestor) Close() error {
-	if err := attestor.consumer.Close(); err != nil {
+	if err := attestor.blockSource.Close(); err != nil {
 		return err
 	}
 
@@ -327,5 +611,11 @@ func (attestor *Attestor) Close() error {
 		client.Close()
 	}
 
+	for _, clients := range attestor.quorumClients {
+		for _, client := range clients {
+			client.Close()
+		}
+	}
+
 	return nil
 }
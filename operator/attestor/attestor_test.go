@@ -0,0 +1,265 @@
+package attestor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
+	safeclientmocks "github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
+	"github.com/Nuffle-Labs/nffl/core/types/messages"
+)
+
+func createTestAttestor(clients map[uint32]safeclient.SafeClient) *Attestor {
+	return &Attestor{
+		signedRootC:      make(chan messages.SignedStateRootUpdateMessage),
+		clients:          clients,
+		notifier:         NewNotifier(),
+		backfillInterval: time.Millisecond,
+		rollupStatuses:   make(map[uint32]RollupStatus),
+		logger:           sdklogging.NewNoopLogger(),
+		listener:         &SelectiveEventListener{},
+	}
+}
+
+func TestProcessRollupHeadersBackfill(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const rollupId = uint32(1)
+
+	backfilledC := make(chan uint64, 10)
+	mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			backfilledC <- number.Uint64()
+			return &types.Header{Number: number}, nil
+		},
+	).Times(3)
+
+	mockSub := safeclientmocks.NewMockSubscription(mockCtrl)
+	mockSub.EXPECT().Err().Return(make(chan error)).AnyTimes()
+	mockSub.EXPECT().Unsubscribe().AnyTimes()
+
+	attestor := createTestAttestor(map[uint32]safeclient.SafeClient{rollupId: mockClient})
+
+	headersC := make(chan *types.Header, 10)
+	go attestor.processRollupHeaders(rollupId, 9, headersC, mockSub, ctx)
+
+	headersC <- &types.Header{Number: big.NewInt(10)}
+	headersC <- &types.Header{Number: big.NewInt(14)}
+	headersC <- &types.Header{Number: big.NewInt(15)}
+
+	for _, expected := range []uint64{11, 12, 13} {
+		select {
+		case height := <-backfilledC:
+			assert.Equal(t, expected, height)
+		case <-time.After(time.Second):
+			t.Fatal("backfill timed out")
+		}
+	}
+
+	select {
+	case height := <-backfilledC:
+		t.Fatal("unexpected backfill", height)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestProcessRollupHeadersNotBlockedByBackfill(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const rollupId = uint32(1)
+
+	// Backfill hangs until the test ends
+	mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	).AnyTimes()
+
+	mockSub := safeclientmocks.NewMockSubscription(mockCtrl)
+	mockSub.EXPECT().Err().Return(make(chan error)).AnyTimes()
+	mockSub.EXPECT().Unsubscribe().AnyTimes()
+
+	attestor := createTestAttestor(map[uint32]safeclient.SafeClient{rollupId: mockClient})
+
+	headersC := make(chan *types.Header)
+	go attestor.processRollupHeaders(rollupId, 9, headersC, mockSub, ctx)
+
+	for _, height := range []int64{20, 21, 22} {
+		select {
+		case headersC <- &types.Header{Number: big.NewInt(height)}:
+		case <-time.After(time.Second):
+			t.Fatal("live header blocked by backfill", height)
+		}
+	}
+}
+
+func TestBackfillHeadersChunks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const rollupId = uint32(1)
+
+	var heights []uint64
+	mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+	mockClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			heights = append(heights, number.Uint64())
+			return &types.Header{Number: number}, nil
+		},
+	).Times(2 * BACKFILL_CHUNK_SIZE)
+
+	attestor := createTestAttestor(map[uint32]safeclient.SafeClient{rollupId: mockClient})
+	attestor.backfillInterval = time.Microsecond
+
+	gaps := attestor.backfillHeaders(rollupId, []heightRange{{from: 1, to: 2 * BACKFILL_CHUNK_SIZE}}, ctx)
+	assert.Equal(t, []heightRange{{from: BACKFILL_CHUNK_SIZE + 1, to: 2 * BACKFILL_CHUNK_SIZE}}, gaps)
+
+	gaps = attestor.backfillHeaders(rollupId, gaps, ctx)
+	assert.Empty(t, gaps)
+
+	// Every height is backfilled once and in order
+	assert.Len(t, heights, 2*BACKFILL_CHUNK_SIZE)
+	for i, height := range heights {
+		assert.Equal(t, uint64(i+1), height)
+	}
+}
+
+func TestBackfillHeadersRetriesFailedHeight(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const rollupId = uint32(1)
+
+	mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+	gomock.InOrder(
+		mockClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(1)).Return(&types.Header{Number: big.NewInt(1)}, nil),
+		mockClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(2)).Return(nil, errors.New("unavailable")).Times(BACKFILL_ATTEMPTS),
+		mockClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(2)).Return(&types.Header{Number: big.NewInt(2)}, nil),
+		mockClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(3)).Return(&types.Header{Number: big.NewInt(3)}, nil),
+	)
+
+	attestor := createTestAttestor(map[uint32]safeclient.SafeClient{rollupId: mockClient})
+
+	// The failed height stops the backfill and is kept for the next one
+	gaps := attestor.backfillHeaders(rollupId, []heightRange{{from: 1, to: 3}}, ctx)
+	assert.Equal(t, []heightRange{{from: 2, to: 3}}, gaps)
+
+	gaps = attestor.backfillHeaders(rollupId, gaps, ctx)
+	assert.Empty(t, gaps)
+}
+
+func TestTallyHeaders(t *testing.T) {
+	headerA := &types.Header{Number: big.NewInt(1), Root: common.Hash{1}}
+	headerB := &types.Header{Number: big.NewInt(1), Root: common.Hash{2}}
+
+	header, ok := tallyHeaders([]*types.Header{headerA, headerB, nil, headerB}, 2)
+	assert.True(t, ok)
+	assert.Equal(t, headerB.Root, header.Root)
+
+	_, ok = tallyHeaders([]*types.Header{headerA, headerB, nil}, 2)
+	assert.False(t, ok)
+}
+
+func TestValidateQuorumThresholds(t *testing.T) {
+	assert.NoError(t, validateQuorumThresholds(nil, nil))
+	assert.NoError(t, validateQuorumThresholds(map[uint32]uint32{1: 2}, map[uint32][]string{1: {"http://quorum"}}))
+
+	assert.Error(t, validateQuorumThresholds(map[uint32]uint32{1: 2}, nil))
+	assert.Error(t, validateQuorumThresholds(map[uint32]uint32{1: 2}, map[uint32][]string{1: {}, 2: {"http://quorum"}}))
+}
+
+func TestReachHeaderQuorum(t *testing.T) {
+	const rollupId = uint32(1)
+
+	rollupHeader := &types.Header{Number: big.NewInt(10), Root: common.Hash{1}}
+	divergingHeader := &types.Header{Number: big.NewInt(10), Root: common.Hash{2}}
+
+	createQuorumAttestor := func(mockCtrl *gomock.Controller, threshold uint32, quorumHeaders ...*types.Header) (*Attestor, *int) {
+		quorumClients := make([]safeclient.SafeClient, 0, len(quorumHeaders))
+		for _, header := range quorumHeaders {
+			header := header
+
+			mockClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+			mockClient.EXPECT().HeaderByNumber(gomock.Any(), rollupHeader.Number).Return(header, nil)
+			quorumClients = append(quorumClients, mockClient)
+		}
+
+		attestor := createTestAttestor(map[uint32]safeclient.SafeClient{rollupId: safeclientmocks.NewMockSafeClient(mockCtrl)})
+		attestor.quorumClients = map[uint32][]safeclient.SafeClient{rollupId: quorumClients}
+		attestor.quorumThresholds = map[uint32]uint32{rollupId: threshold}
+		attestor.quorumWaitTimeout = time.Second
+
+		disagreements := 0
+		attestor.listener = &SelectiveEventListener{
+			OnRpcDisagreementCb: func(uint32) { disagreements++ },
+		}
+
+		return attestor, &disagreements
+	}
+
+	t.Run("agreement", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		attestor, disagreements := createQuorumAttestor(mockCtrl, 2, rollupHeader, divergingHeader)
+
+		header, err := attestor.reachHeaderQuorum(context.Background(), rollupId, rollupHeader)
+		assert.NoError(t, err)
+		assert.Equal(t, rollupHeader.Root, header.Root)
+		assert.Equal(t, 1, *disagreements)
+	})
+
+	t.Run("subscription RPC outvoted", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		attestor, disagreements := createQuorumAttestor(mockCtrl, 2, divergingHeader, divergingHeader)
+
+		header, err := attestor.reachHeaderQuorum(context.Background(), rollupId, rollupHeader)
+		assert.NoError(t, err)
+		assert.Equal(t, divergingHeader.Root, header.Root)
+		assert.Equal(t, 1, *disagreements)
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		attestor, _ := createQuorumAttestor(mockCtrl, 3, rollupHeader, divergingHeader)
+
+		failures := 0
+		attestor.listener = &SelectiveEventListener{
+			OnRpcQuorumFailureCb: func(uint32) { failures++ },
+		}
+
+		_, err := attestor.reachHeaderQuorum(context.Background(), rollupId, rollupHeader)
+		assert.ErrorIs(t, err, quorumNotReachedError)
+		assert.Equal(t, 1, failures)
+	})
+}
@@ -10,6 +10,10 @@ type EventListener interface {
 	OnMissedMQBlock(rollupId uint32)
 	OnBlockMismatch(rollupId uint32)
 	OnBlockReceived(rollupId uint32)
+	OnBlockBackfilled(rollupId uint32)
+	OnBackfillFailure(rollupId uint32)
+	OnRpcDisagreement(rollupId uint32)
+	OnRpcQuorumFailure(rollupId uint32)
 	ObserveLastBlockReceived(rollupId uint32, blockNumber uint64)
 	ObserveLastBlockReceivedTimestamp(rollupId uint32, timestamp uint64)
 	ObserveInitializationInitialBlockNumber(rollupId uint32, blockNumber uint64)
@@ -22,6 +26,10 @@ type SelectiveEventListener struct {
 	OnMissedMQBlockCb                         func(rollupId uint32)
 	OnBlockMismatchCb                         func(rollupId uint32)
 	OnBlockReceivedCb                         func(rollupId uint32)
+	OnBlockBackfilledCb                       func(rollupId uint32)
+	OnBackfillFailureCb                       func(rollupId uint32)
+	OnRpcDisagreementCb                       func(rollupId uint32)
+	OnRpcQuorumFailureCb                      func(rollupId uint32)
 	ObserveLastBlockReceivedCb                func(rollupId uint32, blockNumber uint64)
 	ObserveLastBlockReceivedTimestampCb       func(rollupId uint32, timestamp uint64)
 	ObserveInitializationInitialBlockNumberCb func(rollupId uint32, blockNumber uint64)
@@ -45,6 +53,30 @@ func (l *SelectiveEventListener) OnBlockReceived(rollupId uint32) {
 	}
 }
 
+func (l *SelectiveEventListener) OnBlockBackfilled(rollupId uint32) {
+	if l.OnBlockBackfilledCb != nil {
+		l.OnBlockBackfilledCb(rollupId)
+	}
+}
+
+func (l *SelectiveEventListener) OnBackfillFailure(rollupId uint32) {
+	if l.OnBackfillFailureCb != nil {
+		l.OnBackfillFailureCb(rollupId)
+	}
+}
+
+func (l *SelectiveEventListener) OnRpcDisagreement(rollupId uint32) {
+	if l.OnRpcDisagreementCb != nil {
+		l.OnRpcDisagreementCb(rollupId)
+	}
+}
+
+func (l *SelectiveEventListener) OnRpcQuorumFailure(rollupId uint32) {
+	if l.OnRpcQuorumFailureCb != nil {
+		l.OnRpcQuorumFailureCb(rollupId)
+	}
+}
+
 func (l *SelectiveEventListener) ObserveLastBlockReceived(rollupId uint32, blockNumber uint64) {
 	if l.ObserveLastBlockReceivedCb != nil {
 		l.ObserveLastBlockReceivedCb(rollupId, blockNumber)
@@ -106,6 +138,62 @@ func MakeAttestorMetrics(registry *prometheus.Registry) (EventListener, error) {
 		return nil, fmt.Errorf("error registering numBlocksReceived counter: %w", err)
 	}
 
+	numBlocksBackfilled := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: OperatorNamespace,
+			Subsystem: AttestorSubsystem,
+			Name:      "num_of_backfilled_blocks",
+			Help:      "The number of blocks backfilled from RPC after a subscription gap.",
+		},
+		[]string{"rollup_id"},
+	)
+
+	if err := registry.Register(numBlocksBackfilled); err != nil {
+		return nil, fmt.Errorf("error registering numBlocksBackfilled counter: %w", err)
+	}
+
+	numBackfillFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: OperatorNamespace,
+			Subsystem: AttestorSubsystem,
+			Name:      "num_of_backfill_failures",
+			Help:      "The number of blocks that couldn't be backfilled from RPC.",
+		},
+		[]string{"rollup_id"},
+	)
+
+	if err := registry.Register(numBackfillFailures); err != nil {
+		return nil, fmt.Errorf("error registering numBackfillFailures counter: %w", err)
+	}
+
+	numRpcDisagreements := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: OperatorNamespace,
+			Subsystem: AttestorSubsystem,
+			Name:      "num_of_rpc_disagreements",
+			Help:      "The number of headers from quorum RPCs with a diverging state root.",
+		},
+		[]string{"rollup_id"},
+	)
+
+	if err := registry.Register(numRpcDisagreements); err != nil {
+		return nil, fmt.Errorf("error registering numRpcDisagreements counter: %w", err)
+	}
+
+	numRpcQuorumFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: OperatorNamespace,
+			Subsystem: AttestorSubsystem,
+			Name:      "num_of_rpc_quorum_failures",
+			Help:      "The number of headers not signed due to lack of RPC agreement.",
+		},
+		[]string{"rollup_id"},
+	)
+
+	if err := registry.Register(numRpcQuorumFailures); err != nil {
+		return nil, fmt.Errorf("error registering numRpcQuorumFailures counter: %w", err)
+	}
+
 	lastBlockReceived := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: OperatorNamespace,
@@ -152,6 +240,18 @@ func MakeAttestorMetrics(registry *prometheus.Registry) (EventListener, error) {
 		OnBlockMismatchCb: func(rollupId uint32) {
 			numBlocksMismatched.WithLabelValues(fmt.Sprint(rollupId)).Inc()
 		},
+		OnBlockBackfilledCb: func(rollupId uint32) {
+			numBlocksBackfilled.WithLabelValues(fmt.Sprint(rollupId)).Inc()
+		},
+		OnBackfillFailureCb: func(rollupId uint32) {
+			numBackfillFailures.WithLabelValues(fmt.Sprint(rollupId)).Inc()
+		},
+		OnRpcDisagreementCb: func(rollupId uint32) {
+			numRpcDisagreements.WithLabelValues(fmt.Sprint(rollupId)).Inc()
+		},
+		OnRpcQuorumFailureCb: func(rollupId uint32) {
+			numRpcQuorumFailures.WithLabelValues(fmt.Sprint(rollupId)).Inc()
+		},
 		ObserveLastBlockReceivedCb: func(rollupId uint32, blockNumber uint64) {
 			lastBlockReceived.WithLabelValues(fmt.Sprint(rollupId)).Set(float64(blockNumber))
 		},
@@ -0,0 +1,160 @@
+package attestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
+)
+
+const (
+	QUORUM_WAIT_TIMEOUT   = 10 * time.Second
+	QUORUM_RETRY_INTERVAL = 1 * time.Second
+)
+
+var (
+	quorumNotReachedError = errors.New("rpc quorum not reached")
+)
+
+// Returns the default k in k-of-n agreement, which is a simple majority
+func defaultQuorumThreshold(numRpcs int) uint32 {
+	return uint32(numRpcs/2 + 1)
+}
+
+// Rejects thresholds set for rollups without quorum RPCs, which would
+// otherwise be ignored and leave the rollup single-sourced
+func validateQuorumThresholds(thresholds map[uint32]uint32, quorumRpcUrls map[uint32][]string) error {
+	for rollupId := range thresholds {
+		if len(quorumRpcUrls[rollupId]) == 0 {
+			return fmt.Errorf("RPC quorum threshold set for rollup %d without quorum RPCs", rollupId)
+		}
+	}
+
+	return nil
+}
+
+// Returns the first header whose state root is shared by at least threshold headers
+func tallyHeaders(headers []*ethtypes.Header, threshold uint32) (*ethtypes.Header, bool) {
+	votes := make(map[common.Hash]uint32)
+
+	for _, header := range headers {
+		if header == nil {
+			continue
+		}
+
+		votes[header.Root]++
+		if votes[header.Root] >= threshold {
+			return header, true
+		}
+	}
+
+	return nil, false
+}
+
+// Fetches the header at height from each client which has no header yet
+func fetchMissingHeaders(ctx context.Context, clients []safeclient.SafeClient, headers []*ethtypes.Header, height *big.Int) []error {
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		if headers[i] != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, client safeclient.SafeClient) {
+			defer wg.Done()
+
+			headers[i], errs[i] = client.HeaderByNumber(ctx, height)
+		}(i, client)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// Checks the header received from the rollup subscription against the quorum
+// RPCs of the rollup. Retries RPCs that haven't answered until the threshold is
+// reached or quorumWaitTimeout elapses, and returns the agreed upon header.
+func (attestor *Attestor) reachHeaderQuorum(ctx context.Context, rollupId uint32, rollupHeader *ethtypes.Header) (*ethtypes.Header, error) {
+	quorumClients := attestor.quorumClients[rollupId]
+	threshold := attestor.quorumThresholds[rollupId]
+	if len(quorumClients) == 0 || threshold <= 1 {
+		return rollupHeader, nil
+	}
+
+	// First entry is the subscription client, which already answered
+	clients := append([]safeclient.SafeClient{attestor.clients[rollupId]}, quorumClients...)
+	headers := make([]*ethtypes.Header, len(clients))
+	headers[0] = rollupHeader
+
+	timer := time.After(attestor.quorumWaitTimeout)
+loop:
+	for {
+		errs := fetchMissingHeaders(ctx, clients, headers, rollupHeader.Number)
+		for i, err := range errs {
+			if err != nil {
+				attestor.logger.Debug("Quorum RPC header fetch failed", "rollupId", rollupId, "height", rollupHeader.Number.Uint64(), "rpcIndex", i, "err", err)
+			}
+		}
+
+		agreedHeader, ok := tallyHeaders(headers, threshold)
+		if ok {
+			attestor.reportQuorumDisagreements(rollupId, agreedHeader, headers)
+			return agreedHeader, nil
+		}
+
+		// Every RPC answered, so waiting won't change the outcome
+		if !hasMissingHeaders(headers) {
+			break loop
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer:
+			attestor.logger.Warn("Quorum RPC wait timeout", "rollupId", rollupId, "height", rollupHeader.Number.Uint64())
+			break loop
+		case <-time.After(QUORUM_RETRY_INTERVAL):
+		}
+	}
+
+	attestor.reportQuorumDisagreements(rollupId, rollupHeader, headers)
+	attestor.listener.OnRpcQuorumFailure(rollupId)
+
+	return nil, quorumNotReachedError
+}
+
+func hasMissingHeaders(headers []*ethtypes.Header) bool {
+	for _, header := range headers {
+		if header == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (attestor *Attestor) reportQuorumDisagreements(rollupId uint32, expectedHeader *ethtypes.Header, headers []*ethtypes.Header) {
+	for i, header := range headers {
+		if header == nil || header.Root == expectedHeader.Root {
+			continue
+		}
+
+		attestor.logger.Warn("Quorum RPC state root mismatch",
+			"rollupId", rollupId,
+			"height", expectedHeader.Number.Uint64(),
+			"rpcIndex", i,
+			"expected", expectedHeader.Root,
+			"actual", header.Root,
+		)
+		attestor.listener.OnRpcDisagreement(rollupId)
+	}
+}
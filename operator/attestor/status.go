@@ -0,0 +1,49 @@
+package attestor
+
+import (
+	"time"
+)
+
+// Status of the attestor data sources, used for the node health
+type Status struct {
+	IsDaSourceReady bool
+	Rollups         map[uint32]RollupStatus
+}
+
+type RollupStatus struct {
+	IsSubscribed bool
+	LastHeaderAt time.Time
+}
+
+func (attestor *Attestor) GetStatus() Status {
+	attestor.statusLock.RLock()
+	defer attestor.statusLock.RUnlock()
+
+	rollups := make(map[uint32]RollupStatus, len(attestor.rollupStatuses))
+	for rollupId, rollupStatus := range attestor.rollupStatuses {
+		rollups[rollupId] = rollupStatus
+	}
+
+	return Status{
+		IsDaSourceReady: attestor.blockSource.IsReady(),
+		Rollups:         rollups,
+	}
+}
+
+func (attestor *Attestor) setRollupSubscribed(rollupId uint32, isSubscribed bool) {
+	attestor.statusLock.Lock()
+	defer attestor.statusLock.Unlock()
+
+	rollupStatus := attestor.rollupStatuses[rollupId]
+	rollupStatus.IsSubscribed = isSubscribed
+	attestor.rollupStatuses[rollupId] = rollupStatus
+}
+
+func (attestor *Attestor) markRollupHeaderReceived(rollupId uint32) {
+	attestor.statusLock.Lock()
+	defer attestor.statusLock.Unlock()
+
+	rollupStatus := attestor.rollupStatuses[rollupId]
+	rollupStatus.LastHeaderAt = time.Now()
+	attestor.rollupStatuses[rollupId] = rollupStatus
+}
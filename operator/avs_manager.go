@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/elcontracts"
@@ -26,6 +27,9 @@ import (
 	optypes "github.com/Nuffle-Labs/nffl/operator/types"
 )
 
+// Task and operator set update subscriptions
+const AVS_SUBSCRIPTIONS_COUNT = 2
+
 type AvsManagerer interface {
 	Start(ctx context.Context, operatorAddr common.Address) error
 	DepositIntoStrategy(operatorAddr common.Address, strategyAddr common.Address, amount *big.Int) error
@@ -39,6 +43,7 @@ type AvsManagerer interface {
 	GetOperatorId(options *bind.CallOpts, address common.Address) ([32]byte, error)
 	GetCheckpointTaskCreatedChan() <-chan *taskmanager.ContractSFFLTaskManagerCheckpointTaskCreated
 	GetOperatorSetUpdateChan() <-chan messages.OperatorSetUpdateMessage
+	IsSubscribed() bool
 }
 
 type AvsManager struct {
@@ -55,6 +60,9 @@ type AvsManager struct {
 
 	operatorSetUpdateMessageChan chan messages.OperatorSetUpdateMessage
 
+	// number of live AVS event subscriptions
+	activeSubscriptions atomic.Int32
+
 	logger sdklogging.Logger
 }
 
@@ -127,12 +135,14 @@ func (avsManager *AvsManager) Start(ctx context.Context, operatorAddr common.Add
 		return err
 	}
 
-	operatorSetUpdateSub, err := avsManager.avsSubscriber.SubscribeToOperatorSetUpdates(avsManager.operatorSetUpdateChan)
+	operatorSetUpdateSub, err := avsManager.avsSubscriber.SubscribeToOperatorSetUpdates(ctx, avsManager.operatorSetUpdateChan)
 	if err != nil {
 		avsManager.logger.Error("Error subscribing to operator set updates", "err", err)
 		return err
 	}
 
+	avsManager.activeSubscriptions.Store(AVS_SUBSCRIPTIONS_COUNT)
+
 	go func() {
 		for {
 			select {
@@ -141,6 +151,7 @@ func (avsManager *AvsManager) Start(ctx context.Context, operatorAddr common.Add
 
 			case err := <-newTasksSub.Err():
 				avsManager.logger.Error("New tasks subscription error", "err", err)
+				avsManager.activeSubscriptions.Add(-1)
 				newTasksSub.Unsubscribe()
 				return
 			}
@@ -155,10 +166,16 @@ func (avsManager *AvsManager) Start(ctx context.Context, operatorAddr common.Add
 
 			case err := <-operatorSetUpdateSub.Err():
 				avsManager.logger.Error("Operator set update subscription error", "err", err)
+				avsManager.activeSubscriptions.Add(-1)
 				operatorSetUpdateSub.Unsubscribe()
 				return
 
 			case operatorSetUpdate := <-avsManager.operatorSetUpdateChan:
+				if operatorSetUpdate.Raw.Removed {
+					avsManager.logger.Warn("Operator set update was reorged out", "id", operatorSetUpdate.Id, "block", operatorSetUpdate.Raw.BlockNumber)
+					continue
+				}
+
 				go avsManager.handleOperatorSetUpdate(ctx, operatorSetUpdate)
 				continue
 			}
@@ -168,6 +185,11 @@ func (avsManager *AvsManager) Start(ctx context.Context, operatorAddr common.Add
 	return nil
 }
 
+// Whether all AVS event subscriptions are live
+func (avsManager *AvsManager) IsSubscribed() bool {
+	return avsManager.activeSubscriptions.Load() == AVS_SUBSCRIPTIONS_COUNT
+}
+
 func (avsManager *AvsManager) handleOperatorSetUpdate(ctx context.Context, data *opsetupdatereg.ContractSFFLOperatorSetUpdateRegistryOperatorSetUpdatedAtBlock) error {
 	operatorSetDelta, err := avsManager.avsReader.GetOperatorSetUpdateDelta(ctx, data.Id)
 	if err != nil {
@@ -0,0 +1,25 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/Nuffle-Labs/nffl/core"
+)
+
+// Block source types selectable in the operator config
+const (
+	RMQ_BLOCK_SOURCE  = "rmq"
+	HTTP_BLOCK_SOURCE = "http"
+)
+
+// DaBlockSource streams rollup blocks posted to NEAR DA, independently of
+// how they're delivered to the operator
+type DaBlockSource interface {
+	core.Metricable
+
+	Start(ctx context.Context) error
+	Close() error
+	// Whether the source is currently able to receive blocks
+	IsReady() bool
+	GetBlockStream() <-chan BlockData
+}
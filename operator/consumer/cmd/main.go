@@ -61,12 +61,15 @@ func consumerMain(cliCtx *cli.Context) error {
 	}
 
 	consumer := consumer.NewConsumer(consumer.ConsumerConfig{
+		Addr:      cliCtx.GlobalString("rmq-address"),
 		Id:        cliCtx.GlobalString("id"),
 		RollupIds: rollupIds,
 	}, logger)
 
 	ctx := context.Background()
-	go consumer.Start(ctx, cliCtx.GlobalString("rmq-address"))
+	if err := consumer.Start(ctx); err != nil {
+		return err
+	}
 
 	blockStream := consumer.GetBlockStream()
 
@@ -5,14 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prometheus/client_golang/prometheus"
 	rmq "github.com/rabbitmq/amqp091-go"
-
-	"github.com/Nuffle-Labs/nffl/core"
 )
 
 const (
@@ -38,8 +37,10 @@ func getConsumerTag(rollupId uint32) string {
 }
 
 type ConsumerConfig struct {
-	RollupIds []uint32
-	Id        string
+	Addr         string
+	RollupIds    []uint32
+	Id           string
+	BlobVerifier BlobVerifier
 }
 
 type BlockData struct {
@@ -47,23 +48,20 @@ type BlockData struct {
 	Commitment    Commitment
 	TransactionId TransactionId
 	Block         *types.Block
-}
-
-type Consumerer interface {
-	Reconnect(addr string, ctx context.Context)
-	ResetChannel(conn *rmq.Connection, ctx context.Context) bool
-	Close() error
-	GetBlockStream() <-chan BlockData
+	// Only set if the relayer submitted them
+	Receipts []*types.Receipt
 }
 
 type Consumer struct {
 	receivedBlocksC chan BlockData
 	queuesListener  *QueuesListener
+	blobVerifier    BlobVerifier
 
+	addr      string
 	id        string
 	rollupIds []uint32
 
-	isReady           bool
+	isReady           atomic.Bool
 	contextCancelFunc context.CancelFunc
 	connection        *rmq.Connection
 	connClosedErrC    <-chan *rmq.Error
@@ -74,13 +72,15 @@ type Consumer struct {
 	eventListener EventListener
 }
 
-var _ core.Metricable = (*Consumer)(nil)
+var _ DaBlockSource = (*Consumer)(nil)
 
 func NewConsumer(config ConsumerConfig, logger logging.Logger) *Consumer {
 	consumer := Consumer{
+		addr:            config.Addr,
 		id:              config.Id,
 		rollupIds:       config.RollupIds,
 		receivedBlocksC: make(chan BlockData),
+		blobVerifier:    config.BlobVerifier,
 		logger:          logger,
 		eventListener:   &SelectiveListener{},
 	}
@@ -98,18 +98,20 @@ func (consumer *Consumer) EnableMetrics(registry *prometheus.Registry) error {
 	return nil
 }
 
-func (consumer *Consumer) Start(ctx context.Context, addr string) {
+func (consumer *Consumer) Start(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	consumer.contextCancelFunc = cancel
 
-	go consumer.reconnect(ctx, addr)
+	go consumer.reconnect(ctx, consumer.addr)
+
+	return nil
 }
 
 func (consumer *Consumer) reconnect(ctx context.Context, addr string) {
 	for {
 		consumer.logger.Info("Reconnecting...")
 
-		consumer.isReady = false
+		consumer.isReady.Store(false)
 		conn, err := consumer.connect(addr)
 		if err != nil {
 			consumer.logger.Warn("Connection setup failed", "err", err)
@@ -174,7 +176,7 @@ func (consumer *Consumer) changeConnection(conn *rmq.Connection) {
 
 func (consumer *Consumer) ResetChannel(ctx context.Context, conn *rmq.Connection) bool {
 	for {
-		consumer.isReady = false
+		consumer.isReady.Store(false)
 
 		err := consumer.setupChannel(ctx, conn)
 		if err != nil {
@@ -209,7 +211,7 @@ func (consumer *Consumer) setupChannel(ctx context.Context, conn *rmq.Connection
 		return err
 	}
 
-	listener := NewQueuesListener(consumer.receivedBlocksC, consumer.eventListener, consumer.logger)
+	listener := NewQueuesListener(consumer.receivedBlocksC, consumer.blobVerifier, consumer.eventListener, consumer.logger)
 	for _, rollupId := range consumer.rollupIds {
 		queue, err := channel.QueueDeclare(getQueueName(rollupId, consumer.id), true, false, false, false, nil)
 		if err != nil {
@@ -244,7 +246,7 @@ func (consumer *Consumer) setupChannel(ctx context.Context, conn *rmq.Connection
 
 	consumer.queuesListener = listener
 	consumer.changeChannel(channel)
-	consumer.isReady = true
+	consumer.isReady.Store(true)
 	return nil
 }
 
@@ -256,7 +258,7 @@ func (consumer *Consumer) changeChannel(channel *rmq.Channel) {
 }
 
 func (consumer *Consumer) Close() error {
-	if !consumer.isReady {
+	if !consumer.isReady.Load() {
 		return AlreadyClosedError
 	}
 
@@ -275,10 +277,14 @@ func (consumer *Consumer) Close() error {
 		return err
 	}
 
-	consumer.isReady = false
+	consumer.isReady.Store(false)
 	return nil
 }
 
+func (consumer *Consumer) IsReady() bool {
+	return consumer.isReady.Load()
+}
+
 func (consumer *Consumer) GetBlockStream() <-chan BlockData {
 	return consumer.receivedBlocksC
 }
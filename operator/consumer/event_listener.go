@@ -8,14 +8,16 @@ import (
 type EventListener interface {
 	OnArrival()
 	OnFormatError()
+	OnVerificationFailure()
 }
 
 const OperatorNamespace = "sffl_operator"
 const ConsumerSubsystem = "consumer"
 
 type SelectiveListener struct {
-	OnArrivalCb     func()
-	OnFormatErrorCb func()
+	OnArrivalCb             func()
+	OnFormatErrorCb         func()
+	OnVerificationFailureCb func()
 }
 
 func (l *SelectiveListener) OnArrival() {
@@ -30,6 +32,12 @@ func (l *SelectiveListener) OnFormatError() {
 	}
 }
 
+func (l *SelectiveListener) OnVerificationFailure() {
+	if l.OnVerificationFailureCb != nil {
+		l.OnVerificationFailureCb()
+	}
+}
+
 func MakeConsumerMetrics(registry *prometheus.Registry) (EventListener, error) {
 	numBlocksArrived := prometheus.NewCounter(
 		prometheus.CounterOpts{
@@ -55,6 +63,18 @@ func MakeConsumerMetrics(registry *prometheus.Registry) (EventListener, error) {
 		return nil, fmt.Errorf("error registering numFormatErrors counter: %w", err)
 	}
 
+	numVerificationFailures := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: OperatorNamespace,
+			Subsystem: ConsumerSubsystem,
+			Name:      "num_of_verification_failures",
+			Help:      "The number of MQ submissions that failed NEAR DA verification.",
+		})
+
+	if err := registry.Register(numVerificationFailures); err != nil {
+		return nil, fmt.Errorf("error registering numVerificationFailures counter: %w", err)
+	}
+
 	return &SelectiveListener{
 		OnArrivalCb: func() {
 			numBlocksArrived.Inc()
@@ -62,5 +82,8 @@ func MakeConsumerMetrics(registry *prometheus.Registry) (EventListener, error) {
 		OnFormatErrorCb: func() {
 			numFormatErrors.Inc()
 		},
+		OnVerificationFailureCb: func() {
+			numVerificationFailures.Inc()
+		},
 	}, nil
 }
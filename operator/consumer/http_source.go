@@ -0,0 +1,229 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	HTTP_POLL_TIMEOUT     = 30 * time.Second
+	HTTP_POLL_RETRY_DELAY = 3 * time.Second
+	// Extra time given to the server to answer after the poll timeout
+	HTTP_POLL_GRACE_PERIOD = 10 * time.Second
+)
+
+// Submission as served by the long-poll endpoint. Seq is increasing per rollup
+// and Payload is the borsh-serialized PublishPayload, same as in MQ.
+type HttpSubmission struct {
+	Seq     uint64 `json:"seq"`
+	Payload []byte `json:"payload"`
+}
+
+type HttpSubmissionsResponse struct {
+	Submissions []HttpSubmission `json:"submissions"`
+}
+
+// Returns the long-poll endpoint path for a rollup. It accepts an optional
+// `after` query parameter with the last seen Seq, and `timeout_ms`, the max
+// time to hold the request while there are no new submissions. Without
+// `after`, only submissions made after the request are returned.
+func GetHttpSubmissionsPath(rollupId uint32) string {
+	return fmt.Sprintf("/v1/rollups/%d/submissions", rollupId)
+}
+
+type HttpBlockSourceConfig struct {
+	Url          string
+	RollupIds    []uint32
+	BlobVerifier BlobVerifier
+}
+
+// HttpBlockSource long-polls an HTTP endpoint for indexer submissions,
+// keeping a cursor per rollup
+type HttpBlockSource struct {
+	receivedBlocksC chan BlockData
+	blobVerifier    BlobVerifier
+
+	url         string
+	rollupIds   []uint32
+	httpClient  *http.Client
+	pollTimeout time.Duration
+	retryDelay  time.Duration
+
+	isReady           atomic.Bool
+	contextCancelFunc context.CancelFunc
+
+	logger        logging.Logger
+	eventListener EventListener
+}
+
+var _ DaBlockSource = (*HttpBlockSource)(nil)
+
+func NewHttpBlockSource(config HttpBlockSourceConfig, logger logging.Logger) *HttpBlockSource {
+	return &HttpBlockSource{
+		receivedBlocksC: make(chan BlockData),
+		blobVerifier:    config.BlobVerifier,
+		url:             config.Url,
+		rollupIds:       config.RollupIds,
+		httpClient:      &http.Client{Timeout: HTTP_POLL_TIMEOUT + HTTP_POLL_GRACE_PERIOD},
+		pollTimeout:     HTTP_POLL_TIMEOUT,
+		retryDelay:      HTTP_POLL_RETRY_DELAY,
+		logger:          logger,
+		eventListener:   &SelectiveListener{},
+	}
+}
+
+func (source *HttpBlockSource) EnableMetrics(registry *prometheus.Registry) error {
+	eventListener, err := MakeConsumerMetrics(registry)
+	if err != nil {
+		return err
+	}
+
+	source.eventListener = eventListener
+	return nil
+}
+
+func (source *HttpBlockSource) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	source.contextCancelFunc = cancel
+
+	decoder := newSubmissionDecoder(source.blobVerifier, source.eventListener, source.logger)
+
+	for _, rollupId := range source.rollupIds {
+		go source.poll(ctx, rollupId, decoder)
+	}
+
+	return nil
+}
+
+func (source *HttpBlockSource) poll(ctx context.Context, rollupId uint32, decoder *submissionDecoder) {
+	var cursor *uint64
+
+	for {
+		submissions, err := source.fetchSubmissions(ctx, rollupId, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				source.logger.Info("HTTP block source context canceled", "rollupId", rollupId)
+				return
+			}
+
+			source.logger.Warn("Failed to poll submissions", "rollupId", rollupId, "err", err)
+			source.isReady.Store(false)
+
+			select {
+			case <-ctx.Done():
+				source.logger.Info("HTTP block source context canceled", "rollupId", rollupId)
+				return
+			case <-time.After(source.retryDelay):
+			}
+
+			continue
+		}
+
+		source.isReady.Store(true)
+
+		unavailable := false
+		for _, submission := range submissions {
+			seq := submission.Seq
+
+			source.logger.Info("New submission", "rollupId", rollupId, "seq", seq)
+			source.eventListener.OnArrival()
+
+			blocksData, err := decoder.decode(ctx, rollupId, submission.Payload)
+			if errors.Is(err, VerificationUnavailableError) {
+				// Keep the cursor before this submission so it's fetched again
+				unavailable = true
+				break
+			}
+
+			cursor = &seq
+			if err != nil {
+				continue
+			}
+
+			for _, blockData := range blocksData {
+				source.logger.Info(
+					"HTTP Block",
+					"rollupId", rollupId,
+					"blockHeight", blockData.Block.Header().Number.Uint64(),
+					"transactionId", blockData.TransactionId,
+					"commitment", blockData.Commitment,
+				)
+
+				select {
+				case source.receivedBlocksC <- blockData:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		if unavailable {
+			select {
+			case <-ctx.Done():
+				source.logger.Info("HTTP block source context canceled", "rollupId", rollupId)
+				return
+			case <-time.After(source.retryDelay):
+			}
+		}
+	}
+}
+
+func (source *HttpBlockSource) fetchSubmissions(ctx context.Context, rollupId uint32, cursor *uint64) ([]HttpSubmission, error) {
+	query := url.Values{}
+	query.Set("timeout_ms", strconv.FormatInt(source.pollTimeout.Milliseconds(), 10))
+	if cursor != nil {
+		query.Set("after", strconv.FormatUint(*cursor, 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.url+GetHttpSubmissionsPath(rollupId)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := source.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var response HttpSubmissionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return response.Submissions, nil
+}
+
+func (source *HttpBlockSource) Close() error {
+	if source.contextCancelFunc == nil {
+		return AlreadyClosedError
+	}
+
+	source.contextCancelFunc()
+	source.contextCancelFunc = nil
+	source.isReady.Store(false)
+
+	return nil
+}
+
+func (source *HttpBlockSource) IsReady() bool {
+	return source.isReady.Load()
+}
+
+func (source *HttpBlockSource) GetBlockStream() <-chan BlockData {
+	return source.receivedBlocksC
+}
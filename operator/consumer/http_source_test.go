@@ -0,0 +1,95 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPayload(t *testing.T, transactionId TransactionId, blobs ...Blob) []byte {
+	data, err := borsh.Serialize(SubmitRequest{Blobs: blobs})
+	assert.NoError(t, err)
+
+	payload, err := borsh.Serialize(PublishPayload{TransactionId: transactionId, Data: data})
+	assert.NoError(t, err)
+
+	return payload
+}
+
+func TestHttpBlockSource(t *testing.T) {
+	submissions := []HttpSubmission{
+		{Seq: 1, Payload: createTestPayload(t, TransactionId{1}, createTestBlob(t, common.Hash{1}, common.Hash{2}))},
+		{Seq: 2, Payload: []byte{1, 2, 3}},
+		{Seq: 3, Payload: createTestPayload(t, TransactionId{3}, createTestBlob(t, common.Hash{3}))},
+	}
+
+	cursorsC := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, GetHttpSubmissionsPath(TEST_ROLLUP_ID), r.URL.Path)
+
+		after := r.URL.Query().Get("after")
+		cursorsC <- after
+
+		response := HttpSubmissionsResponse{Submissions: []HttpSubmission{}}
+		switch after {
+		case "":
+			response.Submissions = submissions[:2]
+		case "2":
+			response.Submissions = submissions[2:]
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	formatErrors := 0
+	source := NewHttpBlockSource(HttpBlockSourceConfig{
+		Url:       server.URL,
+		RollupIds: []uint32{TEST_ROLLUP_ID},
+	}, logging.NewNoopLogger())
+	source.eventListener = &SelectiveListener{OnFormatErrorCb: func() { formatErrors++ }}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(t, source.Start(ctx))
+
+	blockStream := source.GetBlockStream()
+	for _, expected := range []struct {
+		transactionId TransactionId
+		stateRoot     common.Hash
+	}{
+		{TransactionId{1}, common.Hash{1}},
+		{TransactionId{1}, common.Hash{2}},
+		{TransactionId{3}, common.Hash{3}},
+	} {
+		select {
+		case blockData := <-blockStream:
+			assert.Equal(t, uint32(TEST_ROLLUP_ID), blockData.RollupId)
+			assert.Equal(t, expected.transactionId, blockData.TransactionId)
+			assert.Equal(t, expected.stateRoot, blockData.Block.Root())
+		case <-time.After(time.Second):
+			t.Fatal("block timed out")
+		}
+	}
+
+	assert.True(t, source.IsReady())
+	assert.NoError(t, source.Close())
+	assert.False(t, source.IsReady())
+	assert.ErrorIs(t, source.Close(), AlreadyClosedError)
+
+	assert.Equal(t, "", <-cursorsC)
+	assert.Equal(t, "2", <-cursorsC)
+	assert.Equal(t, "3", <-cursorsC)
+	assert.Equal(t, 1, formatErrors)
+}
@@ -0,0 +1,240 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/near/borsh-go"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+const (
+	NEAR_RPC_TIMEOUT        = 10 * time.Second
+	NEAR_SUBMIT_METHOD_NAME = "submit"
+)
+
+var (
+	UnknownVerifierRollupIdError = errors.New("Rollup ID has no NEAR DA accounts")
+	TransactionFailedError       = errors.New("NEAR DA transaction did not succeed")
+	AccountMismatchError         = errors.New("NEAR DA transaction accounts mismatch")
+	SubmitCallNotFoundError      = errors.New("NEAR DA transaction has no submit call")
+	BlobNotFoundError            = errors.New("Blob not found in NEAR DA transaction")
+	CommitmentMismatchError      = errors.New("Blob commitment mismatch")
+	BlocksMismatchError          = errors.New("Blob blocks mismatch")
+	// Wraps failures to fetch the transaction, e.g. transport or NEAR RPC
+	// errors, after which the submission may still be valid
+	VerifierUnavailableError = errors.New("NEAR DA verifier unavailable")
+)
+
+// BlobVerifier checks a submission received from MQ against the DA layer
+type BlobVerifier interface {
+	VerifySubmission(ctx context.Context, rollupId uint32, transactionId TransactionId, submitRequest *SubmitRequest) error
+}
+
+type NearDaVerifierConfig struct {
+	RpcUrl                  string
+	RollupIdsToContractIds  map[uint32]string
+	RollupIdsToSubmitterIds map[uint32]string
+}
+
+// NearDaVerifier fetches the submission transaction from a NEAR RPC and
+// checks that the MQ blobs match the ones submitted on NEAR
+type NearDaVerifier struct {
+	config     NearDaVerifierConfig
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+var _ BlobVerifier = (*NearDaVerifier)(nil)
+
+func NewNearDaVerifier(config NearDaVerifierConfig, logger logging.Logger) *NearDaVerifier {
+	return &NearDaVerifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: NEAR_RPC_TIMEOUT},
+		logger:     logger,
+	}
+}
+
+func (v *NearDaVerifier) VerifySubmission(ctx context.Context, rollupId uint32, transactionId TransactionId, submitRequest *SubmitRequest) error {
+	contractId, exists := v.config.RollupIdsToContractIds[rollupId]
+	if !exists {
+		return UnknownVerifierRollupIdError
+	}
+
+	submitterId, exists := v.config.RollupIdsToSubmitterIds[rollupId]
+	if !exists {
+		return UnknownVerifierRollupIdError
+	}
+
+	nearRequest, err := v.fetchSubmitRequest(ctx, transactionId, submitterId, contractId)
+	if err != nil {
+		v.logger.Warn("Failed to fetch NEAR DA transaction", "rollupId", rollupId, "err", err)
+		return err
+	}
+
+	return verifyBlobs(submitRequest.Blobs, nearRequest.Blobs)
+}
+
+// Checks each blob commitment and that a blob with the same commitment
+// and the same blocks was submitted to NEAR
+func verifyBlobs(blobs []Blob, nearBlobs []Blob) error {
+	nearBlobsByCommitment := make(map[Commitment]Blob)
+	for _, nearBlob := range nearBlobs {
+		nearBlobsByCommitment[nearBlob.Commitment] = nearBlob
+	}
+
+	for _, blob := range blobs {
+		if core.ComputeBlobCommitment(blob.Data) != blob.Commitment {
+			return CommitmentMismatchError
+		}
+
+		nearBlob, exists := nearBlobsByCommitment[blob.Commitment]
+		if !exists {
+			return BlobNotFoundError
+		}
+
+		if core.ComputeBlobCommitment(nearBlob.Data) != nearBlob.Commitment {
+			return CommitmentMismatchError
+		}
+
+		if err := compareBlobBlocks(blob.Data, nearBlob.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func compareBlobBlocks(data, nearData []byte) error {
+	blocks, err := coretypes.DecodeRollupBlocks(data)
+	if err != nil {
+		return err
+	}
+
+	nearBlocks, err := coretypes.DecodeRollupBlocks(nearData)
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) != len(nearBlocks) {
+		return BlocksMismatchError
+	}
+
+	for i := range blocks {
+		if blocks[i].Header.Hash() != nearBlocks[i].Header.Hash() {
+			return BlocksMismatchError
+		}
+	}
+
+	return nil
+}
+
+type nearRpcRequest struct {
+	JsonRpc string   `json:"jsonrpc"`
+	Id      string   `json:"id"`
+	Method  string   `json:"method"`
+	Params  []string `json:"params"`
+}
+
+type nearRpcError struct {
+	Name    string          `json:"name"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type nearFunctionCallAction struct {
+	FunctionCall *struct {
+		MethodName string `json:"method_name"`
+		Args       []byte `json:"args"`
+	} `json:"FunctionCall"`
+}
+
+type nearTransactionStatus struct {
+	Status      map[string]json.RawMessage `json:"status"`
+	Transaction struct {
+		SignerId   string            `json:"signer_id"`
+		ReceiverId string            `json:"receiver_id"`
+		Actions    []json.RawMessage `json:"actions"`
+	} `json:"transaction"`
+}
+
+type nearRpcResponse struct {
+	Result *nearTransactionStatus `json:"result"`
+	Error  *nearRpcError          `json:"error"`
+}
+
+func (v *NearDaVerifier) fetchSubmitRequest(ctx context.Context, transactionId TransactionId, submitterId, contractId string) (*SubmitRequest, error) {
+	body, err := json.Marshal(nearRpcRequest{
+		JsonRpc: "2.0",
+		Id:      "nffl",
+		Method:  "tx",
+		Params:  []string{core.EncodeBase58(transactionId[:]), submitterId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.config.RpcUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", VerifierUnavailableError, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResponse nearRpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResponse); err != nil {
+		return nil, fmt.Errorf("%w: %w", VerifierUnavailableError, err)
+	}
+
+	// Includes unknown transactions, which may not have reached the RPC node yet
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("%w: NEAR RPC error %s: %s", VerifierUnavailableError, rpcResponse.Error.Name, rpcResponse.Error.Message)
+	}
+	if rpcResponse.Result == nil {
+		return nil, fmt.Errorf("%w: NEAR RPC returned no result", VerifierUnavailableError)
+	}
+
+	txStatus := rpcResponse.Result
+	if _, ok := txStatus.Status["SuccessValue"]; !ok {
+		return nil, TransactionFailedError
+	}
+
+	if txStatus.Transaction.SignerId != submitterId || txStatus.Transaction.ReceiverId != contractId {
+		return nil, AccountMismatchError
+	}
+
+	for _, rawAction := range txStatus.Transaction.Actions {
+		var action nearFunctionCallAction
+		// Actions without arguments are plain strings
+		if err := json.Unmarshal(rawAction, &action); err != nil {
+			continue
+		}
+
+		if action.FunctionCall == nil || action.FunctionCall.MethodName != NEAR_SUBMIT_METHOD_NAME {
+			continue
+		}
+
+		submitRequest := new(SubmitRequest)
+		if err := borsh.Deserialize(submitRequest, action.FunctionCall.Args); err != nil {
+			return nil, err
+		}
+
+		return submitRequest, nil
+	}
+
+	return nil, SubmitCallNotFoundError
+}
@@ -0,0 +1,185 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+const (
+	TEST_ROLLUP_ID    = 1
+	TEST_CONTRACT_ID  = "da.test.near"
+	TEST_SUBMITTER_ID = "relayer.test.near"
+)
+
+func createTestBlob(t *testing.T, stateRoots ...common.Hash) Blob {
+	blocks := make([]*types.Block, len(stateRoots))
+	for i, stateRoot := range stateRoots {
+		blocks[i] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(i)), Root: stateRoot})
+	}
+
+	data, err := rlp.EncodeToBytes(blocks)
+	assert.NoError(t, err)
+
+	return Blob{
+		Namespace:  Namespace{Version: 0, Id: 1},
+		Commitment: core.ComputeBlobCommitment(data),
+		Data:       data,
+	}
+}
+
+func createTestV1Blob(t *testing.T, stateRoots ...common.Hash) Blob {
+	blocks := make([]coretypes.RollupBlock, len(stateRoots))
+	for i, stateRoot := range stateRoots {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), GasPrice: big.NewInt(1), Gas: 21000, To: &common.Address{1}, Value: big.NewInt(1)})
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(i)), Root: stateRoot}).WithBody([]*types.Transaction{tx}, nil)
+		blocks[i] = coretypes.NewRollupBlock(block, nil)
+	}
+
+	data, err := coretypes.EncodeRollupBlocks(blocks)
+	assert.NoError(t, err)
+
+	return Blob{
+		Namespace:  Namespace{Version: 0, Id: 1},
+		Commitment: core.ComputeBlobCommitment(data),
+		Data:       data,
+	}
+}
+
+// Mocks the NEAR RPC tx method, serving the given submission for the expected transaction
+func createMockNearRpc(t *testing.T, transactionId TransactionId, submitRequest SubmitRequest) *httptest.Server {
+	args, err := borsh.Serialize(submitRequest)
+	assert.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request nearRpcRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, "tx", request.Method)
+
+		if request.Params[0] != core.EncodeBase58(transactionId[:]) || request.Params[1] != TEST_SUBMITTER_ID {
+			json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      request.Id,
+				"error":   map[string]any{"name": "HANDLER_ERROR", "code": -32000, "message": "Server error"},
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      request.Id,
+			"result": map[string]any{
+				"status": map[string]any{"SuccessValue": ""},
+				"transaction": map[string]any{
+					"signer_id":   TEST_SUBMITTER_ID,
+					"receiver_id": TEST_CONTRACT_ID,
+					"actions": []any{
+						map[string]any{
+							"FunctionCall": map[string]any{
+								"method_name": NEAR_SUBMIT_METHOD_NAME,
+								"args":        args,
+								"gas":         100000000000000,
+								"deposit":     "0",
+							},
+						},
+					},
+				},
+			},
+		})
+	}))
+}
+
+func createTestVerifier(rpcUrl string) *NearDaVerifier {
+	verifier := NewNearDaVerifier(NearDaVerifierConfig{
+		RpcUrl:                  rpcUrl,
+		RollupIdsToContractIds:  map[uint32]string{TEST_ROLLUP_ID: TEST_CONTRACT_ID},
+		RollupIdsToSubmitterIds: map[uint32]string{TEST_ROLLUP_ID: TEST_SUBMITTER_ID},
+	}, logging.NewNoopLogger())
+
+	return verifier
+}
+
+func TestNearDaVerifier(t *testing.T) {
+	transactionId := TransactionId{1, 2, 3}
+	blob := createTestBlob(t, common.Hash{1}, common.Hash{2})
+
+	server := createMockNearRpc(t, transactionId, SubmitRequest{Blobs: []Blob{blob}})
+	defer server.Close()
+
+	verifier := createTestVerifier(server.URL)
+	ctx := context.Background()
+
+	t.Run("valid submission", func(t *testing.T) {
+		err := verifier.VerifySubmission(ctx, TEST_ROLLUP_ID, transactionId, &SubmitRequest{Blobs: []Blob{blob}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown rollup", func(t *testing.T) {
+		err := verifier.VerifySubmission(ctx, TEST_ROLLUP_ID+1, transactionId, &SubmitRequest{Blobs: []Blob{blob}})
+		assert.ErrorIs(t, err, UnknownVerifierRollupIdError)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		err := verifier.VerifySubmission(ctx, TEST_ROLLUP_ID, TransactionId{4}, &SubmitRequest{Blobs: []Blob{blob}})
+		assert.ErrorIs(t, err, VerifierUnavailableError)
+	})
+
+	t.Run("unreachable rpc", func(t *testing.T) {
+		unreachableVerifier := createTestVerifier("http://127.0.0.1:1")
+
+		err := unreachableVerifier.VerifySubmission(ctx, TEST_ROLLUP_ID, transactionId, &SubmitRequest{Blobs: []Blob{blob}})
+		assert.ErrorIs(t, err, VerifierUnavailableError)
+	})
+
+	t.Run("invalid commitment", func(t *testing.T) {
+		tamperedBlob := blob
+		tamperedBlob.Commitment = Commitment{1}
+
+		err := verifier.VerifySubmission(ctx, TEST_ROLLUP_ID, transactionId, &SubmitRequest{Blobs: []Blob{tamperedBlob}})
+		assert.ErrorIs(t, err, CommitmentMismatchError)
+	})
+
+	t.Run("blob not submitted", func(t *testing.T) {
+		otherBlob := createTestBlob(t, common.Hash{3})
+
+		err := verifier.VerifySubmission(ctx, TEST_ROLLUP_ID, transactionId, &SubmitRequest{Blobs: []Blob{otherBlob}})
+		assert.ErrorIs(t, err, BlobNotFoundError)
+	})
+}
+
+func TestNearDaVerifierV1Blobs(t *testing.T) {
+	transactionId := TransactionId{1, 2, 3}
+	blob := createTestV1Blob(t, common.Hash{1}, common.Hash{2})
+
+	server := createMockNearRpc(t, transactionId, SubmitRequest{Blobs: []Blob{blob}})
+	defer server.Close()
+
+	verifier := createTestVerifier(server.URL)
+
+	err := verifier.VerifySubmission(context.Background(), TEST_ROLLUP_ID, transactionId, &SubmitRequest{Blobs: []Blob{blob}})
+	assert.NoError(t, err)
+
+	// Same blocks in the legacy format, which only differs in the encoding
+	blocks, err := coretypes.DecodeRollupBlocks(blob.Data)
+	assert.NoError(t, err)
+	legacyData, err := coretypes.EncodeLegacyRollupBlocks(blocks)
+	assert.NoError(t, err)
+	assert.NoError(t, compareBlobBlocks(legacyData, blob.Data))
+
+	otherBlob := createTestV1Blob(t, common.Hash{1}, common.Hash{3})
+	assert.ErrorIs(t, compareBlobBlocks(otherBlob.Data, blob.Data), BlocksMismatchError)
+}
@@ -5,14 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/near/borsh-go"
 	rmq "github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	// Deliveries verified concurrently across all queues
+	VERIFICATION_WORKERS = 8
+	// Delay before retrying the verification of a delivery that couldn't be
+	// verified yet
+	VERIFICATION_RETRY_DELAY = 5 * time.Second
+)
+
 var (
 	QueueExistsError = errors.New("Queue already exists")
 )
@@ -48,15 +54,25 @@ type QueuesListener struct {
 	receivedBlocksC    chan<- BlockData
 	queueDeliveryCs    map[uint32]<-chan rmq.Delivery
 	queueDeliveryMutex sync.Mutex
+	decoder            *submissionDecoder
+	verifying          bool
+	workers            chan struct{}
+	retryDelay         time.Duration
 
 	logger        logging.Logger
 	eventListener EventListener
 }
 
-func NewQueuesListener(receivedBlocksC chan<- BlockData, eventListener EventListener, logger logging.Logger) *QueuesListener {
+// blobVerifier is optional, and if set every submission is verified before
+// its blocks are forwarded
+func NewQueuesListener(receivedBlocksC chan<- BlockData, blobVerifier BlobVerifier, eventListener EventListener, logger logging.Logger) *QueuesListener {
 	listener := QueuesListener{
 		receivedBlocksC: receivedBlocksC,
 		queueDeliveryCs: make(map[uint32]<-chan rmq.Delivery),
+		decoder:         newSubmissionDecoder(blobVerifier, eventListener, logger),
+		verifying:       blobVerifier != nil,
+		workers:         make(chan struct{}, VERIFICATION_WORKERS),
+		retryDelay:      VERIFICATION_RETRY_DELAY,
 		logger:          logger,
 		eventListener:   eventListener,
 	}
@@ -96,62 +112,79 @@ func (l *QueuesListener) listen(ctx context.Context, rollupId uint32, rollupData
 			l.logger.Info("New delivery", "rollupId", rollupId)
 			l.eventListener.OnArrival()
 
-			publishPayload := new(PublishPayload)
-			err := borsh.Deserialize(publishPayload, d.Body)
-			if err != nil {
-				l.logger.Error("Error deserializing payload")
-				l.eventListener.OnFormatError()
-				d.Reject(false)
+			// Deliveries of a rollup are handled one at a time, so that its
+			// blocks are forwarded in order
+			l.handleDelivery(ctx, rollupId, d)
 
-				continue
-			}
+		case <-ctx.Done():
+			l.logger.Info("Consumer context canceled")
+			// TODO: some closing and canceling here
+			return
+		}
+	}
+}
 
-			submitRequest := new(SubmitRequest)
-			err = borsh.Deserialize(submitRequest, publishPayload.Data)
-			if err != nil {
-				l.logger.Error("Invalid blob", "d.Body", d.Body, "err", err)
-				l.eventListener.OnFormatError()
-				d.Reject(false)
+func (l *QueuesListener) handleDelivery(ctx context.Context, rollupId uint32, d rmq.Delivery) {
+	blocksData, err := l.decode(ctx, rollupId, d.Body)
+	if ctx.Err() != nil {
+		d.Nack(false, true)
+		return
+	}
+	if err != nil {
+		d.Reject(false)
+		return
+	}
 
-				continue
-			}
+	for _, blockData := range blocksData {
+		l.logger.Info(
+			"MQ Block",
+			"rollupId", rollupId,
+			"blockHeight", blockData.Block.Header().Number.Uint64(),
+			"transactionId", blockData.TransactionId,
+			"commitment", blockData.Commitment,
+			"listener", fmt.Sprintf("%p", l),
+		)
 
-			for _, blob := range submitRequest.Blobs {
-				var blocks []*types.Block
-				if err := rlp.DecodeBytes(blob.Data, &blocks); err != nil {
-					l.logger.Warn("Invalid block", "rollupId", rollupId, "err", err)
-					l.eventListener.OnFormatError()
-
-					continue
-				}
-
-				for _, block := range blocks {
-					blockData := BlockData{
-						RollupId:      rollupId,
-						TransactionId: publishPayload.TransactionId,
-						Commitment:    blob.Commitment,
-						Block:         block,
-					}
-
-					l.logger.Info(
-						"MQ Block",
-						"rollupId", rollupId,
-						"blockHeight", blockData.Block.Header().Number.Uint64(),
-						"transactionId", blockData.TransactionId,
-						"commitment", blockData.Commitment,
-						"listener", fmt.Sprintf("%p", l),
-					)
-					l.receivedBlocksC <- blockData
-				}
-			}
+		select {
+		case l.receivedBlocksC <- blockData:
+		case <-ctx.Done():
+			d.Nack(false, true)
+			return
+		}
+	}
 
-			l.logger.Info("Acking delivery", "rollupId", rollupId)
-			d.Ack(false)
+	l.logger.Info("Acking delivery", "rollupId", rollupId)
+	d.Ack(false)
+}
+
+// Decodes a delivery, retrying in place while the verifier is unavailable
+// rather than requeueing it behind later deliveries. The decoder bounds the
+// attempts per submission. Verification may take a NEAR RPC roundtrip, so
+// it's limited to VERIFICATION_WORKERS at once across all queues.
+func (l *QueuesListener) decode(ctx context.Context, rollupId uint32, body []byte) ([]BlockData, error) {
+	if !l.verifying {
+		return l.decoder.decode(ctx, rollupId, body)
+	}
 
+	for {
+		select {
+		case l.workers <- struct{}{}:
 		case <-ctx.Done():
-			l.logger.Info("Consumer context canceled")
-			// TODO: some closing and canceling here
-			return
+			return nil, ctx.Err()
+		}
+
+		blocksData, err := l.decoder.decode(ctx, rollupId, body)
+		<-l.workers
+
+		if !errors.Is(err, VerificationUnavailableError) {
+			return blocksData, err
+		}
+
+		l.logger.Info("Retrying delivery verification", "rollupId", rollupId)
+		select {
+		case <-time.After(l.retryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
 	}
 }
@@ -0,0 +1,139 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	rmq "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAcknowledger struct {
+	lock     sync.Mutex
+	acked    []uint64
+	requeued []uint64
+	rejected []uint64
+}
+
+func (a *mockAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *mockAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if requeue {
+		a.requeued = append(a.requeued, tag)
+	} else {
+		a.rejected = append(a.rejected, tag)
+	}
+	return nil
+}
+
+func (a *mockAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func (a *mockAcknowledger) settled() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return len(a.acked) + len(a.requeued) + len(a.rejected)
+}
+
+// Unavailable for the first failures verifications
+type flakyBlobVerifier struct {
+	lock     sync.Mutex
+	failures int
+}
+
+func (v *flakyBlobVerifier) VerifySubmission(ctx context.Context, rollupId uint32, transactionId TransactionId, submitRequest *SubmitRequest) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.failures > 0 {
+		v.failures--
+		return fmt.Errorf("%w: %w", VerifierUnavailableError, errors.New("timeout"))
+	}
+
+	return nil
+}
+
+func TestQueuesListenerVerificationOutcomes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier := &mockBlobVerifier{err: fmt.Errorf("%w: %w", VerifierUnavailableError, errors.New("timeout"))}
+	blocksC := make(chan BlockData, 10)
+	listener := NewQueuesListener(blocksC, verifier, &SelectiveListener{}, logging.NewNoopLogger())
+	listener.retryDelay = time.Millisecond
+
+	deliveriesC := make(chan rmq.Delivery)
+	assert.NoError(t, listener.Add(ctx, TEST_ROLLUP_ID, deliveriesC))
+
+	acknowledger := &mockAcknowledger{}
+	payload := createTestPayload(t, TransactionId{1}, createTestBlob(t, common.Hash{1}))
+
+	// Retried in place until the attempts cap, then dropped
+	deliveriesC <- rmq.Delivery{Acknowledger: acknowledger, DeliveryTag: 1, Body: payload}
+	assert.Eventually(t, func() bool { return acknowledger.settled() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []uint64{1}, acknowledger.rejected)
+	assert.Empty(t, acknowledger.requeued)
+	assert.Empty(t, blocksC)
+
+	verifier.err = CommitmentMismatchError
+	deliveriesC <- rmq.Delivery{Acknowledger: acknowledger, DeliveryTag: 2, Body: payload}
+	assert.Eventually(t, func() bool { return acknowledger.settled() == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []uint64{1, 2}, acknowledger.rejected)
+	assert.Empty(t, blocksC)
+
+	verifier.err = nil
+	deliveriesC <- rmq.Delivery{Acknowledger: acknowledger, DeliveryTag: 3, Body: payload}
+	assert.Eventually(t, func() bool { return acknowledger.settled() == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []uint64{3}, acknowledger.acked)
+	assert.Len(t, blocksC, 1)
+}
+
+func TestQueuesListenerKeepsOrder(t *testing.T) {
+	for name, verifier := range map[string]BlobVerifier{
+		"without verifier":     nil,
+		"verification retried": &flakyBlobVerifier{failures: VERIFICATION_MAX_ATTEMPTS - 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			blocksC := make(chan BlockData, 10)
+			listener := NewQueuesListener(blocksC, verifier, &SelectiveListener{}, logging.NewNoopLogger())
+			listener.retryDelay = time.Millisecond
+
+			deliveriesC := make(chan rmq.Delivery, 10)
+			assert.NoError(t, listener.Add(ctx, TEST_ROLLUP_ID, deliveriesC))
+
+			acknowledger := &mockAcknowledger{}
+			for i := byte(1); i <= 5; i++ {
+				payload := createTestPayload(t, TransactionId{i}, createTestBlob(t, common.Hash{i}))
+				deliveriesC <- rmq.Delivery{Acknowledger: acknowledger, DeliveryTag: uint64(i), Body: payload}
+			}
+
+			assert.Eventually(t, func() bool { return acknowledger.settled() == 5 }, time.Second, time.Millisecond)
+			assert.Equal(t, []uint64{1, 2, 3, 4, 5}, acknowledger.acked)
+
+			for i := byte(1); i <= 5; i++ {
+				blockData := <-blocksC
+				assert.Equal(t, TransactionId{i}, blockData.TransactionId)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/near/borsh-go"
+
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+const (
+	// Times a submission is retried while the verifier is unavailable before
+	// it's dropped
+	VERIFICATION_MAX_ATTEMPTS = 5
+)
+
+var (
+	InvalidPayloadError     = errors.New("Invalid submission payload")
+	VerificationFailedError = errors.New("Submission verification failed")
+	// The submission couldn't be checked yet and should be retried later
+	VerificationUnavailableError = errors.New("Submission verification unavailable")
+)
+
+// Decodes indexer submissions into blocks, which is shared by all block
+// sources as they carry the same payload
+type submissionDecoder struct {
+	blobVerifier  BlobVerifier
+	eventListener EventListener
+	logger        logging.Logger
+
+	attempts     map[TransactionId]int
+	attemptsLock sync.Mutex
+}
+
+func newSubmissionDecoder(blobVerifier BlobVerifier, eventListener EventListener, logger logging.Logger) *submissionDecoder {
+	return &submissionDecoder{
+		blobVerifier:  blobVerifier,
+		eventListener: eventListener,
+		logger:        logger,
+		attempts:      make(map[TransactionId]int),
+	}
+}
+
+// Decodes a borsh-serialized PublishPayload and, if a verifier is set, checks
+// it against NEAR DA. Blobs whose data isn't a list of blocks are skipped.
+// Returns VerificationUnavailableError if the verifier couldn't reach NEAR, up
+// to VERIFICATION_MAX_ATTEMPTS times per transaction, and
+// VerificationFailedError once the submission is known to be invalid.
+func (d *submissionDecoder) decode(ctx context.Context, rollupId uint32, body []byte) ([]BlockData, error) {
+	publishPayload := new(PublishPayload)
+	if err := borsh.Deserialize(publishPayload, body); err != nil {
+		d.logger.Error("Error deserializing payload", "rollupId", rollupId, "err", err)
+		d.eventListener.OnFormatError()
+		return nil, InvalidPayloadError
+	}
+
+	submitRequest := new(SubmitRequest)
+	if err := borsh.Deserialize(submitRequest, publishPayload.Data); err != nil {
+		d.logger.Error("Invalid blob", "rollupId", rollupId, "err", err)
+		d.eventListener.OnFormatError()
+		return nil, InvalidPayloadError
+	}
+
+	if d.blobVerifier != nil {
+		err := d.verify(ctx, rollupId, publishPayload.TransactionId, submitRequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocksData := make([]BlockData, 0)
+	for _, blob := range submitRequest.Blobs {
+		blocks, err := coretypes.DecodeRollupBlocks(blob.Data)
+		if err != nil {
+			d.logger.Warn("Invalid block", "rollupId", rollupId, "err", err)
+			d.eventListener.OnFormatError()
+
+			continue
+		}
+
+		for _, block := range blocks {
+			blocksData = append(blocksData, BlockData{
+				RollupId:      rollupId,
+				TransactionId: publishPayload.TransactionId,
+				Commitment:    blob.Commitment,
+				Block:         block.Block(),
+				Receipts:      block.Receipts,
+			})
+		}
+	}
+
+	return blocksData, nil
+}
+
+func (d *submissionDecoder) verify(ctx context.Context, rollupId uint32, transactionId TransactionId, submitRequest *SubmitRequest) error {
+	err := d.blobVerifier.VerifySubmission(ctx, rollupId, transactionId, submitRequest)
+	if err == nil {
+		d.attemptsLock.Lock()
+		delete(d.attempts, transactionId)
+		d.attemptsLock.Unlock()
+
+		return nil
+	}
+
+	if errors.Is(err, VerifierUnavailableError) {
+		d.attemptsLock.Lock()
+		d.attempts[transactionId]++
+		attempts := d.attempts[transactionId]
+		if attempts >= VERIFICATION_MAX_ATTEMPTS {
+			delete(d.attempts, transactionId)
+		}
+		d.attemptsLock.Unlock()
+
+		if attempts < VERIFICATION_MAX_ATTEMPTS {
+			d.logger.Warn("Submission verification unavailable, retrying later", "rollupId", rollupId, "transactionId", transactionId, "attempt", attempts, "err", err)
+			return VerificationUnavailableError
+		}
+	}
+
+	d.logger.Error("Submission verification failed", "rollupId", rollupId, "transactionId", transactionId, "err", err)
+	d.eventListener.OnVerificationFailure()
+	return VerificationFailedError
+}
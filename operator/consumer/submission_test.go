@@ -0,0 +1,91 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+)
+
+func TestSubmissionDecoderFormats(t *testing.T) {
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1), To: &common.Address{1}, Value: big.NewInt(1)})
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{}}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Root: common.Hash{3}}).WithBody([]*types.Transaction{tx}, nil)
+
+	data, err := coretypes.EncodeRollupBlocks([]coretypes.RollupBlock{coretypes.NewRollupBlock(block, []*types.Receipt{receipt})})
+	assert.NoError(t, err)
+
+	fullBlob := Blob{
+		Namespace:  Namespace{Version: 0, Id: 1},
+		Commitment: core.ComputeBlobCommitment(data),
+		Data:       data,
+	}
+
+	decoder := &submissionDecoder{eventListener: &SelectiveListener{}, logger: logging.NewNoopLogger()}
+
+	blocksData, err := decoder.decode(context.Background(), TEST_ROLLUP_ID, createTestPayload(t, TransactionId{1}, createTestBlob(t, common.Hash{1}), fullBlob))
+	assert.NoError(t, err)
+	assert.Len(t, blocksData, 2)
+
+	assert.Equal(t, common.Hash{1}, blocksData[0].Block.Root())
+	assert.Empty(t, blocksData[0].Block.Transactions())
+	assert.Empty(t, blocksData[0].Receipts)
+
+	assert.Equal(t, block.Hash(), blocksData[1].Block.Hash())
+	assert.Equal(t, tx.Hash(), blocksData[1].Block.Transactions()[0].Hash())
+	assert.Len(t, blocksData[1].Receipts, 1)
+	assert.Equal(t, fullBlob.Commitment, blocksData[1].Commitment)
+}
+
+type mockBlobVerifier struct {
+	err error
+}
+
+func (v *mockBlobVerifier) VerifySubmission(ctx context.Context, rollupId uint32, transactionId TransactionId, submitRequest *SubmitRequest) error {
+	return v.err
+}
+
+func TestSubmissionDecoderVerificationErrors(t *testing.T) {
+	verifier := &mockBlobVerifier{}
+	decoder := newSubmissionDecoder(verifier, &SelectiveListener{}, logging.NewNoopLogger())
+	payload := createTestPayload(t, TransactionId{1}, createTestBlob(t, common.Hash{1}))
+
+	t.Run("unavailable is retried up to the attempts cap", func(t *testing.T) {
+		verifier.err = fmt.Errorf("%w: %w", VerifierUnavailableError, errors.New("timeout"))
+
+		for i := 1; i < VERIFICATION_MAX_ATTEMPTS; i++ {
+			_, err := decoder.decode(context.Background(), TEST_ROLLUP_ID, payload)
+			assert.ErrorIs(t, err, VerificationUnavailableError)
+		}
+
+		_, err := decoder.decode(context.Background(), TEST_ROLLUP_ID, payload)
+		assert.ErrorIs(t, err, VerificationFailedError)
+	})
+
+	t.Run("success clears attempts", func(t *testing.T) {
+		verifier.err = fmt.Errorf("%w: %w", VerifierUnavailableError, errors.New("timeout"))
+		_, err := decoder.decode(context.Background(), TEST_ROLLUP_ID, payload)
+		assert.ErrorIs(t, err, VerificationUnavailableError)
+
+		verifier.err = nil
+		_, err = decoder.decode(context.Background(), TEST_ROLLUP_ID, payload)
+		assert.NoError(t, err)
+		assert.Empty(t, decoder.attempts)
+	})
+
+	t.Run("mismatch fails immediately", func(t *testing.T) {
+		verifier.err = CommitmentMismatchError
+
+		_, err := decoder.decode(context.Background(), TEST_ROLLUP_ID, payload)
+		assert.ErrorIs(t, err, VerificationFailedError)
+	})
+}
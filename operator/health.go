@@ -0,0 +1,292 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/nodeapi"
+
+	"github.com/Nuffle-Labs/nffl/operator/attestor"
+)
+
+const (
+	HEALTH_CHECK_INTERVAL = 5 * time.Second
+	// Time without new rollup headers after which a rollup is degraded or down
+	ROLLUP_HEADER_DEGRADED_AGE = 30 * time.Second
+	ROLLUP_HEADER_DOWN_AGE     = 2 * time.Minute
+	// Aggregator resend queue sizes after which it's degraded or down
+	RESEND_QUEUE_DEGRADED_SIZE = 10
+	RESEND_QUEUE_DOWN_SIZE     = 100
+)
+
+type ServiceHealth int
+
+const (
+	ServiceInitializing ServiceHealth = iota
+	ServiceUp
+	ServiceDegraded
+	ServiceDown
+)
+
+func (health ServiceHealth) String() string {
+	switch health {
+	case ServiceInitializing:
+		return "initializing"
+	case ServiceUp:
+		return "up"
+	case ServiceDegraded:
+		return "degraded"
+	case ServiceDown:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+type HealthCheck = func() ServiceHealth
+
+type healthService struct {
+	id     string
+	check  HealthCheck
+	health ServiceHealth
+}
+
+// HealthMonitor periodically runs the registered service checks and reports
+// each service status, as well as the overall node health, to the node API
+type HealthMonitor struct {
+	nodeApi  *nodeapi.NodeApi
+	services []*healthService
+	logger   sdklogging.Logger
+}
+
+func NewHealthMonitor(nodeApi *nodeapi.NodeApi, logger sdklogging.Logger) *HealthMonitor {
+	return &HealthMonitor{
+		nodeApi:  nodeApi,
+		services: make([]*healthService, 0),
+		logger:   logger,
+	}
+}
+
+// Should only be called before Start
+func (monitor *HealthMonitor) RegisterService(id, name, description string, check HealthCheck) {
+	monitor.services = append(monitor.services, &healthService{
+		id:     id,
+		check:  check,
+		health: ServiceInitializing,
+	})
+
+	monitor.nodeApi.RegisterNewService(id, name, description, toNodeApiServiceStatus(ServiceInitializing))
+}
+
+func (monitor *HealthMonitor) Start(ctx context.Context) {
+	monitor.nodeApi.UpdateHealth(nodeapi.PartiallyHealthy)
+
+	go func() {
+		ticker := time.NewTicker(HEALTH_CHECK_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				monitor.update()
+			}
+		}
+	}()
+}
+
+// Runs all service checks and updates the node API accordingly
+func (monitor *HealthMonitor) update() nodeapi.NodeHealth {
+	healths := make([]ServiceHealth, len(monitor.services))
+
+	for i, service := range monitor.services {
+		health := service.check()
+		healths[i] = health
+
+		if health != service.health {
+			if health == ServiceUp {
+				monitor.logger.Info("Service health changed", "service", service.id, "from", service.health, "to", health)
+			} else {
+				monitor.logger.Warn("Service health changed", "service", service.id, "from", service.health, "to", health)
+			}
+
+			service.health = health
+		}
+
+		err := monitor.nodeApi.UpdateServiceStatus(service.id, toNodeApiServiceStatus(health))
+		if err != nil {
+			monitor.logger.Error("Error updating service status", "service", service.id, "err", err)
+		}
+	}
+
+	nodeHealth := aggregateNodeHealth(healths)
+	monitor.nodeApi.UpdateHealth(nodeHealth)
+
+	return nodeHealth
+}
+
+// The node is unhealthy if any service is down, and partially healthy if any
+// service is degraded or still initializing
+func aggregateNodeHealth(healths []ServiceHealth) nodeapi.NodeHealth {
+	nodeHealth := nodeapi.Healthy
+
+	for _, health := range healths {
+		switch health {
+		case ServiceDown:
+			return nodeapi.Unhealthy
+		case ServiceDegraded, ServiceInitializing:
+			nodeHealth = nodeapi.PartiallyHealthy
+		}
+	}
+
+	return nodeHealth
+}
+
+func toNodeApiServiceStatus(health ServiceHealth) nodeapi.ServiceStatus {
+	switch health {
+	case ServiceUp:
+		return nodeapi.ServiceStatusUp
+	case ServiceDegraded, ServiceInitializing:
+		// The node API spec has no degraded service status, so degraded
+		// services are reported as initializing, served as partially healthy
+		return nodeapi.ServiceStatusInitializing
+	default:
+		return nodeapi.ServiceStatusDown
+	}
+}
+
+// Wraps a check so it's reported as initializing until it's first up
+func initializingUntilUp(check HealthCheck) HealthCheck {
+	wasUp := false
+
+	return func() ServiceHealth {
+		health := check()
+		if health == ServiceUp {
+			wasUp = true
+		}
+
+		if !wasUp {
+			return ServiceInitializing
+		}
+
+		return health
+	}
+}
+
+func checkRollupHeaders(rollupStatus attestor.RollupStatus, exists bool, now time.Time) ServiceHealth {
+	if !exists {
+		return ServiceInitializing
+	}
+
+	if !rollupStatus.IsSubscribed {
+		return ServiceDown
+	}
+
+	if rollupStatus.LastHeaderAt.IsZero() {
+		return ServiceInitializing
+	}
+
+	age := now.Sub(rollupStatus.LastHeaderAt)
+	if age > ROLLUP_HEADER_DOWN_AGE {
+		return ServiceDown
+	}
+
+	if age > ROLLUP_HEADER_DEGRADED_AGE {
+		return ServiceDegraded
+	}
+
+	return ServiceUp
+}
+
+func checkResendQueue(size int) ServiceHealth {
+	if size >= RESEND_QUEUE_DOWN_SIZE {
+		return ServiceDown
+	}
+
+	if size >= RESEND_QUEUE_DEGRADED_SIZE {
+		return ServiceDegraded
+	}
+
+	return ServiceUp
+}
+
+func boolToServiceHealth(isUp bool) ServiceHealth {
+	if isUp {
+		return ServiceUp
+	}
+
+	return ServiceDown
+}
+
+func (o *Operator) registerHealthServices(monitor *HealthMonitor) {
+	monitor.RegisterService(
+		"near-da-indexer",
+		"NEAR DA indexer",
+		"Connection to the source of rollup blocks posted to NEAR DA",
+		initializingUntilUp(func() ServiceHealth {
+			return boolToServiceHealth(o.attestor.GetStatus().IsDaSourceReady)
+		}),
+	)
+
+	rollupIds := make([]uint32, 0, len(o.config.RollupIdsToRpcUrls))
+	for rollupId := range o.config.RollupIdsToRpcUrls {
+		rollupIds = append(rollupIds, rollupId)
+	}
+	sort.Slice(rollupIds, func(i, j int) bool { return rollupIds[i] < rollupIds[j] })
+
+	for _, rollupId := range rollupIds {
+		rollupId := rollupId
+
+		monitor.RegisterService(
+			fmt.Sprintf("rollup-%d", rollupId),
+			fmt.Sprintf("Rollup %d headers", rollupId),
+			fmt.Sprintf("Header subscription and freshness for rollup %d", rollupId),
+			func() ServiceHealth {
+				rollupStatus, exists := o.attestor.GetStatus().Rollups[rollupId]
+				return checkRollupHeaders(rollupStatus, exists, time.Now())
+			},
+		)
+	}
+
+	monitor.RegisterService(
+		"aggregator",
+		"Aggregator",
+		"Connection to the aggregator RPC server",
+		initializingUntilUp(func() ServiceHealth {
+			return boolToServiceHealth(o.aggregatorRpcClient.IsConnected())
+		}),
+	)
+
+	monitor.RegisterService(
+		"aggregator-resend-queue",
+		"Aggregator resend queue",
+		"Messages waiting to be resent to the aggregator",
+		func() ServiceHealth {
+			return checkResendQueue(o.aggregatorRpcClient.GetResendQueueSize())
+		},
+	)
+
+	monitor.RegisterService(
+		"avs-subscriptions",
+		"AVS event subscriptions",
+		"Subscriptions to checkpoint tasks and operator set updates",
+		initializingUntilUp(func() ServiceHealth {
+			return boolToServiceHealth(o.avsManager.IsSubscribed())
+		}),
+	)
+
+	if o.config.EnableMetrics {
+		monitor.RegisterService(
+			"metrics",
+			"Metrics server",
+			"Prometheus metrics server",
+			func() ServiceHealth {
+				return boolToServiceHealth(!o.metricsFailed.Load())
+			},
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/nodeapi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/operator/attestor"
+)
+
+func TestAggregateNodeHealth(t *testing.T) {
+	assert.Equal(t, nodeapi.Healthy, aggregateNodeHealth([]ServiceHealth{}))
+	assert.Equal(t, nodeapi.Healthy, aggregateNodeHealth([]ServiceHealth{ServiceUp, ServiceUp}))
+	assert.Equal(t, nodeapi.PartiallyHealthy, aggregateNodeHealth([]ServiceHealth{ServiceUp, ServiceInitializing}))
+	assert.Equal(t, nodeapi.PartiallyHealthy, aggregateNodeHealth([]ServiceHealth{ServiceDegraded, ServiceUp}))
+	assert.Equal(t, nodeapi.Unhealthy, aggregateNodeHealth([]ServiceHealth{ServiceDegraded, ServiceDown, ServiceUp}))
+}
+
+func TestToNodeApiServiceStatus(t *testing.T) {
+	assert.Equal(t, nodeapi.ServiceStatusUp, toNodeApiServiceStatus(ServiceUp))
+	assert.Equal(t, nodeapi.ServiceStatusInitializing, toNodeApiServiceStatus(ServiceDegraded))
+	assert.Equal(t, nodeapi.ServiceStatusInitializing, toNodeApiServiceStatus(ServiceInitializing))
+	assert.Equal(t, nodeapi.ServiceStatusDown, toNodeApiServiceStatus(ServiceDown))
+}
+
+func TestCheckRollupHeaders(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, ServiceInitializing, checkRollupHeaders(attestor.RollupStatus{}, false, now))
+	assert.Equal(t, ServiceInitializing, checkRollupHeaders(attestor.RollupStatus{IsSubscribed: true}, true, now))
+	assert.Equal(t, ServiceDown, checkRollupHeaders(attestor.RollupStatus{IsSubscribed: false, LastHeaderAt: now}, true, now))
+	assert.Equal(t, ServiceUp, checkRollupHeaders(attestor.RollupStatus{IsSubscribed: true, LastHeaderAt: now.Add(-time.Second)}, true, now))
+	assert.Equal(t, ServiceDegraded, checkRollupHeaders(attestor.RollupStatus{IsSubscribed: true, LastHeaderAt: now.Add(-ROLLUP_HEADER_DEGRADED_AGE - time.Second)}, true, now))
+	assert.Equal(t, ServiceDown, checkRollupHeaders(attestor.RollupStatus{IsSubscribed: true, LastHeaderAt: now.Add(-ROLLUP_HEADER_DOWN_AGE - time.Second)}, true, now))
+}
+
+func TestCheckResendQueue(t *testing.T) {
+	assert.Equal(t, ServiceUp, checkResendQueue(0))
+	assert.Equal(t, ServiceDegraded, checkResendQueue(RESEND_QUEUE_DEGRADED_SIZE))
+	assert.Equal(t, ServiceDown, checkResendQueue(RESEND_QUEUE_DOWN_SIZE))
+}
+
+func TestHealthMonitor(t *testing.T) {
+	logger := sdklogging.NewNoopLogger()
+	monitor := NewHealthMonitor(nodeapi.NewNodeApi(AVS_NAME, SEM_VER, "", logger), logger)
+
+	connected := false
+	queueSize := 0
+	monitor.RegisterService("connection", "Connection", "", initializingUntilUp(func() ServiceHealth {
+		return boolToServiceHealth(connected)
+	}))
+	monitor.RegisterService("queue", "Queue", "", func() ServiceHealth {
+		return checkResendQueue(queueSize)
+	})
+
+	assert.Equal(t, nodeapi.PartiallyHealthy, monitor.update())
+
+	connected = true
+	assert.Equal(t, nodeapi.Healthy, monitor.update())
+
+	queueSize = RESEND_QUEUE_DEGRADED_SIZE
+	assert.Equal(t, nodeapi.PartiallyHealthy, monitor.update())
+
+	connected = false
+	assert.Equal(t, nodeapi.Unhealthy, monitor.update())
+	assert.Equal(t, ServiceDown, monitor.services[0].health)
+	assert.Equal(t, ServiceDegraded, monitor.services[1].health)
+}
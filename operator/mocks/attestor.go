@@ -75,6 +75,13 @@ func (mockAttestor *MockAttestor) GetSignedRootC() <-chan messages.SignedStateRo
 	return mockAttestor.signedRootC
 }
 
+func (mockAttestor *MockAttestor) GetStatus() attestor.Status {
+	return attestor.Status{
+		IsDaSourceReady: mockAttestor.consumer.IsReady(),
+		Rollups:         make(map[uint32]attestor.RollupStatus),
+	}
+}
+
 func (mockAttestor *MockAttestor) MockGetConsumer() *MockConsumer {
 	return mockAttestor.consumer
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/Nuffle-Labs/nffl/operator/consumer"
-	rmq "github.com/rabbitmq/amqp091-go"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type MockConsumer struct {
@@ -16,13 +16,21 @@ func NewMockConsumer() *MockConsumer {
 		blockReceivedC: make(chan consumer.BlockData),
 	}
 }
-func (c *MockConsumer) Reconnect(addr string, ctx context.Context) {}
-func (c *MockConsumer) ResetChannel(conn *rmq.Connection, ctx context.Context) bool {
-	return true
+
+var _ consumer.DaBlockSource = (*MockConsumer)(nil)
+
+func (c *MockConsumer) EnableMetrics(registry *prometheus.Registry) error {
+	return nil
+}
+func (c *MockConsumer) Start(ctx context.Context) error {
+	return nil
 }
 func (c *MockConsumer) Close() error {
 	return nil
 }
+func (c *MockConsumer) IsReady() bool {
+	return true
+}
 func (c *MockConsumer) GetBlockStream() <-chan consumer.BlockData {
 	return c.blockReceivedC
 }
@@ -5,6 +5,7 @@
 //
 //	mockgen -destination=./mocks/rpc_client.go -package=mocks github.com/Nuffle-Labs/nffl/operator AggregatorRpcClienter
 //
+
 // Package mocks is a generated GoMock package.
 package mocks
 
@@ -68,6 +69,34 @@ func (mr *MockAggregatorRpcClienterMockRecorder) GetAggregatedCheckpointMessages
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedCheckpointMessages", reflect.TypeOf((*MockAggregatorRpcClienter)(nil).GetAggregatedCheckpointMessages), arg0, arg1)
 }
 
+// GetResendQueueSize mocks base method.
+func (m *MockAggregatorRpcClienter) GetResendQueueSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResendQueueSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetResendQueueSize indicates an expected call of GetResendQueueSize.
+func (mr *MockAggregatorRpcClienterMockRecorder) GetResendQueueSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResendQueueSize", reflect.TypeOf((*MockAggregatorRpcClienter)(nil).GetResendQueueSize))
+}
+
+// IsConnected mocks base method.
+func (m *MockAggregatorRpcClienter) IsConnected() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConnected")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConnected indicates an expected call of IsConnected.
+func (mr *MockAggregatorRpcClienterMockRecorder) IsConnected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConnected", reflect.TypeOf((*MockAggregatorRpcClienter)(nil).IsConnected))
+}
+
 // SendSignedCheckpointTaskResponseToAggregator mocks base method.
 func (m *MockAggregatorRpcClienter) SendSignedCheckpointTaskResponseToAggregator(arg0 *messages.SignedCheckpointTaskResponse) {
 	m.ctrl.T.Helper()
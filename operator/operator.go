@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
@@ -42,6 +43,8 @@ type Operator struct {
 	config    optypes.NodeConfig
 	logger    sdklogging.Logger
 	ethClient safeclient.SafeClient
+	// persists event cursors of the ws client, nil if not configured
+	cursorStore *safeclient.FileCursorStore
 	// they are only used for registration, so we should make a special registration package
 	// this way, auditing this operator code makes it obvious that operators don't need to
 	// write to the chain during the course of their normal operations
@@ -51,6 +54,7 @@ type Operator struct {
 	listener   OperatorEventListener
 
 	nodeApi          *nodeapi.NodeApi
+	metricsFailed    atomic.Bool
 	blsKeypair       *bls.KeyPair
 	operatorId       eigentypes.OperatorId
 	operatorAddr     common.Address
@@ -120,7 +124,19 @@ func NewOperatorFromConfig(c optypes.NodeConfig) (*Operator, error) {
 		return nil, err
 	}
 
-	ethWsClient, err := core.CreateEthClientWithCollector(id, c.EthWsUrl, c.EnableMetrics, reg, logger)
+	ethWsClientOpts := []safeclient.SafeEthClientOption{safeclient.WithLogConfirmations(c.EthLogConfirmations)}
+	var cursorStore *safeclient.FileCursorStore
+	if c.EthEventCursorPath != "" {
+		cursorStore, err = safeclient.NewFileCursorStore(c.EthEventCursorPath)
+		if err != nil {
+			logger.Error("Cannot create event cursor store", "err", err)
+			return nil, err
+		}
+
+		ethWsClientOpts = append(ethWsClientOpts, safeclient.WithCursorStore(cursorStore))
+	}
+
+	ethWsClient, err := core.CreateEthClientWithCollector(id, c.EthWsUrl, c.EnableMetrics, reg, logger, ethWsClientOpts...)
 	if err != nil {
 		logger.Error("Cannot create ws ethclient", "err", err)
 		return nil, err
@@ -211,6 +227,7 @@ func NewOperatorFromConfig(c optypes.NodeConfig) (*Operator, error) {
 		config:                     c,
 		logger:                     logger,
 		ethClient:                  ethHttpClient,
+		cursorStore:                cursorStore,
 		metricsReg:                 reg,
 		metrics:                    optionalMetrics,
 		listener:                   &SelectiveOperatorListener{},
@@ -282,6 +299,10 @@ func (o *Operator) Start(ctx context.Context) error {
 
 	if o.config.EnableNodeApi {
 		o.nodeApi.Start()
+
+		healthMonitor := NewHealthMonitor(o.nodeApi, o.logger)
+		o.registerHealthServices(healthMonitor)
+		healthMonitor.Start(ctx)
 	}
 
 	var metricsErrChan <-chan error
@@ -313,9 +334,10 @@ func (o *Operator) Start(ctx context.Context) error {
 			return o.Close()
 
 		case err := <-metricsErrChan:
-			// TODO(samlaf); we should also register the service as unhealthy in the node api
-			// https://eigen.nethermind.io/docs/spec/api/
-			o.logger.Fatal("Error in metrics server", "err", err)
+			// Reported as unhealthy through the node API
+			o.logger.Error("Error in metrics server", "err", err)
+			o.metricsFailed.Store(true)
+			metricsErrChan = nil
 			continue
 
 		case signedStateRootUpdateMessage := <-signedRootsC:
@@ -328,6 +350,11 @@ func (o *Operator) Start(ctx context.Context) error {
 				return o.Close()
 			}
 
+			if checkpointTaskCreatedEvent.Raw.Removed {
+				o.logger.Warn("Checkpoint task was reorged out", "taskIndex", checkpointTaskCreatedEvent.TaskIndex, "block", checkpointTaskCreatedEvent.Raw.BlockNumber)
+				continue
+			}
+
 			go o.ProcessCheckpointTask(checkpointTaskCreatedEvent)
 			continue
 
@@ -356,6 +383,10 @@ func (o *Operator) Close() error {
 
 	o.ethClient.Close()
 
+	if o.cursorStore != nil {
+		return o.cursorStore.Close()
+	}
+
 	return nil
 }
 
@@ -141,7 +141,7 @@ func TestOperator(t *testing.T) {
 			<-quit
 			return nil
 		}), nil)
-		mockSubscriber.EXPECT().SubscribeToOperatorSetUpdates(avsManager.operatorSetUpdateChan).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		mockSubscriber.EXPECT().SubscribeToOperatorSetUpdates(gomock.Any(), avsManager.operatorSetUpdateChan).Return(event.NewSubscription(func(quit <-chan struct{}) error {
 			// loop forever
 			<-quit
 			return nil
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/rpc"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -28,6 +29,8 @@ type AggregatorRpcClienter interface {
 	SendSignedStateRootUpdateToAggregator(signedStateRootUpdateMessage *messages.SignedStateRootUpdateMessage)
 	SendSignedOperatorSetUpdateToAggregator(signedOperatorSetUpdateMessage *messages.SignedOperatorSetUpdateMessage)
 	GetAggregatedCheckpointMessages(fromTimestamp, toTimestamp uint64) (*messages.CheckpointMessages, error)
+	IsConnected() bool
+	GetResendQueueSize() int
 }
 
 type unsentRpcMessage struct {
@@ -46,7 +49,9 @@ type AggregatorRpcClient struct {
 
 	unsentMessagesLock sync.Mutex
 	unsentMessages     []unsentRpcMessage
-	resendTicker       *time.Ticker
+	// Mirrors len(unsentMessages) so it can be read while resending
+	resendQueueSize atomic.Int64
+	resendTicker    *time.Ticker
 
 	logger   logging.Logger
 	listener RpcClientEventListener
@@ -140,6 +145,17 @@ func (c *AggregatorRpcClient) InitializeClientIfNotExist() error {
 	return c.dialAggregatorRpcClient()
 }
 
+func (c *AggregatorRpcClient) IsConnected() bool {
+	c.rpcClientLock.RLock()
+	defer c.rpcClientLock.RUnlock()
+
+	return c.rpcClient != nil
+}
+
+func (c *AggregatorRpcClient) GetResendQueueSize() int {
+	return int(c.resendQueueSize.Load())
+}
+
 func isShutdownOrNetworkError(err error) bool {
 	if err == rpc.ErrShutdown {
 		return true
@@ -280,6 +296,7 @@ func (c *AggregatorRpcClient) tryResendFromDeque() {
 
 	c.unsentMessages = c.unsentMessages[:errorPos]
 	c.listener.ObserveResendQueueSize(len(c.unsentMessages))
+	c.resendQueueSize.Store(int64(len(c.unsentMessages)))
 }
 
 func (c *AggregatorRpcClient) sendOperatorMessage(sendCb func() error, message interface{}) {
@@ -292,6 +309,7 @@ func (c *AggregatorRpcClient) sendOperatorMessage(sendCb func() error, message i
 
 		c.unsentMessages = append(c.unsentMessages, unsentRpcMessage{Message: message})
 		c.listener.ObserveResendQueueSize(len(c.unsentMessages))
+		c.resendQueueSize.Store(int64(len(c.unsentMessages)))
 	}
 
 	if c.rpcClient == nil {
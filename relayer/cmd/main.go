@@ -35,14 +35,12 @@ func main() {
 					Usage:    "Connect to the indicated RPC",
 				},
 				cli.StringFlag{
-					Name:     "da-account-id",
-					Required: true,
-					Usage:    "Publish block data to the indicated NEAR account",
+					Name:  "da-account-id",
+					Usage: "Publish block data to the indicated NEAR account, for the NEAR DA backend",
 				},
 				cli.StringFlag{
-					Name:     "key-path",
-					Required: true,
-					Usage:    "Path to NEAR account's key file",
+					Name:  "key-path",
+					Usage: "Path to NEAR account's key file, for the NEAR DA backend",
 				},
 				cli.StringFlag{
 					Name:  "network",
@@ -54,6 +52,74 @@ func main() {
 					Value: "",
 					Usage: "Metrics scrape address",
 				},
+				cli.BoolFlag{
+					Name:  "full-blocks",
+					Usage: "Submit full blocks with transactions instead of headers only",
+				},
+				cli.BoolFlag{
+					Name:  "include-receipts",
+					Usage: "Include transaction receipts in submitted full blocks",
+				},
+				cli.StringFlag{
+					Name:  "cursor-path",
+					Value: "",
+					Usage: "File storing the last submitted height, to backfill missed heights on restart",
+				},
+				cli.StringFlag{
+					Name:  "da-backend",
+					Value: "near",
+					Usage: "DA backend to submit blocks to (options: near, local)",
+				},
+				cli.StringFlag{
+					Name:  "local-da-url",
+					Value: "",
+					Usage: "URL of the local DA server, for the local DA backend",
+				},
+				cli.UintFlag{
+					Name:  "rollup-id",
+					Value: 0,
+					Usage: "Rollup ID blocks are stored under, for the local DA backend",
+				},
+				cli.UintFlag{
+					Name:  "namespace-id",
+					Value: 0,
+					Usage: "NEAR DA blob namespace (default: 1)",
+				},
+				cli.UintFlag{
+					Name:  "max-blob-size",
+					Value: 0,
+					Usage: "Max size in bytes of a submitted batch (default: 1 MiB)",
+				},
+				cli.UintFlag{
+					Name:  "max-batch-blocks",
+					Value: 0,
+					Usage: "Max number of blocks in a submitted batch (default: 100)",
+				},
+				cli.UintFlag{
+					Name:  "min-batch-interval-ms",
+					Value: 0,
+					Usage: "Min time between submissions of batches that aren't full (default: 2500)",
+				},
+				cli.StringFlag{
+					Name:  "compression",
+					Value: "",
+					Usage: "Batch payload compression (options: zstd, brotli, default: none)",
+				},
+				cli.StringFlag{
+					Name:  "batch-store-path",
+					Value: "",
+					Usage: "File storing the DA status of submitted batches",
+				},
+				cli.UintFlag{
+					Name:  "finality-deadline-ms",
+					Value: 0,
+					Usage: "Time after which unfinalized batches are reported as overdue (default: 300000)",
+				},
+				cli.StringFlag{
+					Name:  "status-ip-port-address",
+					Value: "",
+					Usage: "Batch status endpoints address",
+				},
 			},
 			Action: relayerMainFromArgs,
 		},
@@ -79,12 +145,26 @@ func main() {
 
 func relayerMainFromArgs(ctx *cli.Context) error {
 	config := config.RelayerConfig{
-		Production:        ctx.Bool("production"),
-		RpcUrl:            ctx.String("rpc-url"),
-		DaAccountId:       ctx.String("da-account-id"),
-		KeyPath:           ctx.String("key-path"),
-		Network:           ctx.String("network"),
-		MetricsIpPortAddr: ctx.String("metrics-ip-port-address"),
+		Production:         ctx.Bool("production"),
+		RpcUrl:             ctx.String("rpc-url"),
+		DaAccountId:        ctx.String("da-account-id"),
+		KeyPath:            ctx.String("key-path"),
+		Network:            ctx.String("network"),
+		MetricsIpPortAddr:  ctx.String("metrics-ip-port-address"),
+		FullBlocks:         ctx.Bool("full-blocks"),
+		IncludeReceipts:    ctx.Bool("include-receipts"),
+		CursorPath:         ctx.String("cursor-path"),
+		DaBackend:          ctx.String("da-backend"),
+		LocalDaUrl:         ctx.String("local-da-url"),
+		RollupId:           uint32(ctx.Uint("rollup-id")),
+		NamespaceId:        uint32(ctx.Uint("namespace-id")),
+		MaxBlobSize:        uint32(ctx.Uint("max-blob-size")),
+		MaxBatchBlocks:     uint32(ctx.Uint("max-batch-blocks")),
+		MinBatchIntervalMs: uint32(ctx.Uint("min-batch-interval-ms")),
+		Compression:        ctx.String("compression"),
+		BatchStorePath:     ctx.String("batch-store-path"),
+		FinalityDeadlineMs: uint32(ctx.Uint("finality-deadline-ms")),
+		StatusIpPortAddr:   ctx.String("status-ip-port-address"),
 	}
 
 	return relayerMain(config)
@@ -124,7 +204,7 @@ func relayerMain(config config.RelayerConfig) error {
 	}
 
 	logger.Info("initializing relayer")
-	rel, err := relayer.NewRelayerFromConfig(&config, logger)
+	rel, err := relayer.NewMultiRelayerFromConfig(&config, logger)
 	if err != nil {
 		logger.Error("Error creating relayer", "err", err)
 		return err
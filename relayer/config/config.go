@@ -1,14 +1,78 @@
 package config
 
+import "strconv"
+
+// Rollup served by a relayer process
+type RollupConfig struct {
+	RollupId    uint32 `yaml:"rollup_id"`
+	RpcUrl      string `yaml:"rpc_url"`
+	DaAccountId string `yaml:"da_account_id"`
+	KeyPath     string `yaml:"key_path"`
+	// Endpoints failed over to when RpcUrl is down or lagging
+	FailoverRpcUrls []string `yaml:"failover_rpc_urls"`
+	// NEAR DA blob namespace, which must be unique among the rollups
+	// submitting to the same DA account. Defaults to 1.
+	NamespaceId uint32 `yaml:"namespace_id"`
+}
+
 type RelayerConfig struct {
-	Production        bool   `yaml:"production"`
-	RpcUrl            string `yaml:"rpc_url"`
-	DaAccountId       string `yaml:"da_account_id"`
-	KeyPath           string `yaml:"key_path"`
-	Network           string `yaml:"network"`
-	MetricsIpPortAddr string `yaml:"metrics_ip_port_address"`
+	Production bool `yaml:"production"`
+	// Single rollup settings, used when Rollups is empty
+	RollupId    uint32 `yaml:"rollup_id"`
+	RpcUrl      string `yaml:"rpc_url"`
+	DaAccountId string `yaml:"da_account_id"`
+	KeyPath     string `yaml:"key_path"`
+	NamespaceId uint32 `yaml:"namespace_id"`
+	// Rollups served by the process. Each one has its own queue and
+	// batching, and submissions signed with the same key are serialized.
+	Rollups           []RollupConfig `yaml:"rollups"`
+	Network           string         `yaml:"network"`
+	MetricsIpPortAddr string         `yaml:"metrics_ip_port_address"`
+	// Submits full blocks in the versioned format instead of headers only
+	FullBlocks      bool `yaml:"full_blocks"`
+	IncludeReceipts bool `yaml:"include_receipts"`
+	// File storing the last submitted height of each rollup, so that missed
+	// heights are backfilled after a restart
+	CursorPath string `yaml:"cursor_path"`
+	// DA backend to submit to: `near` (default) or `local`, a LocalDaServer
+	// at LocalDaUrl which stores blobs under each rollup ID
+	DaBackend  string `yaml:"da_backend"`
+	LocalDaUrl string `yaml:"local_da_url"`
+	// Batching limits, defaulting to the relayer's when unset. Batches are
+	// submitted when full or after the min interval.
+	MaxBlobSize        uint32 `yaml:"max_blob_size"`
+	MaxBatchBlocks     uint32 `yaml:"max_batch_blocks"`
+	MinBatchIntervalMs uint32 `yaml:"min_batch_interval_ms"`
+	// Batch payload compression: none (default), `zstd` or `brotli`
+	Compression string `yaml:"compression"`
+	// File storing the DA status of submitted batches, which are otherwise
+	// only kept in memory
+	BatchStorePath string `yaml:"batch_store_path"`
+	// Time after which unfinalized batches are reported as overdue, 5
+	// minutes by default
+	FinalityDeadlineMs uint32 `yaml:"finality_deadline_ms"`
+	// Address serving the batch status endpoints
+	StatusIpPortAddr string `yaml:"status_ip_port_address"`
 }
 
+// Returns the configured rollups, or the single rollup from the top-level
+// settings if there are none
+func (c RelayerConfig) GetRollups() []RollupConfig {
+	if len(c.Rollups) != 0 {
+		return c.Rollups
+	}
+
+	return []RollupConfig{{
+		RollupId:    c.RollupId,
+		RpcUrl:      c.RpcUrl,
+		DaAccountId: c.DaAccountId,
+		KeyPath:     c.KeyPath,
+		NamespaceId: c.NamespaceId,
+	}}
+}
+
+// Compiles the single rollup settings into run-args arguments, as Rollups
+// can only be set in a config file
 func (c RelayerConfig) CompileCMD() []string {
 	var cmd []string
 	cmd = append(cmd, "run-args")
@@ -23,6 +87,48 @@ func (c RelayerConfig) CompileCMD() []string {
 	if c.MetricsIpPortAddr != "" {
 		cmd = append(cmd, "--metrics-ip-port-address", c.MetricsIpPortAddr)
 	}
+	if c.FullBlocks {
+		cmd = append(cmd, "--full-blocks")
+	}
+	if c.IncludeReceipts {
+		cmd = append(cmd, "--include-receipts")
+	}
+	if c.CursorPath != "" {
+		cmd = append(cmd, "--cursor-path", c.CursorPath)
+	}
+	if c.DaBackend != "" {
+		cmd = append(cmd, "--da-backend", c.DaBackend)
+	}
+	if c.LocalDaUrl != "" {
+		cmd = append(cmd, "--local-da-url", c.LocalDaUrl)
+	}
+	if c.NamespaceId != 0 {
+		cmd = append(cmd, "--namespace-id", strconv.FormatUint(uint64(c.NamespaceId), 10))
+	}
+	if c.RollupId != 0 {
+		cmd = append(cmd, "--rollup-id", strconv.FormatUint(uint64(c.RollupId), 10))
+	}
+	if c.MaxBlobSize != 0 {
+		cmd = append(cmd, "--max-blob-size", strconv.FormatUint(uint64(c.MaxBlobSize), 10))
+	}
+	if c.MaxBatchBlocks != 0 {
+		cmd = append(cmd, "--max-batch-blocks", strconv.FormatUint(uint64(c.MaxBatchBlocks), 10))
+	}
+	if c.MinBatchIntervalMs != 0 {
+		cmd = append(cmd, "--min-batch-interval-ms", strconv.FormatUint(uint64(c.MinBatchIntervalMs), 10))
+	}
+	if c.Compression != "" {
+		cmd = append(cmd, "--compression", c.Compression)
+	}
+	if c.BatchStorePath != "" {
+		cmd = append(cmd, "--batch-store-path", c.BatchStorePath)
+	}
+	if c.FinalityDeadlineMs != 0 {
+		cmd = append(cmd, "--finality-deadline-ms", strconv.FormatUint(uint64(c.FinalityDeadlineMs), 10))
+	}
+	if c.StatusIpPortAddr != "" {
+		cmd = append(cmd, "--status-ip-port-address", c.StatusIpPortAddr)
+	}
 
 	return cmd
 }
@@ -0,0 +1,160 @@
+package da
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type BatchStatus string
+
+const (
+	BATCH_STATUS_SUBMITTED BatchStatus = "submitted"
+	BATCH_STATUS_FINALIZED BatchStatus = "finalized"
+	BATCH_STATUS_FAILED    BatchStatus = "failed"
+
+	// Settled batches kept per rollup, besides all pending ones
+	BATCH_STORE_MAX_SETTLED = 1000
+)
+
+// Submitted batch of rollup blocks and its DA status
+type BatchRecord struct {
+	RollupId    uint32      `json:"rollupId"`
+	FromHeight  uint64      `json:"fromHeight"`
+	ToHeight    uint64      `json:"toHeight"`
+	Submission  Submission  `json:"submission"`
+	Status      BatchStatus `json:"status"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	SettledAt   *time.Time  `json:"settledAt,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// BatchStore keeps batch records in memory and, if it has a path, in a JSON
+// file. Only the latest settled batches of each rollup are kept.
+type BatchStore struct {
+	path    string
+	lock    sync.Mutex
+	batches []BatchRecord
+}
+
+func NewBatchStore(path string) (*BatchStore, error) {
+	store := &BatchStore{path: path}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &store.batches)
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *BatchStore) Add(batch BatchRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.batches = append(s.batches, batch)
+	return s.persist()
+}
+
+// Settles a submitted batch as finalized or failed. Batches are matched by
+// transaction, as a resubmitted batch has the same heights.
+func (s *BatchStore) Settle(transactionId common.Hash, status BatchStatus, settledAt time.Time, settleErr error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := range s.batches {
+		batch := &s.batches[i]
+		if batch.Submission.TransactionId != transactionId || batch.Status != BATCH_STATUS_SUBMITTED {
+			continue
+		}
+
+		batch.Status = status
+		batch.SettledAt = &settledAt
+		if settleErr != nil {
+			batch.Error = settleErr.Error()
+		}
+	}
+
+	s.prune()
+	return s.persist()
+}
+
+// Returns the batches matching a filter, oldest first
+func (s *BatchStore) Get(filter func(batch *BatchRecord) bool) []BatchRecord {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batches := make([]BatchRecord, 0)
+	for i := range s.batches {
+		if filter == nil || filter(&s.batches[i]) {
+			batches = append(batches, s.batches[i])
+		}
+	}
+
+	return batches
+}
+
+// Drops the oldest settled batches over the limit. Must be called with the
+// lock held.
+func (s *BatchStore) prune() {
+	settledCounts := make(map[uint32]int)
+	for _, batch := range s.batches {
+		if batch.Status != BATCH_STATUS_SUBMITTED {
+			settledCounts[batch.RollupId]++
+		}
+	}
+
+	batches := s.batches[:0]
+	for _, batch := range s.batches {
+		if batch.Status != BATCH_STATUS_SUBMITTED && settledCounts[batch.RollupId] > BATCH_STORE_MAX_SETTLED {
+			settledCounts[batch.RollupId]--
+			continue
+		}
+
+		batches = append(batches, batch)
+	}
+	s.batches = batches
+}
+
+// Must be called with the lock held
+func (s *BatchStore) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s.batches)
+	if err != nil {
+		return err
+	}
+
+	// Written to a temporary file first so that a crash never leaves a
+	// truncated file behind
+	tmpPath := s.path + ".tmp"
+	err = os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.path)
+}
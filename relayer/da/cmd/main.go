@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli"
+
+	"github.com/Nuffle-Labs/nffl/relayer/da"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "sffl-local-da"
+	app.Usage = "SFFL Local DA Server"
+	app.Description = "Stand-in for NEAR DA and its indexer that stores relayer submissions on disk and serves them to operators over HTTP."
+	app.Flags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "production",
+			Usage: "Run in production logging mode",
+		},
+		cli.StringFlag{
+			Name:  "ip-port-address",
+			Value: ":8080",
+			Usage: "Address to serve on",
+		},
+		cli.StringFlag{
+			Name:     "dir",
+			Required: true,
+			Usage:    "Directory to store submissions in",
+		},
+	}
+	app.Action = localDaMain
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatalln("Application failed. Message:", err)
+	}
+}
+
+func localDaMain(ctx *cli.Context) error {
+	var logLevel sdklogging.LogLevel
+	if ctx.Bool("production") {
+		logLevel = sdklogging.Production
+	} else {
+		logLevel = sdklogging.Development
+	}
+
+	logger, err := sdklogging.NewZapLogger(logLevel)
+	if err != nil {
+		return err
+	}
+
+	server, err := da.NewLocalDaServer(ctx.String("dir"), logger)
+	if err != nil {
+		logger.Error("Error creating local DA server", "err", err)
+		return err
+	}
+
+	signalCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	return server.Start(signalCtx, ctx.String("ip-port-address"))
+}
@@ -0,0 +1,337 @@
+package da
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+	"github.com/near/borsh-go"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	"github.com/Nuffle-Labs/nffl/operator/consumer"
+)
+
+const (
+	LOCAL_DA_NAMESPACE_ID      = 1
+	LOCAL_DA_MAX_SUBMISSIONS   = 100
+	LOCAL_DA_MAX_POLL_TIMEOUT  = time.Minute
+	LOCAL_DA_MAX_BLOB_SIZE     = 4 * 1024 * 1024
+	LOCAL_DA_SHUTDOWN_TIMEOUT  = 5 * time.Second
+	localDaSubmissionExtension = ".bin"
+)
+
+var (
+	InvalidRollupIdError = errors.New("Invalid rollup ID")
+	InvalidQueryError    = errors.New("Invalid query parameters")
+)
+
+type LocalDaSubmitResponse struct {
+	Seq           uint64                 `json:"seq"`
+	TransactionId consumer.TransactionId `json:"transactionId"`
+}
+
+// Returns the path blobs are submitted to for a rollup
+func GetLocalDaBlobsPath(rollupId uint32) string {
+	return fmt.Sprintf("/v1/rollups/%d/blobs", rollupId)
+}
+
+// LocalDaServer is a stand-in for NEAR DA and the indexer. It stores each
+// submitted blob on disk and serves them through the long-poll endpoint read
+// by consumer.HttpBlockSource.
+type LocalDaServer struct {
+	dir    string
+	logger logging.Logger
+
+	lock     sync.Mutex
+	lastSeqs map[uint32]uint64
+	// Closed and replaced on each submission to the rollup
+	submittedC map[uint32]chan struct{}
+}
+
+func NewLocalDaServer(dir string, logger logging.Logger) (*LocalDaServer, error) {
+	server := &LocalDaServer{
+		dir:        dir,
+		logger:     logger,
+		lastSeqs:   make(map[uint32]uint64),
+		submittedC: make(map[uint32]chan struct{}),
+	}
+
+	if err := server.loadLastSeqs(); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// Restores the last Seq of each rollup from disk
+func (s *LocalDaServer) loadLastSeqs() error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	rollupDirs, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, rollupDir := range rollupDirs {
+		rollupId, err := strconv.ParseUint(rollupDir.Name(), 10, 32)
+		if err != nil || !rollupDir.IsDir() {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(s.dir, rollupDir.Name()))
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if filepath.Ext(file.Name()) != localDaSubmissionExtension {
+				continue
+			}
+
+			seq, err := strconv.ParseUint(file.Name()[:len(file.Name())-len(localDaSubmissionExtension)], 10, 64)
+			if err != nil {
+				continue
+			}
+
+			s.lastSeqs[uint32(rollupId)] = max(s.lastSeqs[uint32(rollupId)], seq)
+		}
+	}
+
+	return nil
+}
+
+func (s *LocalDaServer) getSubmissionPath(rollupId uint32, seq uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(uint64(rollupId), 10), fmt.Sprintf("%020d%s", seq, localDaSubmissionExtension))
+}
+
+// Must be called with the lock held
+func (s *LocalDaServer) getSubmittedC(rollupId uint32) chan struct{} {
+	submittedC, ok := s.submittedC[rollupId]
+	if !ok {
+		submittedC = make(chan struct{})
+		s.submittedC[rollupId] = submittedC
+	}
+
+	return submittedC
+}
+
+// Stores a blob as a single-blob submission, in the same format the indexer
+// publishes
+func (s *LocalDaServer) Submit(rollupId uint32, data []byte) (LocalDaSubmitResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	seq := s.lastSeqs[rollupId] + 1
+
+	submitRequest, err := borsh.Serialize(consumer.SubmitRequest{
+		Blobs: []consumer.Blob{{
+			Namespace:  consumer.Namespace{Version: 0, Id: LOCAL_DA_NAMESPACE_ID},
+			Commitment: core.ComputeBlobCommitment(data),
+			Data:       data,
+		}},
+	})
+	if err != nil {
+		return LocalDaSubmitResponse{}, err
+	}
+
+	hasher := sha256.New()
+	binary.Write(hasher, binary.BigEndian, rollupId)
+	binary.Write(hasher, binary.BigEndian, seq)
+	hasher.Write(data)
+	transactionId := consumer.TransactionId(hasher.Sum(nil))
+
+	payload, err := borsh.Serialize(consumer.PublishPayload{TransactionId: transactionId, Data: submitRequest})
+	if err != nil {
+		return LocalDaSubmitResponse{}, err
+	}
+
+	path := s.getSubmissionPath(rollupId, seq)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return LocalDaSubmitResponse{}, err
+	}
+
+	// Written to a temporary file first so that partial submissions are
+	// never served
+	if err := os.WriteFile(path+".tmp", payload, 0644); err != nil {
+		return LocalDaSubmitResponse{}, err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return LocalDaSubmitResponse{}, err
+	}
+
+	s.lastSeqs[rollupId] = seq
+
+	close(s.getSubmittedC(rollupId))
+	delete(s.submittedC, rollupId)
+
+	s.logger.Info("Stored submission", "rollupId", rollupId, "seq", seq, "size", len(data))
+
+	return LocalDaSubmitResponse{Seq: seq, TransactionId: transactionId}, nil
+}
+
+// Returns the submissions after a Seq, waiting up to the timeout for new ones
+// if there are none. Without a Seq, only later submissions are returned.
+func (s *LocalDaServer) GetSubmissions(ctx context.Context, rollupId uint32, after *uint64, timeout time.Duration) ([]consumer.HttpSubmission, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	s.lock.Lock()
+	if after == nil {
+		lastSeq := s.lastSeqs[rollupId]
+		after = &lastSeq
+	}
+
+	for s.lastSeqs[rollupId] <= *after {
+		submittedC := s.getSubmittedC(rollupId)
+		s.lock.Unlock()
+
+		select {
+		case <-submittedC:
+		case <-timer.C:
+			return []consumer.HttpSubmission{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		s.lock.Lock()
+	}
+
+	lastSeq := min(s.lastSeqs[rollupId], *after+LOCAL_DA_MAX_SUBMISSIONS)
+	s.lock.Unlock()
+
+	submissions := make([]consumer.HttpSubmission, 0, lastSeq-*after)
+	for seq := *after + 1; seq <= lastSeq; seq++ {
+		payload, err := os.ReadFile(s.getSubmissionPath(rollupId, seq))
+		if err != nil {
+			return nil, err
+		}
+
+		submissions = append(submissions, consumer.HttpSubmission{Seq: seq, Payload: payload})
+	}
+
+	return submissions, nil
+}
+
+func (s *LocalDaServer) Handler() http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/rollups/{rollupId}/blobs", s.handleSubmit).Methods("POST")
+	router.HandleFunc("/v1/rollups/{rollupId}/submissions", s.handleGetSubmissions).Methods("GET")
+
+	return router
+}
+
+// Serves until the context is done
+func (s *LocalDaServer) Start(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: s.Handler()}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), LOCAL_DA_SHUTDOWN_TIMEOUT)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error("Error shutting down local DA server", "err", err)
+		}
+	}()
+
+	s.logger.Info("Starting local DA server", "addr", addr, "dir", s.dir)
+
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *LocalDaServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	rollupId, err := parseRollupId(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, LOCAL_DA_MAX_BLOB_SIZE))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	response, err := s.Submit(rollupId, data)
+	if err != nil {
+		s.logger.Error("Error storing submission", "rollupId", rollupId, "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *LocalDaServer) handleGetSubmissions(w http.ResponseWriter, r *http.Request) {
+	rollupId, err := parseRollupId(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var after *uint64
+	if query.Has("after") {
+		seq, err := strconv.ParseUint(query.Get("after"), 10, 64)
+		if err != nil {
+			http.Error(w, InvalidQueryError.Error(), http.StatusBadRequest)
+			return
+		}
+
+		after = &seq
+	}
+
+	timeout := time.Duration(0)
+	if query.Has("timeout_ms") {
+		timeoutMs, err := strconv.ParseUint(query.Get("timeout_ms"), 10, 32)
+		if err != nil {
+			http.Error(w, InvalidQueryError.Error(), http.StatusBadRequest)
+			return
+		}
+
+		timeout = min(time.Duration(timeoutMs)*time.Millisecond, LOCAL_DA_MAX_POLL_TIMEOUT)
+	}
+
+	submissions, err := s.GetSubmissions(r.Context(), rollupId, after, timeout)
+	if err != nil {
+		if r.Context().Err() == nil {
+			s.logger.Error("Error reading submissions", "rollupId", rollupId, "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(consumer.HttpSubmissionsResponse{Submissions: submissions})
+}
+
+func parseRollupId(r *http.Request) (uint32, error) {
+	rollupId, err := strconv.ParseUint(mux.Vars(r)["rollupId"], 10, 32)
+	if err != nil {
+		return 0, InvalidRollupIdError
+	}
+
+	return uint32(rollupId), nil
+}
@@ -0,0 +1,100 @@
+package da
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/operator/consumer"
+)
+
+const TEST_ROLLUP_ID = 2
+
+func createTestBlocks(t *testing.T, start int64, stateRoots ...common.Hash) []byte {
+	blocks := make([]coretypes.RollupBlock, len(stateRoots))
+	for i, stateRoot := range stateRoots {
+		blocks[i] = coretypes.RollupBlock{Header: &ethtypes.Header{Number: big.NewInt(start + int64(i)), Root: stateRoot}}
+	}
+
+	data, err := coretypes.EncodeRollupBlocks(blocks)
+	assert.NoError(t, err)
+
+	return data
+}
+
+func TestLocalDaPipeline(t *testing.T) {
+	dir := t.TempDir()
+	server, err := NewLocalDaServer(dir, logging.NewNoopLogger())
+	assert.NoError(t, err)
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	submitter := NewLocalDaSubmitter(httpServer.URL, TEST_ROLLUP_ID)
+
+	data := createTestBlocks(t, 1, common.Hash{1}, common.Hash{2})
+	submission, err := submitter.Submit(ctx, data)
+	assert.NoError(t, err)
+	assert.Equal(t, common.Hash(core.ComputeBlobCommitment(data)), submission.Commitment)
+
+	final, err := submitter.CheckFinality(ctx, submission)
+	assert.NoError(t, err)
+	assert.True(t, final)
+
+	// Submissions made before the first poll are only served with a cursor
+	submissions, err := server.GetSubmissions(ctx, TEST_ROLLUP_ID, new(uint64), 0)
+	assert.NoError(t, err)
+	assert.Len(t, submissions, 1)
+	assert.Equal(t, uint64(1), submissions[0].Seq)
+
+	source := consumer.NewHttpBlockSource(consumer.HttpBlockSourceConfig{
+		Url:       httpServer.URL,
+		RollupIds: []uint32{TEST_ROLLUP_ID},
+	}, logging.NewNoopLogger())
+	assert.NoError(t, source.Start(ctx))
+	defer source.Close()
+
+	// Waits for the first poll to be held before submitting
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = submitter.Submit(ctx, createTestBlocks(t, 3, common.Hash{3}))
+	assert.NoError(t, err)
+
+	select {
+	case blockData := <-source.GetBlockStream():
+		assert.Equal(t, uint32(TEST_ROLLUP_ID), blockData.RollupId)
+		assert.Equal(t, uint64(3), blockData.Block.NumberU64())
+		assert.Equal(t, common.Hash{3}, blockData.Block.Root())
+	case <-time.After(time.Second):
+		t.Fatal("block timed out")
+	}
+
+	// Sequence numbers continue after a restart
+	restartedServer, err := NewLocalDaServer(dir, logging.NewNoopLogger())
+	assert.NoError(t, err)
+
+	response, err := restartedServer.Submit(TEST_ROLLUP_ID, createTestBlocks(t, 4, common.Hash{4}))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), response.Seq)
+}
+
+func TestLocalDaSubmitterUnavailable(t *testing.T) {
+	httpServer := httptest.NewServer(nil)
+	url := httpServer.URL
+	httpServer.Close()
+
+	_, err := NewLocalDaSubmitter(url, TEST_ROLLUP_ID).Submit(context.Background(), []byte{1})
+	assert.ErrorIs(t, err, UnavailableError)
+}
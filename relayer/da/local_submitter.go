@@ -0,0 +1,83 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Nuffle-Labs/nffl/core"
+)
+
+const LOCAL_DA_SUBMIT_TIMEOUT = 10 * time.Second
+
+// LocalDaSubmitter submits blobs to a LocalDaServer over HTTP
+type LocalDaSubmitter struct {
+	url        string
+	rollupId   uint32
+	httpClient *http.Client
+}
+
+var _ DaSubmitter = (*LocalDaSubmitter)(nil)
+
+func NewLocalDaSubmitter(url string, rollupId uint32) *LocalDaSubmitter {
+	return &LocalDaSubmitter{
+		url:        url,
+		rollupId:   rollupId,
+		httpClient: &http.Client{Timeout: LOCAL_DA_SUBMIT_TIMEOUT},
+	}
+}
+
+func (s *LocalDaSubmitter) Submit(ctx context.Context, data []byte) (Submission, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+GetLocalDaBlobsPath(s.rollupId), bytes.NewReader(data))
+	if err != nil {
+		return Submission{}, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+			return Submission{}, fmt.Errorf("%w: %w", TimeoutError, err)
+		}
+
+		return Submission{}, fmt.Errorf("%w: %w", UnavailableError, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Submission{}, fmt.Errorf("%w: %w", UnavailableError, err)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return Submission{}, fmt.Errorf("%w: status code %d", UnavailableError, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Submission{}, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var response LocalDaSubmitResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return Submission{}, err
+	}
+
+	return Submission{
+		TransactionId: response.TransactionId,
+		Commitment:    core.ComputeBlobCommitment(data),
+	}, nil
+}
+
+// Submissions are final once stored by the server
+func (s *LocalDaSubmitter) CheckFinality(ctx context.Context, submission Submission) (bool, error) {
+	return true, nil
+}
+
+func isTimeout(err error) bool {
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
@@ -0,0 +1,152 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nuffle-Labs/nffl/core"
+)
+
+const (
+	NEAR_FINALITY_RPC_TIMEOUT = 10 * time.Second
+	NEAR_MAINNET_RPC_URL      = "https://rpc.mainnet.near.org"
+	NEAR_TESTNET_RPC_URL      = "https://rpc.testnet.near.org"
+
+	nearFinalExecutionStatus   = "FINAL"
+	nearUnknownTransactionName = "UNKNOWN_TRANSACTION"
+)
+
+// Returns the RPC URL of a NEAR DA client network, which is either Mainnet,
+// Testnet or a node address
+func GetNearRpcUrl(network string) string {
+	switch network {
+	case "Mainnet":
+		return NEAR_MAINNET_RPC_URL
+	case "Testnet":
+		return NEAR_TESTNET_RPC_URL
+	}
+
+	if !strings.Contains(network, "://") {
+		return "http://" + network
+	}
+
+	return network
+}
+
+// NearFinalityChecker queries a NEAR RPC for the execution status of
+// submission transactions
+type NearFinalityChecker struct {
+	rpcUrl     string
+	signerId   string
+	httpClient *http.Client
+}
+
+func NewNearFinalityChecker(rpcUrl, signerId string) *NearFinalityChecker {
+	return &NearFinalityChecker{
+		rpcUrl:     rpcUrl,
+		signerId:   signerId,
+		httpClient: &http.Client{Timeout: NEAR_FINALITY_RPC_TIMEOUT},
+	}
+}
+
+type nearTxRequest struct {
+	JsonRpc string `json:"jsonrpc"`
+	Id      string `json:"id"`
+	Method  string `json:"method"`
+	Params  struct {
+		TxHash          string `json:"tx_hash"`
+		SenderAccountId string `json:"sender_account_id"`
+		WaitUntil       string `json:"wait_until"`
+	} `json:"params"`
+}
+
+type nearTxResponse struct {
+	Result *struct {
+		FinalExecutionStatus string `json:"final_execution_status"`
+		// Either a string, such as "NotStarted" or "Started", or a map with
+		// a "SuccessValue" or "Failure" key
+		Status json.RawMessage `json:"status"`
+	} `json:"result"`
+	Error *struct {
+		Name  string `json:"name"`
+		Cause struct {
+			Name string `json:"name"`
+		} `json:"cause"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Transactions the RPC doesn't know are reported with UnknownSubmissionError,
+// so that the tracker can tell those still propagating from dropped ones
+func (c *NearFinalityChecker) CheckFinality(ctx context.Context, submission Submission) (bool, error) {
+	request := nearTxRequest{JsonRpc: "2.0", Id: "nffl", Method: "tx"}
+	request.Params.TxHash = core.EncodeBase58(submission.TransactionId[:])
+	request.Params.SenderAccountId = c.signerId
+	// Returns right away with the current status
+	request.Params.WaitUntil = "NONE"
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.rpcUrl, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var response nearTxResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return false, err
+	}
+
+	if response.Error != nil {
+		if response.Error.Cause.Name == nearUnknownTransactionName {
+			return false, fmt.Errorf("%w: %s", UnknownSubmissionError, response.Error.Message)
+		}
+
+		return false, fmt.Errorf("NEAR RPC error %s: %s", response.Error.Name, response.Error.Message)
+	}
+	if response.Result == nil {
+		return false, errors.New("NEAR RPC returned no result")
+	}
+
+	if response.Result.FinalExecutionStatus != nearFinalExecutionStatus {
+		return false, nil
+	}
+
+	return parseNearExecutionStatus(response.Result.Status)
+}
+
+// Returns whether a final transaction's execution status is successful. A
+// status string means the execution isn't done, so it's reported as not final.
+func parseNearExecutionStatus(status json.RawMessage) (bool, error) {
+	var statusName string
+	if err := json.Unmarshal(status, &statusName); err == nil {
+		return false, nil
+	}
+
+	var statusMap map[string]json.RawMessage
+	if err := json.Unmarshal(status, &statusMap); err != nil {
+		return false, fmt.Errorf("invalid NEAR execution status: %w", err)
+	}
+
+	if _, ok := statusMap["SuccessValue"]; !ok {
+		return false, SubmissionFailedError
+	}
+
+	return true, nil
+}
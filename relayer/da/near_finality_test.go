@@ -0,0 +1,82 @@
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nuffle-Labs/nffl/core"
+)
+
+const TEST_SIGNER_ID = "submitter.test.near"
+
+func TestNearFinalityChecker(t *testing.T) {
+	responses := map[common.Hash]string{
+		{1}: `{"result": {"final_execution_status": "FINAL", "status": {"SuccessValue": ""}}}`,
+		{2}: `{"result": {"final_execution_status": "FINAL", "status": {"Failure": {}}}}`,
+		{3}: `{"result": {"final_execution_status": "EXECUTED_OPTIMISTIC", "status": {"SuccessValue": ""}}}`,
+		{4}: `{"error": {"name": "HANDLER_ERROR", "cause": {"name": "UNKNOWN_TRANSACTION"}, "message": "unknown"}}`,
+		{5}: `{"error": {"name": "HANDLER_ERROR", "cause": {"name": "INVALID_TRANSACTION"}, "message": "invalid"}}`,
+		{6}: `{"result": {"final_execution_status": "FINAL", "status": "Started"}}`,
+		{7}: `{"result": {"final_execution_status": "FINAL", "status": 1}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request nearTxRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "tx", request.Method)
+		assert.Equal(t, TEST_SIGNER_ID, request.Params.SenderAccountId)
+
+		for transactionId, response := range responses {
+			if request.Params.TxHash == core.EncodeBase58(transactionId[:]) {
+				w.Write([]byte(response))
+				return
+			}
+		}
+
+		t.Fatal("unexpected transaction")
+	}))
+	defer server.Close()
+
+	checker := NewNearFinalityChecker(server.URL, TEST_SIGNER_ID)
+	ctx := context.Background()
+
+	final, err := checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{1}})
+	assert.NoError(t, err)
+	assert.True(t, final)
+
+	_, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{2}})
+	assert.ErrorIs(t, err, SubmissionFailedError)
+
+	final, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{3}})
+	assert.NoError(t, err)
+	assert.False(t, final)
+
+	final, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{4}})
+	assert.ErrorIs(t, err, UnknownSubmissionError)
+	assert.False(t, final)
+
+	_, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{5}})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, SubmissionFailedError)
+
+	// Status strings are execution states, not outcomes
+	final, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{6}})
+	assert.NoError(t, err)
+	assert.False(t, final)
+
+	_, err = checker.CheckFinality(ctx, Submission{TransactionId: common.Hash{7}})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, SubmissionFailedError)
+}
+
+func TestGetNearRpcUrl(t *testing.T) {
+	assert.Equal(t, NEAR_TESTNET_RPC_URL, GetNearRpcUrl("Testnet"))
+	assert.Equal(t, "http://127.0.0.1:3030", GetNearRpcUrl("127.0.0.1:3030"))
+	assert.Equal(t, "http://nffl-indexer:3030", GetNearRpcUrl("http://nffl-indexer:3030"))
+}
@@ -0,0 +1,41 @@
+package da
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Errors after which a submission may be retried
+var (
+	InvalidNonceError = errors.New("Invalid nonce")
+	ExpiredTxError    = errors.New("Transaction expired")
+	TimeoutError      = errors.New("Submission timed out")
+	UnavailableError  = errors.New("DA backend unavailable")
+)
+
+var (
+	// Returned when a submission is known to have failed on the DA layer
+	SubmissionFailedError = errors.New("DA submission failed")
+	// Returned when the DA layer doesn't know a submission, which may still
+	// be propagating or may have been dropped
+	UnknownSubmissionError = errors.New("DA submission unknown")
+)
+
+// Reference to a submitted blob
+type Submission struct {
+	TransactionId common.Hash `json:"transactionId"`
+	Commitment    common.Hash `json:"commitment"`
+}
+
+// DaSubmitter submits blobs to a data availability backend
+type DaSubmitter interface {
+	// Submits data, returning a reference to it. Errors wrap one of the
+	// retryable errors above when applicable.
+	Submit(ctx context.Context, data []byte) (Submission, error)
+	// Returns whether a submission reached DA finality, or an error wrapping
+	// SubmissionFailedError if it never will, or UnknownSubmissionError if
+	// the DA backend doesn't know it
+	CheckFinality(ctx context.Context, submission Submission) (bool, error)
+}
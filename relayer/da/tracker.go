@@ -0,0 +1,340 @@
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+)
+
+const (
+	FINALITY_POLL_INTERVAL    = 2 * time.Second
+	DEFAULT_FINALITY_DEADLINE = 5 * time.Minute
+	STATUS_SHUTDOWN_TIMEOUT   = 5 * time.Second
+	// Time after which a submission the DA backend still doesn't know is
+	// considered dropped, and so failed and resubmitted
+	UNKNOWN_SUBMISSION_TIMEOUT = 2 * time.Minute
+)
+
+// Receives the finality events of the batches of a rollup
+type FinalityListener interface {
+	OnBatchFinalized(latency time.Duration)
+	OnBatchFailed()
+	ObserveOverdueBatches(count int)
+}
+
+type noopFinalityListener struct{}
+
+func (noopFinalityListener) OnBatchFinalized(latency time.Duration) {}
+func (noopFinalityListener) OnBatchFailed()                         {}
+func (noopFinalityListener) ObserveOverdueBatches(count int)        {}
+
+// DA status summary of a rollup
+type RollupStatus struct {
+	LastSubmittedHeight *uint64 `json:"lastSubmittedHeight,omitempty"`
+	LastFinalizedHeight *uint64 `json:"lastFinalizedHeight,omitempty"`
+	PendingBatches      int     `json:"pendingBatches"`
+	// Pending batches submitted longer than the finality deadline ago
+	OverdueBatches int `json:"overdueBatches"`
+	FailedBatches  int `json:"failedBatches"`
+}
+
+// Called with the heights of a failed batch so that they're submitted again
+type ResubmitHandler func(fromHeight, toHeight uint64)
+
+type trackedRollup struct {
+	submitter DaSubmitter
+	listener  FinalityListener
+	resubmit  ResubmitHandler
+}
+
+// SubmissionTracker records submitted batches and polls the DA backend of
+// their rollup until they're finalized or failed. Batches still pending after
+// the finality deadline are reported as overdue, and those still unknown to
+// the DA backend after UNKNOWN_SUBMISSION_TIMEOUT are failed. Failed batches
+// are handed to the rollup's resubmit handler.
+//
+// Whether the indexer picked a finalized batch up isn't tracked, as the
+// indexer publishes every final submission and exposes no per-transaction
+// acknowledgement. Operators report missing blocks on their side.
+type SubmissionTracker struct {
+	store          *BatchStore
+	deadline       time.Duration
+	unknownTimeout time.Duration
+	pollInterval   time.Duration
+	logger         logging.Logger
+
+	lock    sync.Mutex
+	rollups map[uint32]*trackedRollup
+}
+
+func NewSubmissionTracker(store *BatchStore, deadline time.Duration, logger logging.Logger) *SubmissionTracker {
+	if deadline == 0 {
+		deadline = DEFAULT_FINALITY_DEADLINE
+	}
+
+	return &SubmissionTracker{
+		store:          store,
+		deadline:       deadline,
+		unknownTimeout: UNKNOWN_SUBMISSION_TIMEOUT,
+		pollInterval:   FINALITY_POLL_INTERVAL,
+		logger:         logger,
+		rollups:        make(map[uint32]*trackedRollup),
+	}
+}
+
+func (t *SubmissionTracker) AddRollup(rollupId uint32, submitter DaSubmitter) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.rollups[rollupId] = &trackedRollup{submitter: submitter, listener: noopFinalityListener{}}
+}
+
+func (t *SubmissionTracker) SetListener(rollupId uint32, listener FinalityListener) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if rollup, ok := t.rollups[rollupId]; ok {
+		rollup.listener = listener
+	}
+}
+
+func (t *SubmissionTracker) SetResubmitHandler(rollupId uint32, handler ResubmitHandler) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if rollup, ok := t.rollups[rollupId]; ok {
+		rollup.resubmit = handler
+	}
+}
+
+// Records a submitted batch, whose finality is then polled
+func (t *SubmissionTracker) Track(rollupId uint32, fromHeight, toHeight uint64, submission Submission) error {
+	return t.store.Add(BatchRecord{
+		RollupId:    rollupId,
+		FromHeight:  fromHeight,
+		ToHeight:    toHeight,
+		Submission:  submission,
+		Status:      BATCH_STATUS_SUBMITTED,
+		SubmittedAt: time.Now(),
+	})
+}
+
+// Polls pending batches until the context is done
+func (t *SubmissionTracker) Start(ctx context.Context) {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.checkPendingBatches(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (t *SubmissionTracker) getRollup(rollupId uint32) (*trackedRollup, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	rollup, ok := t.rollups[rollupId]
+	return rollup, ok
+}
+
+func (t *SubmissionTracker) checkPendingBatches(ctx context.Context) {
+	pendingBatches := t.store.Get(func(batch *BatchRecord) bool {
+		return batch.Status == BATCH_STATUS_SUBMITTED
+	})
+
+	overdueCounts := make(map[uint32]int)
+	for _, batch := range pendingBatches {
+		rollup, ok := t.getRollup(batch.RollupId)
+		if !ok {
+			continue
+		}
+
+		final, err := rollup.submitter.CheckFinality(ctx, batch.Submission)
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		switch {
+		case errors.Is(err, UnknownSubmissionError) && now.Sub(batch.SubmittedAt) > t.unknownTimeout:
+			t.logger.Error("Batch still unknown to DA, considering it dropped", "rollupId", batch.RollupId, "from", batch.FromHeight, "to", batch.ToHeight, "transactionId", batch.Submission.TransactionId, "submittedAt", batch.SubmittedAt)
+			err = t.failBatch(rollup, batch, now, err)
+		case errors.Is(err, UnknownSubmissionError):
+			err = nil
+			if now.Sub(batch.SubmittedAt) > t.deadline {
+				overdueCounts[batch.RollupId]++
+			}
+		case errors.Is(err, SubmissionFailedError):
+			t.logger.Error("Batch failed on DA", "rollupId", batch.RollupId, "from", batch.FromHeight, "to", batch.ToHeight, "transactionId", batch.Submission.TransactionId, "err", err)
+			err = t.failBatch(rollup, batch, now, err)
+		case err != nil:
+			t.logger.Warn("Error checking batch finality", "rollupId", batch.RollupId, "to", batch.ToHeight, "err", err)
+			err = nil
+		case final:
+			t.logger.Info("Batch finalized on DA", "rollupId", batch.RollupId, "from", batch.FromHeight, "to", batch.ToHeight, "transactionId", batch.Submission.TransactionId)
+			rollup.listener.OnBatchFinalized(now.Sub(batch.SubmittedAt))
+
+			err = t.store.Settle(batch.Submission.TransactionId, BATCH_STATUS_FINALIZED, now, nil)
+		default:
+			if now.Sub(batch.SubmittedAt) > t.deadline {
+				t.logger.Warn("Batch not finalized on DA within deadline", "rollupId", batch.RollupId, "from", batch.FromHeight, "to", batch.ToHeight, "submittedAt", batch.SubmittedAt)
+				overdueCounts[batch.RollupId]++
+			}
+		}
+
+		if err != nil {
+			t.logger.Error("Error storing batch status", "rollupId", batch.RollupId, "to", batch.ToHeight, "err", err)
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for rollupId, rollup := range t.rollups {
+		rollup.listener.ObserveOverdueBatches(overdueCounts[rollupId])
+	}
+}
+
+// Settles a batch as failed and hands it to the resubmit handler
+func (t *SubmissionTracker) failBatch(rollup *trackedRollup, batch BatchRecord, settledAt time.Time, settleErr error) error {
+	rollup.listener.OnBatchFailed()
+
+	if err := t.store.Settle(batch.Submission.TransactionId, BATCH_STATUS_FAILED, settledAt, settleErr); err != nil {
+		return err
+	}
+
+	if rollup.resubmit != nil {
+		t.logger.Info("Resubmitting failed batch", "rollupId", batch.RollupId, "from", batch.FromHeight, "to", batch.ToHeight)
+		rollup.resubmit(batch.FromHeight, batch.ToHeight)
+	}
+
+	return nil
+}
+
+func (t *SubmissionTracker) GetStatus() map[uint32]RollupStatus {
+	t.lock.Lock()
+	statuses := make(map[uint32]RollupStatus, len(t.rollups))
+	for rollupId := range t.rollups {
+		statuses[rollupId] = RollupStatus{}
+	}
+	t.lock.Unlock()
+
+	now := time.Now()
+	for _, batch := range t.store.Get(nil) {
+		status, ok := statuses[batch.RollupId]
+		if !ok {
+			continue
+		}
+
+		toHeight := batch.ToHeight
+		if status.LastSubmittedHeight == nil || toHeight > *status.LastSubmittedHeight {
+			status.LastSubmittedHeight = &toHeight
+		}
+
+		switch batch.Status {
+		case BATCH_STATUS_SUBMITTED:
+			status.PendingBatches++
+			if now.Sub(batch.SubmittedAt) > t.deadline {
+				status.OverdueBatches++
+			}
+		case BATCH_STATUS_FINALIZED:
+			if status.LastFinalizedHeight == nil || toHeight > *status.LastFinalizedHeight {
+				status.LastFinalizedHeight = &toHeight
+			}
+		case BATCH_STATUS_FAILED:
+			status.FailedBatches++
+		}
+
+		statuses[batch.RollupId] = status
+	}
+
+	return statuses
+}
+
+// Serves `GET /v1/status`, the status of each rollup, which responds with 503
+// while any batch is overdue, and `GET /v1/batches`, the stored batches, which
+// can be filtered with the `rollup_id` and `status` query parameters
+func (t *SubmissionTracker) Handler() http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/status", t.handleGetStatus).Methods("GET")
+	router.HandleFunc("/v1/batches", t.handleGetBatches).Methods("GET")
+
+	return router
+}
+
+// Serves the status endpoints until the context is done
+func (t *SubmissionTracker) StartServer(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: t.Handler()}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), STATUS_SHUTDOWN_TIMEOUT)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			t.logger.Error("Error shutting down status server", "err", err)
+		}
+	}()
+
+	t.logger.Info("Starting status server", "addr", addr)
+
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (t *SubmissionTracker) handleGetStatus(w http.ResponseWriter, r *http.Request) {
+	statuses := t.GetStatus()
+
+	statusCode := http.StatusOK
+	for _, status := range statuses {
+		if status.OverdueBatches > 0 {
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(statuses)
+}
+
+func (t *SubmissionTracker) handleGetBatches(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var rollupId *uint32
+	if query.Has("rollup_id") {
+		id, err := strconv.ParseUint(query.Get("rollup_id"), 10, 32)
+		if err != nil {
+			http.Error(w, InvalidQueryError.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id32 := uint32(id)
+		rollupId = &id32
+	}
+
+	status := BatchStatus(query.Get("status"))
+
+	batches := t.store.Get(func(batch *BatchRecord) bool {
+		return (rollupId == nil || batch.RollupId == *rollupId) && (status == "" || batch.Status == status)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(batches)
+}
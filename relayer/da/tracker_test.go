@@ -0,0 +1,163 @@
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSubmitter struct {
+	lock     sync.Mutex
+	statuses map[common.Hash]error
+	final    map[common.Hash]bool
+}
+
+func (s *mockSubmitter) Submit(ctx context.Context, data []byte) (Submission, error) {
+	return Submission{}, nil
+}
+
+func (s *mockSubmitter) CheckFinality(ctx context.Context, submission Submission) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.final[submission.TransactionId], s.statuses[submission.TransactionId]
+}
+
+func TestSubmissionTracker(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "batches.json")
+	store, err := NewBatchStore(storePath)
+	assert.NoError(t, err)
+
+	submitter := &mockSubmitter{
+		statuses: map[common.Hash]error{common.Hash{2}: SubmissionFailedError},
+		final:    map[common.Hash]bool{common.Hash{1}: true},
+	}
+
+	tracker := NewSubmissionTracker(store, time.Hour, logging.NewNoopLogger())
+	tracker.AddRollup(TEST_ROLLUP_ID, submitter)
+
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 1, 2, Submission{TransactionId: common.Hash{1}}))
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 3, 3, Submission{TransactionId: common.Hash{2}}))
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 4, 5, Submission{TransactionId: common.Hash{3}}))
+
+	tracker.checkPendingBatches(context.Background())
+
+	status := tracker.GetStatus()[TEST_ROLLUP_ID]
+	assert.Equal(t, uint64(5), *status.LastSubmittedHeight)
+	assert.Equal(t, uint64(2), *status.LastFinalizedHeight)
+	assert.Equal(t, 1, status.PendingBatches)
+	assert.Equal(t, 1, status.FailedBatches)
+	assert.Equal(t, 0, status.OverdueBatches)
+
+	// Statuses survive a restart
+	restartedStore, err := NewBatchStore(storePath)
+	assert.NoError(t, err)
+
+	batches := restartedStore.Get(nil)
+	assert.Len(t, batches, 3)
+	assert.Equal(t, BATCH_STATUS_FINALIZED, batches[0].Status)
+	assert.Equal(t, BATCH_STATUS_FAILED, batches[1].Status)
+	assert.NotEmpty(t, batches[1].Error)
+	assert.Equal(t, BATCH_STATUS_SUBMITTED, batches[2].Status)
+}
+
+func TestSubmissionTrackerOverdue(t *testing.T) {
+	store, err := NewBatchStore("")
+	assert.NoError(t, err)
+
+	overdueCounts := make(chan int, 1)
+	tracker := NewSubmissionTracker(store, time.Millisecond, logging.NewNoopLogger())
+	tracker.AddRollup(TEST_ROLLUP_ID, &mockSubmitter{})
+	tracker.SetListener(TEST_ROLLUP_ID, &testFinalityListener{overdueCounts: overdueCounts})
+
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 1, 1, Submission{TransactionId: common.Hash{1}}))
+	time.Sleep(10 * time.Millisecond)
+
+	tracker.checkPendingBatches(context.Background())
+	assert.Equal(t, 1, <-overdueCounts)
+
+	server := httptest.NewServer(tracker.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/status")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var statuses map[uint32]RollupStatus
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&statuses))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, statuses[TEST_ROLLUP_ID].OverdueBatches)
+
+	resp, err = http.Get(server.URL + "/v1/batches?status=finalized")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var batches []BatchRecord
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&batches))
+	assert.Empty(t, batches)
+}
+
+type testFinalityListener struct {
+	noopFinalityListener
+	overdueCounts chan int
+}
+
+func (l *testFinalityListener) ObserveOverdueBatches(count int) {
+	l.overdueCounts <- count
+}
+
+func TestSubmissionTrackerFailsUnknownBatches(t *testing.T) {
+	store, err := NewBatchStore("")
+	assert.NoError(t, err)
+
+	submitter := &mockSubmitter{
+		statuses: map[common.Hash]error{
+			common.Hash{1}: UnknownSubmissionError,
+			common.Hash{2}: UnknownSubmissionError,
+		},
+	}
+
+	tracker := NewSubmissionTracker(store, time.Hour, logging.NewNoopLogger())
+	tracker.unknownTimeout = time.Millisecond
+	tracker.AddRollup(TEST_ROLLUP_ID, submitter)
+
+	var resubmitted [][2]uint64
+	tracker.SetResubmitHandler(TEST_ROLLUP_ID, func(fromHeight, toHeight uint64) {
+		resubmitted = append(resubmitted, [2]uint64{fromHeight, toHeight})
+	})
+
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 1, 2, Submission{TransactionId: common.Hash{1}}))
+	time.Sleep(10 * time.Millisecond)
+
+	// Known once resubmitted, with the same heights
+	assert.NoError(t, tracker.Track(TEST_ROLLUP_ID, 1, 2, Submission{TransactionId: common.Hash{2}}))
+	tracker.unknownTimeout = 5 * time.Millisecond
+
+	tracker.checkPendingBatches(context.Background())
+	assert.Equal(t, [][2]uint64{{1, 2}}, resubmitted)
+
+	batches := store.Get(nil)
+	assert.Equal(t, BATCH_STATUS_FAILED, batches[0].Status)
+	assert.Equal(t, BATCH_STATUS_SUBMITTED, batches[1].Status)
+
+	submitter.lock.Lock()
+	submitter.statuses = nil
+	submitter.final = map[common.Hash]bool{common.Hash{2}: true}
+	submitter.lock.Unlock()
+
+	tracker.checkPendingBatches(context.Background())
+
+	status := tracker.GetStatus()[TEST_ROLLUP_ID]
+	assert.Equal(t, uint64(2), *status.LastFinalizedHeight)
+	assert.Equal(t, 0, status.PendingBatches)
+	assert.Equal(t, 1, status.FailedBatches)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
@@ -22,16 +23,32 @@ type EventListener interface {
 	OnInvalidNonce()
 	OnExpiredTx()
 	OnTimeoutTx()
+	OnBlockBackfilled()
+	ObserveQueuedBlocks(count int)
+	OnBytesSubmitted(bytes int)
+	ObserveCompressionRatio(ratio float64)
+	OnBatchFinalized(latency time.Duration)
+	OnBatchFailed()
+	ObserveOverdueBatches(count int)
+	OnBatchSkipped()
 }
 
 type SelectiveListener struct {
-	OnBlockReceivedCb      func()
-	OnDaSubmissionFailedCb func()
-	OnDaSubmittedCb        func(duration time.Duration)
-	OnRetriesRequiredCb    func(retries int)
-	OnInvalidNonceCb       func()
-	OnExpiredTxCb          func()
-	OnTimeoutTxCb          func()
+	OnBlockReceivedCb         func()
+	OnDaSubmissionFailedCb    func()
+	OnDaSubmittedCb           func(duration time.Duration)
+	OnRetriesRequiredCb       func(retries int)
+	OnInvalidNonceCb          func()
+	OnExpiredTxCb             func()
+	OnTimeoutTxCb             func()
+	OnBlockBackfilledCb       func()
+	ObserveQueuedBlocksCb     func(count int)
+	OnBytesSubmittedCb        func(bytes int)
+	ObserveCompressionRatioCb func(ratio float64)
+	OnBatchFinalizedCb        func(latency time.Duration)
+	OnBatchFailedCb           func()
+	ObserveOverdueBatchesCb   func(count int)
+	OnBatchSkippedCb          func()
 }
 
 func (l *SelectiveListener) OnBlockReceived() {
@@ -76,23 +93,75 @@ func (l *SelectiveListener) OnTimeoutTx() {
 	}
 }
 
-func MakeRelayerMetrics(registry *prometheus.Registry) (EventListener, error) {
-	numBlocksReceived := prometheus.NewCounter(
+func (l *SelectiveListener) OnBlockBackfilled() {
+	if l.OnBlockBackfilledCb != nil {
+		l.OnBlockBackfilledCb()
+	}
+}
+
+func (l *SelectiveListener) ObserveQueuedBlocks(count int) {
+	if l.ObserveQueuedBlocksCb != nil {
+		l.ObserveQueuedBlocksCb(count)
+	}
+}
+
+func (l *SelectiveListener) OnBytesSubmitted(bytes int) {
+	if l.OnBytesSubmittedCb != nil {
+		l.OnBytesSubmittedCb(bytes)
+	}
+}
+
+func (l *SelectiveListener) ObserveCompressionRatio(ratio float64) {
+	if l.ObserveCompressionRatioCb != nil {
+		l.ObserveCompressionRatioCb(ratio)
+	}
+}
+
+func (l *SelectiveListener) OnBatchFinalized(latency time.Duration) {
+	if l.OnBatchFinalizedCb != nil {
+		l.OnBatchFinalizedCb(latency)
+	}
+}
+
+func (l *SelectiveListener) OnBatchFailed() {
+	if l.OnBatchFailedCb != nil {
+		l.OnBatchFailedCb()
+	}
+}
+
+func (l *SelectiveListener) ObserveOverdueBatches(count int) {
+	if l.ObserveOverdueBatchesCb != nil {
+		l.ObserveOverdueBatchesCb(count)
+	}
+}
+
+func (l *SelectiveListener) OnBatchSkipped() {
+	if l.OnBatchSkippedCb != nil {
+		l.OnBatchSkippedCb()
+	}
+}
+
+// Returns a function making the EventListener of each rollup, whose metrics
+// are labeled with its ID
+func MakeRelayerMetrics(registry *prometheus.Registry) (func(rollupId uint32) EventListener, error) {
+	rollupLabels := []string{"rollup_id"}
+
+	numBlocksReceived := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: RelayerNamespace,
 			Name:      "num_blocks_received",
 			Help:      "The number of blocks received from rollup",
-		})
+		}, rollupLabels)
 
 	if err := registry.Register(numBlocksReceived); err != nil {
 		return nil, fmt.Errorf("error registering numBlocksReceived counter: %w", err)
 	}
 
-	numDaSubmissionsFailed := prometheus.NewCounter(prometheus.CounterOpts{
+	numDaSubmissionsFailed := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: RelayerNamespace,
 		Name:      "num_da_submissions_failed",
 		Help:      "The number of failed da submissions",
-	})
+	}, rollupLabels)
 
 	if err := registry.Register(numDaSubmissionsFailed); err != nil {
 		return nil, fmt.Errorf("error registering numDaSubmissionsFailed counter: %w", err)
@@ -115,77 +184,179 @@ func MakeRelayerMetrics(registry *prometheus.Registry) (EventListener, error) {
 		math.Inf(0),
 	}
 
-	submissionDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+	submissionDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: RelayerNamespace,
 		Name:      "submission_duration_ms",
 		Help:      "Duration of successful DA submissions",
 		Buckets:   latencyBuckets,
-	})
+	}, rollupLabels)
 
 	if err := registry.Register(submissionDuration); err != nil {
 		return nil, fmt.Errorf("error registering submissionDuration histogram: %w", err)
 	}
 
-	retriesHistogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+	retriesHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: RelayerNamespace,
 		Name:      "retries_histogram",
 		Help:      "Histogram of retry counts",
 		Buckets:   prometheus.LinearBuckets(0, 1, SUBMIT_BLOCK_RETRIES),
-	})
+	}, rollupLabels)
 
 	if err := registry.Register(retriesHistogram); err != nil {
 		return nil, fmt.Errorf("error registering retriesHistogram histogram: %w", err)
 	}
 
-	numInvalidNonces := prometheus.NewCounter(prometheus.CounterOpts{
+	numInvalidNonces := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: RelayerNamespace,
 		Name:      "num_of_invalid_nonces",
 		Help:      "Number of InvalidNonce error",
-	})
+	}, rollupLabels)
 	if err := registry.Register(numInvalidNonces); err != nil {
 		return nil, fmt.Errorf("error registering numInvalidNonces count: %w", err)
 	}
 
-	numExpiredTxs := prometheus.NewCounter(prometheus.CounterOpts{
+	numExpiredTxs := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: RelayerNamespace,
 		Name:      "num_of_expired_txs",
 		Help:      "Number of Expired transactions",
-	})
+	}, rollupLabels)
 	if err := registry.Register(numExpiredTxs); err != nil {
 		return nil, fmt.Errorf("error registering numExpiredTxs count: %w", err)
 	}
 
-	numTimeoutTxs := prometheus.NewCounter(prometheus.CounterOpts{
+	numTimeoutTxs := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: RelayerNamespace,
 		Name:      "num_of_timeout_txs",
 		Help:      "Number of Timeout transactions",
-	})
+	}, rollupLabels)
 	if err := registry.Register(numTimeoutTxs); err != nil {
 		return nil, fmt.Errorf("error registering numTimeoutTxs count: %w", err)
 	}
 
-	return &SelectiveListener{
-		OnBlockReceivedCb: func() {
-			numBlocksReceived.Inc()
-		},
-		OnDaSubmissionFailedCb: func() {
-			numDaSubmissionsFailed.Inc()
-		},
-		OnDaSubmittedCb: func(duration time.Duration) {
-			submissionDuration.Observe(float64(duration.Milliseconds()))
-		},
-		OnRetriesRequiredCb: func(retries int) {
-			retriesHistogram.Observe(float64(retries))
-		},
-		OnInvalidNonceCb: func() {
-			numInvalidNonces.Inc()
-		},
-		OnExpiredTxCb: func() {
-			numExpiredTxs.Inc()
-		},
-		OnTimeoutTxCb: func() {
-			numTimeoutTxs.Inc()
-		},
+	numBlocksBackfilled := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_blocks_backfilled",
+		Help:      "Number of blocks fetched to fill height gaps",
+	}, rollupLabels)
+	if err := registry.Register(numBlocksBackfilled); err != nil {
+		return nil, fmt.Errorf("error registering numBlocksBackfilled count: %w", err)
+	}
+
+	numQueuedBlocks := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_queued_blocks",
+		Help:      "Number of blocks waiting to be submitted",
+	}, rollupLabels)
+	if err := registry.Register(numQueuedBlocks); err != nil {
+		return nil, fmt.Errorf("error registering numQueuedBlocks gauge: %w", err)
+	}
+
+	numBytesSubmitted := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_bytes_submitted",
+		Help:      "Number of payload bytes submitted to DA",
+	}, rollupLabels)
+	if err := registry.Register(numBytesSubmitted); err != nil {
+		return nil, fmt.Errorf("error registering numBytesSubmitted count: %w", err)
+	}
+
+	compressionRatio := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: RelayerNamespace,
+		Name:      "compression_ratio",
+		Help:      "Ratio of uncompressed to compressed batch payload sizes",
+		Buckets:   []float64{0.5, 1, 1.5, 2, 3, 4, 6, 8, 12, 16},
+	}, rollupLabels)
+	if err := registry.Register(compressionRatio); err != nil {
+		return nil, fmt.Errorf("error registering compressionRatio histogram: %w", err)
+	}
+
+	finalityLatency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: RelayerNamespace,
+		Name:      "finality_latency_ms",
+		Help:      "Time from submission to DA finality of batches",
+		Buckets:   prometheus.ExponentialBuckets(1000, 2, 10),
+	}, rollupLabels)
+	if err := registry.Register(finalityLatency); err != nil {
+		return nil, fmt.Errorf("error registering finalityLatency histogram: %w", err)
+	}
+
+	numBatchesFailed := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_batches_failed",
+		Help:      "Number of submitted batches that failed on DA",
+	}, rollupLabels)
+	if err := registry.Register(numBatchesFailed); err != nil {
+		return nil, fmt.Errorf("error registering numBatchesFailed count: %w", err)
+	}
+
+	numOverdueBatches := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_overdue_batches",
+		Help:      "Number of submitted batches not finalized on DA within the deadline",
+	}, rollupLabels)
+	if err := registry.Register(numOverdueBatches); err != nil {
+		return nil, fmt.Errorf("error registering numOverdueBatches gauge: %w", err)
+	}
+
+	numBatchesSkipped := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: RelayerNamespace,
+		Name:      "num_batches_skipped",
+		Help:      "Number of batches skipped after being repeatedly rejected",
+	}, rollupLabels)
+	if err := registry.Register(numBatchesSkipped); err != nil {
+		return nil, fmt.Errorf("error registering numBatchesSkipped count: %w", err)
+	}
+
+	return func(rollupId uint32) EventListener {
+		rollupIdLabel := strconv.FormatUint(uint64(rollupId), 10)
+
+		return &SelectiveListener{
+			OnBlockReceivedCb: func() {
+				numBlocksReceived.WithLabelValues(rollupIdLabel).Inc()
+			},
+			OnDaSubmissionFailedCb: func() {
+				numDaSubmissionsFailed.WithLabelValues(rollupIdLabel).Inc()
+			},
+			OnDaSubmittedCb: func(duration time.Duration) {
+				submissionDuration.WithLabelValues(rollupIdLabel).Observe(float64(duration.Milliseconds()))
+			},
+			OnRetriesRequiredCb: func(retries int) {
+				retriesHistogram.WithLabelValues(rollupIdLabel).Observe(float64(retries))
+			},
+			OnInvalidNonceCb: func() {
+				numInvalidNonces.WithLabelValues(rollupIdLabel).Inc()
+			},
+			OnExpiredTxCb: func() {
+				numExpiredTxs.WithLabelValues(rollupIdLabel).Inc()
+			},
+			OnTimeoutTxCb: func() {
+				numTimeoutTxs.WithLabelValues(rollupIdLabel).Inc()
+			},
+			OnBlockBackfilledCb: func() {
+				numBlocksBackfilled.WithLabelValues(rollupIdLabel).Inc()
+			},
+			ObserveQueuedBlocksCb: func(count int) {
+				numQueuedBlocks.WithLabelValues(rollupIdLabel).Set(float64(count))
+			},
+			OnBytesSubmittedCb: func(bytes int) {
+				numBytesSubmitted.WithLabelValues(rollupIdLabel).Add(float64(bytes))
+			},
+			ObserveCompressionRatioCb: func(ratio float64) {
+				compressionRatio.WithLabelValues(rollupIdLabel).Observe(ratio)
+			},
+			OnBatchFinalizedCb: func(latency time.Duration) {
+				finalityLatency.WithLabelValues(rollupIdLabel).Observe(float64(latency.Milliseconds()))
+			},
+			OnBatchFailedCb: func() {
+				numBatchesFailed.WithLabelValues(rollupIdLabel).Inc()
+			},
+			ObserveOverdueBatchesCb: func(count int) {
+				numOverdueBatches.WithLabelValues(rollupIdLabel).Set(float64(count))
+			},
+			OnBatchSkippedCb: func() {
+				numBatchesSkipped.WithLabelValues(rollupIdLabel).Inc()
+			},
+		}
 	}, nil
 }
 
@@ -0,0 +1,202 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Nuffle-Labs/nffl/core"
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/relayer/config"
+	"github.com/Nuffle-Labs/nffl/relayer/da"
+)
+
+// MultiRelayer runs a Relayer per configured rollup in a single process.
+// Rollups whose submissions are signed with the same NEAR access key share a
+// lock, so that their transactions don't race for nonces.
+type MultiRelayer struct {
+	relayers         []*Relayer
+	tracker          *da.SubmissionTracker
+	cursorStore      *safeclient.FileCursorStore
+	statusIpPortAddr string
+	logger           sdklogging.Logger
+}
+
+var _ core.Metricable = (*MultiRelayer)(nil)
+
+func NewMultiRelayerFromConfig(config *config.RelayerConfig, logger sdklogging.Logger) (*MultiRelayer, error) {
+	if config.IncludeReceipts && !config.FullBlocks {
+		return nil, errors.New("receipts can only be included in full blocks")
+	}
+
+	switch config.Compression {
+	case coretypes.ROLLUP_BLOCKS_COMPRESSION_NONE, coretypes.ROLLUP_BLOCKS_COMPRESSION_ZSTD, coretypes.ROLLUP_BLOCKS_COMPRESSION_BROTLI:
+	default:
+		return nil, fmt.Errorf("unknown compression: %s", config.Compression)
+	}
+
+	rollups := config.GetRollups()
+	if err := validateRollups(rollups); err != nil {
+		return nil, err
+	}
+
+	var cursorStore safeclient.CursorStore
+	var fileCursorStore *safeclient.FileCursorStore
+	if config.CursorPath != "" {
+		store, err := safeclient.NewFileCursorStore(config.CursorPath)
+		if err != nil {
+			return nil, err
+		}
+
+		cursorStore = store
+		fileCursorStore = store
+	}
+
+	batchStore, err := da.NewBatchStore(config.BatchStorePath)
+	if err != nil {
+		return nil, err
+	}
+	tracker := da.NewSubmissionTracker(batchStore, time.Duration(config.FinalityDeadlineMs)*time.Millisecond, logger)
+
+	nearKeyLocks := newNearKeyLocks()
+	relayers := make([]*Relayer, len(rollups))
+	for i, rollup := range rollups {
+		rollupLogger := logger.With("rollupId", rollup.RollupId)
+
+		daSubmitter, err := newDaSubmitter(config, rollup, nearKeyLocks)
+		if err != nil {
+			return nil, err
+		}
+
+		relayer, err := newRelayer(config, rollup, daSubmitter, tracker, cursorStore, rollupLogger)
+		if err != nil {
+			return nil, err
+		}
+
+		tracker.AddRollup(rollup.RollupId, daSubmitter)
+		tracker.SetResubmitHandler(rollup.RollupId, relayer.resubmit)
+
+		relayers[i] = relayer
+	}
+
+	return &MultiRelayer{
+		relayers:         relayers,
+		tracker:          tracker,
+		cursorStore:      fileCursorStore,
+		statusIpPortAddr: config.StatusIpPortAddr,
+		logger:           logger,
+	}, nil
+}
+
+// Checks that rollup IDs are unique, as well as namespaces within each DA
+// account
+func validateRollups(rollups []config.RollupConfig) error {
+	rollupIds := make(map[uint32]bool)
+	namespaces := make(map[string]uint32)
+
+	for _, rollup := range rollups {
+		if rollupIds[rollup.RollupId] {
+			return fmt.Errorf("duplicate rollup ID: %d", rollup.RollupId)
+		}
+		rollupIds[rollup.RollupId] = true
+
+		namespaceKey := fmt.Sprintf("%s/%d", rollup.DaAccountId, getNamespaceId(rollup))
+		if otherRollupId, ok := namespaces[namespaceKey]; ok {
+			return fmt.Errorf("rollups %d and %d share a namespace in DA account %s", otherRollupId, rollup.RollupId, rollup.DaAccountId)
+		}
+		namespaces[namespaceKey] = rollup.RollupId
+	}
+
+	return nil
+}
+
+func getNamespaceId(rollup config.RollupConfig) uint32 {
+	if rollup.NamespaceId == 0 {
+		return DEFAULT_NAMESPACE_ID
+	}
+
+	return rollup.NamespaceId
+}
+
+func newDaSubmitter(config *config.RelayerConfig, rollup config.RollupConfig, nearKeyLocks *nearKeyLocks) (da.DaSubmitter, error) {
+	switch config.DaBackend {
+	case "", NEAR_DA_BACKEND:
+		if rollup.KeyPath == "" || rollup.DaAccountId == "" {
+			return nil, errors.New("NEAR DA backend requires a key path and DA account ID")
+		}
+
+		lock, err := nearKeyLocks.forKeyFile(rollup.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNearDaSubmitter(rollup.KeyPath, rollup.DaAccountId, config.Network, getNamespaceId(rollup), lock)
+	case LOCAL_DA_BACKEND:
+		if config.LocalDaUrl == "" {
+			return nil, errors.New("local DA backend requires a URL")
+		}
+
+		return da.NewLocalDaSubmitter(config.LocalDaUrl, rollup.RollupId), nil
+	default:
+		return nil, fmt.Errorf("unknown DA backend: %s", config.DaBackend)
+	}
+}
+
+func (m *MultiRelayer) EnableMetrics(registry *prometheus.Registry) error {
+	makeListener, err := MakeRelayerMetrics(registry)
+	if err != nil {
+		return err
+	}
+
+	for _, relayer := range m.relayers {
+		relayer.listener = makeListener(relayer.rollupId)
+		m.tracker.SetListener(relayer.rollupId, relayer.listener)
+	}
+
+	return nil
+}
+
+// Runs all relayers until the context is done or one of them fails, which
+// stops the others, along with the submission tracker and its status server.
+// Pending cursors are written out once all relayers stopped.
+func (m *MultiRelayer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go m.tracker.Start(ctx)
+
+	if m.statusIpPortAddr != "" {
+		go func() {
+			if err := m.tracker.StartServer(ctx, m.statusIpPortAddr); err != nil {
+				m.logger.Error("Status server error", "err", err)
+			}
+		}()
+	}
+
+	errC := make(chan error, len(m.relayers))
+	for _, relayer := range m.relayers {
+		go func(relayer *Relayer) {
+			errC <- relayer.Start(ctx)
+		}(relayer)
+	}
+
+	err := <-errC
+	cancel()
+
+	for i := 1; i < len(m.relayers); i++ {
+		<-errC
+	}
+
+	if m.cursorStore != nil {
+		if closeErr := m.cursorStore.Close(); closeErr != nil {
+			m.logger.Error("Failed to write cursors", "err", closeErr)
+		}
+	}
+
+	return err
+}
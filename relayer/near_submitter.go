@@ -0,0 +1,146 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	near "github.com/near/rollup-data-availability/gopkg/da-rpc"
+
+	"github.com/Nuffle-Labs/nffl/relayer/da"
+)
+
+// NearDaSubmitter submits blobs to NEAR DA through the da-rpc client.
+// Submitters signing with the same access key must share the lock, as
+// concurrent transactions would use the same nonce.
+type NearDaSubmitter struct {
+	client nearClient
+	lock   *sync.Mutex
+	*da.NearFinalityChecker
+}
+
+// Subset of the da-rpc client used for submissions
+type nearClient interface {
+	ForceSubmit(data []byte) ([]byte, error)
+}
+
+var _ da.DaSubmitter = (*NearDaSubmitter)(nil)
+
+func NewNearDaSubmitter(keyPath, daAccountId, network string, namespaceId uint32, lock *sync.Mutex) (*NearDaSubmitter, error) {
+	key, err := readNearKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := near.NewConfigFile(keyPath, daAccountId, network, namespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NearDaSubmitter{
+		client:              client,
+		lock:                lock,
+		NearFinalityChecker: da.NewNearFinalityChecker(da.GetNearRpcUrl(network), key.AccountId),
+	}, nil
+}
+
+// Access key of the account signing the submissions
+type nearKey struct {
+	AccountId string `json:"account_id"`
+	PublicKey string `json:"public_key"`
+}
+
+func readNearKey(keyPath string) (nearKey, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nearKey{}, err
+	}
+
+	var key nearKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nearKey{}, err
+	}
+
+	if key.AccountId == "" {
+		return nearKey{}, errors.New("NEAR key file has no account ID")
+	}
+
+	if key.PublicKey == "" {
+		return nearKey{}, errors.New("NEAR key file has no public key")
+	}
+
+	return key, nil
+}
+
+// Locks shared by the submitters signing with the same access key. NEAR
+// tracks nonces per account ID and public key, so that's what locks are keyed
+// by, rather than the key file path, which may differ for the same key.
+type nearKeyLocks struct {
+	lock  sync.Mutex
+	locks map[nearKey]*sync.Mutex
+}
+
+func newNearKeyLocks() *nearKeyLocks {
+	return &nearKeyLocks{locks: make(map[nearKey]*sync.Mutex)}
+}
+
+// Returns the lock of the access key in a key file
+func (l *nearKeyLocks) forKeyFile(keyPath string) (*sync.Mutex, error) {
+	key, err := readNearKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = new(sync.Mutex)
+		l.locks[key] = lock
+	}
+
+	return lock, nil
+}
+
+// The client doesn't support cancellation, so ctx is unused. Its errors are
+// only exposed as strings, so they're matched here once and wrapped into the
+// typed DA errors.
+func (s *NearDaSubmitter) Submit(ctx context.Context, data []byte) (da.Submission, error) {
+	s.lock.Lock()
+	out, err := s.client.ForceSubmit(data)
+	s.lock.Unlock()
+	if err == nil {
+		return parseFrameRef(out)
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "InvalidNonce"):
+		return da.Submission{}, fmt.Errorf("%w: %w", da.InvalidNonceError, err)
+	case strings.Contains(err.Error(), "Expired"):
+		return da.Submission{}, fmt.Errorf("%w: %w", da.ExpiredTxError, err)
+	case strings.Contains(err.Error(), "Timeout"):
+		return da.Submission{}, fmt.Errorf("%w: %w", da.TimeoutError, err)
+	default:
+		return da.Submission{}, fmt.Errorf("%w: %w", da.UnavailableError, err)
+	}
+}
+
+// Parses the frame reference returned on submission, which is the
+// transaction ID followed by the blob commitment
+func parseFrameRef(out []byte) (da.Submission, error) {
+	var frameRef near.FrameRef
+	if err := frameRef.UnmarshalBinary(out); err != nil || len(out) != 2*common.HashLength {
+		return da.Submission{}, fmt.Errorf("invalid NEAR DA frame reference of length %d", len(out))
+	}
+
+	return da.Submission{
+		TransactionId: common.BytesToHash(frameRef.TxId),
+		Commitment:    common.BytesToHash(frameRef.TxCommitment),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails submissions that overlap, as they would reuse the same nonce
+type mockNearClient struct {
+	inFlight *atomic.Int32
+}
+
+func (c *mockNearClient) ForceSubmit(data []byte) ([]byte, error) {
+	if c.inFlight.Add(1) > 1 {
+		c.inFlight.Add(-1)
+		return nil, errors.New("InvalidNonce")
+	}
+	defer c.inFlight.Add(-1)
+
+	time.Sleep(time.Millisecond)
+	return make([]byte, 64), nil
+}
+
+func writeNearKeyFile(t *testing.T, name string, key nearKey) string {
+	data, err := json.Marshal(key)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestNearKeyLocksKeyedByAccessKey(t *testing.T) {
+	key := nearKey{AccountId: "relayer.testnet", PublicKey: "ed25519:A"}
+	locks := newNearKeyLocks()
+
+	lock, err := locks.forKeyFile(writeNearKeyFile(t, "a.json", key))
+	assert.NoError(t, err)
+
+	// Same access key in another file
+	sameKeyLock, err := locks.forKeyFile(writeNearKeyFile(t, "b.json", key))
+	assert.NoError(t, err)
+	assert.Same(t, lock, sameKeyLock)
+
+	// Same account, other access key
+	otherKeyLock, err := locks.forKeyFile(writeNearKeyFile(t, "c.json", nearKey{AccountId: key.AccountId, PublicKey: "ed25519:B"}))
+	assert.NoError(t, err)
+	assert.NotSame(t, lock, otherKeyLock)
+
+	_, err = locks.forKeyFile(writeNearKeyFile(t, "d.json", nearKey{AccountId: key.AccountId}))
+	assert.Error(t, err)
+}
+
+func TestConcurrentSubmittersSharingAccessKey(t *testing.T) {
+	key := nearKey{AccountId: "relayer.testnet", PublicKey: "ed25519:A"}
+	locks := newNearKeyLocks()
+	inFlight := new(atomic.Int32)
+
+	submitters := make([]*NearDaSubmitter, 4)
+	for i := range submitters {
+		lock, err := locks.forKeyFile(writeNearKeyFile(t, "key.json", key))
+		assert.NoError(t, err)
+
+		submitters[i] = &NearDaSubmitter{client: &mockNearClient{inFlight: inFlight}, lock: lock}
+	}
+
+	var wg sync.WaitGroup
+	for _, submitter := range submitters {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(submitter *NearDaSubmitter) {
+				defer wg.Done()
+
+				_, err := submitter.Submit(context.Background(), []byte{1})
+				assert.NoError(t, err)
+			}(submitter)
+		}
+	}
+	wg.Wait()
+}
@@ -3,84 +3,205 @@ package relayer
 import (
 	"context"
 	"errors"
-	"strings"
+	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
-	near "github.com/near/rollup-data-availability/gopkg/da-rpc"
-	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/Nuffle-Labs/nffl/core"
 	"github.com/Nuffle-Labs/nffl/core/safeclient"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
 	"github.com/Nuffle-Labs/nffl/relayer/config"
+	"github.com/Nuffle-Labs/nffl/relayer/da"
 )
 
 const (
-	NAMESPACE_ID               = 1
-	SUBMIT_BLOCK_INTERVAL      = 2500 * time.Millisecond
+	DEFAULT_NAMESPACE_ID       = 1
 	SUBMIT_BLOCK_RETRY_TIMEOUT = 2 * time.Second
 	SUBMIT_BLOCK_RETRIES       = 3
+	// How often the queue is checked for a batch to submit
+	SUBMIT_CHECK_INTERVAL = 250 * time.Millisecond
+	// Batches are capped so that large backfills are spread over several
+	// submissions
+	DEFAULT_MAX_BATCH_BLOCKS   = 100
+	DEFAULT_MIN_BATCH_INTERVAL = 2500 * time.Millisecond
+	// Kept under the NEAR transaction size limit
+	DEFAULT_MAX_BLOB_SIZE = 1024 * 1024
+	// Upper bound on the bytes a batch takes besides its encoded blocks, i.e.
+	// the format and compression bytes and the RLP list header
+	BATCH_SIZE_OVERHEAD = 16
+	// Blocks backfilled per received header, so that headers keep being
+	// consumed during long backfills
+	BACKFILL_MAX_BLOCKS = 1000
+	// Blocks kept in the queue at most. Once reached, new headers are dropped
+	// and their heights backfilled after the queue drains.
+	MAX_QUEUED_BLOCKS = 10_000
+	// Rejections after which a batch is skipped, so that a batch the DA
+	// backend never accepts doesn't halt the rollup. Transient DA errors don't
+	// count towards it.
+	MAX_BATCH_REJECTIONS = 5
+	CURSOR_KEY           = "last_submitted_height"
+
+	NEAR_DA_BACKEND  = "near"
+	LOCAL_DA_BACKEND = "local"
 )
 
+var (
+	// Returned when a batch can't be encoded or is over the max blob size,
+	// which no retry fixes. DA backend errors never reject a batch, as they
+	// can't be told apart from outages.
+	BatchRejectedError = errors.New("Batch rejected")
+	// Returned when a batch's encoded payload is over the max blob size, which
+	// only happens for a single block too large even after compression
+	BlobSizeExceededError = errors.New("Batch exceeds the max blob size")
+)
+
+// Relayer submits the blocks of a rollup to a DA backend in strictly increasing and
+// contiguous height order. Blocks are queued right after the last queued
+// height, backfilling any missed heights first, and a batch is only removed
+// from the queue, and the cursor moved, once submitted. Failed batches are
+// therefore retried before any later block is submitted, unless it fails to
+// encode MAX_BATCH_REJECTIONS times in a row or exceeds the max blob size, in
+// which case it's skipped. Heights at
+// or below the last queued one, such as reorged blocks, are not queued again.
+//
+// Batches that fail on DA after submission are fetched again and resubmitted
+// ahead of the queue.
+//
+// The queue holds at most maxQueuedBlocks blocks. While full, no more blocks
+// are fetched, and the missed heights are backfilled once it drains.
+//
+// A batch is the longest queue prefix within the max block count and blob
+// size. It's submitted as soon as it's full, or otherwise once the min batch
+// interval has passed since the last submission.
 type Relayer struct {
-	rpcClient   safeclient.SafeClient
-	rpcUrl      string
-	daAccountId string
-	logger      sdklogging.Logger
-	listener    EventListener
+	rollupId  uint32
+	rpcClient safeclient.SafeClient
+	rpcUrl    string
+	logger    sdklogging.Logger
+	listener  EventListener
+
+	fullBlocks      bool
+	includeReceipts bool
+
+	maxBlobSize      int
+	maxBatchBlocks   int
+	minBatchInterval time.Duration
+	maxQueuedBlocks  int
+	compression      string
+	// Only accessed by the submission loop
+	nextSubmissionTime time.Time
+	retryTime          time.Time
+	batchRejections    int
+
+	cursorStore safeclient.CursorStore
+	queueLock   sync.Mutex
+	queue       []queuedBlock
+	// Height ranges of the batches failed on DA, oldest first
+	resubmissionsLock sync.Mutex
+	resubmissions     []heightRange
+	// Only accessed by the block listener
+	nextHeight    uint64
+	hasNextHeight bool
+
+	daSubmitter da.DaSubmitter
+	tracker     *da.SubmissionTracker
+}
 
-	nearClient *near.Config
+type heightRange struct {
+	from uint64
+	to   uint64
 }
 
-var _ core.Metricable = (*Relayer)(nil)
+type queuedBlock struct {
+	block coretypes.RollupBlock
+	// RLP-encoded size in the submitted format
+	size int
+}
 
-func NewRelayerFromConfig(config *config.RelayerConfig, logger sdklogging.Logger) (*Relayer, error) {
-	rpcClient, err := safeclient.NewSafeEthClient(config.RpcUrl, logger)
+func newRelayer(config *config.RelayerConfig, rollup config.RollupConfig, daSubmitter da.DaSubmitter, tracker *da.SubmissionTracker, cursorStore safeclient.CursorStore, logger sdklogging.Logger) (*Relayer, error) {
+	rpcClient, err := safeclient.NewRollupSafeEthClient(rollup.RollupId, rollup.RpcUrl, rollup.FailoverRpcUrls, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	nearClient, err := near.NewConfigFile(config.KeyPath, config.DaAccountId, config.Network, NAMESPACE_ID)
-	if err != nil {
-		return nil, err
+	maxBlobSize := DEFAULT_MAX_BLOB_SIZE
+	if config.MaxBlobSize != 0 {
+		maxBlobSize = int(config.MaxBlobSize)
 	}
 
-	return &Relayer{
-		rpcClient:   rpcClient,
-		rpcUrl:      config.RpcUrl,
-		daAccountId: config.DaAccountId,
-		nearClient:  nearClient,
-		logger:      logger,
-		listener:    &SelectiveListener{},
-	}, nil
-}
+	maxBatchBlocks := DEFAULT_MAX_BATCH_BLOCKS
+	if config.MaxBatchBlocks != 0 {
+		maxBatchBlocks = int(config.MaxBatchBlocks)
+	}
 
-func (r *Relayer) EnableMetrics(registry *prometheus.Registry) error {
-	listener, err := MakeRelayerMetrics(registry)
-	if err != nil {
-		return err
+	minBatchInterval := DEFAULT_MIN_BATCH_INTERVAL
+	if config.MinBatchIntervalMs != 0 {
+		minBatchInterval = time.Duration(config.MinBatchIntervalMs) * time.Millisecond
 	}
 
-	r.listener = listener
-	return nil
+	relayer := &Relayer{
+		rollupId:         rollup.RollupId,
+		rpcClient:        rpcClient,
+		rpcUrl:           rollup.RpcUrl,
+		daSubmitter:      daSubmitter,
+		tracker:          tracker,
+		logger:           logger,
+		listener:         &SelectiveListener{},
+		fullBlocks:       config.FullBlocks,
+		includeReceipts:  config.IncludeReceipts,
+		maxBlobSize:      maxBlobSize,
+		maxBatchBlocks:   maxBatchBlocks,
+		minBatchInterval: minBatchInterval,
+		compression:      config.Compression,
+		maxQueuedBlocks:  MAX_QUEUED_BLOCKS,
+	}
+
+	if cursorStore != nil {
+		lastSubmittedHeight, ok, err := cursorStore.FetchCursor(getCursorKey(rollup.RollupId))
+		if err != nil {
+			return nil, err
+		}
+
+		// Single rollup relayers used to store their cursor without the ID
+		if !ok && len(config.Rollups) == 0 {
+			lastSubmittedHeight, ok, err = cursorStore.FetchCursor(CURSOR_KEY)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if ok {
+			logger.Info("Resuming from last submitted height", "height", lastSubmittedHeight)
+			relayer.nextHeight = lastSubmittedHeight + 1
+			relayer.hasNextHeight = true
+		}
+
+		relayer.cursorStore = cursorStore
+	}
+
+	return relayer, nil
 }
 
-func (r *Relayer) Start(ctx context.Context) error {
-	blocksToSubmit := make(chan []*ethtypes.Block)
+func getCursorKey(rollupId uint32) string {
+	return fmt.Sprintf("%s/%d", CURSOR_KEY, rollupId)
+}
 
-	ticker := time.NewTicker(SUBMIT_BLOCK_INTERVAL)
+func (r *Relayer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(SUBMIT_CHECK_INTERVAL)
 	defer ticker.Stop()
 
-	go r.listenToBlocks(ctx, blocksToSubmit, ticker)
+	go r.listenToBlocks(ctx)
 
 	for {
 		select {
-		case blocks := <-blocksToSubmit:
-			err := r.handleBlocks(blocks, ticker)
+		case <-ticker.C:
+			err := r.submitQueuedBlocks(ctx)
 			if err != nil {
-				r.logger.Error("Error handling blocks", "err", err)
+				r.logger.Error("Error handling blocks, keeping them queued", "err", err)
 			}
 		case <-ctx.Done():
 			r.rpcClient.Close()
@@ -89,22 +210,258 @@ func (r *Relayer) Start(ctx context.Context) error {
 	}
 }
 
-func (r *Relayer) handleBlocks(blocks []*ethtypes.Block, ticker *time.Ticker) error {
-	defer ticker.Reset(SUBMIT_BLOCK_INTERVAL)
+// Submits the next batch if it's due, only dequeuing its blocks once
+// submitted
+func (r *Relayer) submitQueuedBlocks(ctx context.Context) error {
+	if resubmitting, err := r.resubmitFailedBatch(ctx); resubmitting {
+		return err
+	}
+
+	blocks, full := r.nextBatch()
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	// Failed batches are only retried after the min interval, even if full
+	now := time.Now()
+	if now.Before(r.retryTime) || (!full && now.Before(r.nextSubmissionTime)) {
+		return nil
+	}
+
+	err := r.handleBlocks(ctx, blocks)
+	if err != nil {
+		r.retryTime = time.Now().Add(r.minBatchInterval)
+		// Submission errors keep the batch queued however often they occur
+		if !errors.Is(err, BatchRejectedError) {
+			return err
+		}
+
+		r.batchRejections++
+		// An oversize block will never fit, so it's skipped right away
+		if r.batchRejections < MAX_BATCH_REJECTIONS && !errors.Is(err, BlobSizeExceededError) {
+			return err
+		}
+
+		r.logger.Error("Batch repeatedly rejected, skipping it", "from", blocks[0].Header.Number.Uint64(), "to", blocks[len(blocks)-1].Header.Number.Uint64(), "rejections", r.batchRejections, "err", err)
+		r.listener.OnBatchSkipped()
+	} else {
+		r.nextSubmissionTime = time.Now().Add(r.minBatchInterval)
+	}
+
+	r.batchRejections = 0
+	r.dequeue(blocks)
+
+	return nil
+}
+
+// Schedules a batch failed on DA to be submitted again
+func (r *Relayer) resubmit(fromHeight, toHeight uint64) {
+	r.resubmissionsLock.Lock()
+	defer r.resubmissionsLock.Unlock()
+
+	r.resubmissions = append(r.resubmissions, heightRange{from: fromHeight, to: toHeight})
+}
+
+// Fetches and submits the oldest failed batch, if any, returning whether
+// there was one. Queued blocks wait until all failed batches are resubmitted.
+func (r *Relayer) resubmitFailedBatch(ctx context.Context) (bool, error) {
+	r.resubmissionsLock.Lock()
+	if len(r.resubmissions) == 0 {
+		r.resubmissionsLock.Unlock()
+		return false, nil
+	}
+	heights := r.resubmissions[0]
+	r.resubmissionsLock.Unlock()
+
+	if time.Now().Before(r.retryTime) {
+		return true, nil
+	}
+
+	blocks := make([]coretypes.RollupBlock, 0, heights.to-heights.from+1)
+	for height := heights.from; height <= heights.to; height++ {
+		block, err := r.fetchBlockByNumber(ctx, height)
+		if err != nil {
+			r.retryTime = time.Now().Add(r.minBatchInterval)
+			return true, fmt.Errorf("error fetching rollup block %d to resubmit: %w", height, err)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if err := r.handleBlocks(ctx, blocks); err != nil {
+		r.retryTime = time.Now().Add(r.minBatchInterval)
+		return true, err
+	}
+
+	r.nextSubmissionTime = time.Now().Add(r.minBatchInterval)
+
+	r.resubmissionsLock.Lock()
+	r.resubmissions = r.resubmissions[1:]
+	r.resubmissionsLock.Unlock()
+
+	return true, nil
+}
+
+// Removes a batch from the queue head and moves the cursor past it
+func (r *Relayer) dequeue(blocks []coretypes.RollupBlock) {
+	r.queueLock.Lock()
+	r.queue = r.queue[len(blocks):]
+	r.listener.ObserveQueuedBlocks(len(r.queue))
+	r.queueLock.Unlock()
+
+	if r.cursorStore != nil {
+		lastHeight := blocks[len(blocks)-1].Header.Number.Uint64()
+		if err := r.cursorStore.StoreCursor(getCursorKey(r.rollupId), lastHeight); err != nil {
+			r.logger.Error("Error storing last submitted height", "height", lastHeight, "err", err)
+		}
+	}
+}
+
+// Returns the longest queue prefix within the batch limits, and whether the
+// limits were reached. Sizes are uncompressed, so that the batch payload fits
+// whether or not compression helps. A block over the size limit is still
+// taken alone, as it can't be split, and is only submitted if it fits once
+// compressed.
+func (r *Relayer) nextBatch() ([]coretypes.RollupBlock, bool) {
+	r.queueLock.Lock()
+	defer r.queueLock.Unlock()
+
+	blocks := make([]coretypes.RollupBlock, 0, min(len(r.queue), r.maxBatchBlocks))
+	size := BATCH_SIZE_OVERHEAD
+	for _, queued := range r.queue {
+		if len(blocks) == r.maxBatchBlocks {
+			return blocks, true
+		}
+
+		size += queued.size
+		if size > r.maxBlobSize {
+			if len(blocks) == 0 {
+				r.logger.Warn("Rollup block exceeds the max blob size uncompressed, batching it alone", "number", queued.block.Header.Number.Uint64(), "size", queued.size)
+				blocks = append(blocks, queued.block)
+			}
+
+			return blocks, true
+		}
+
+		blocks = append(blocks, queued.block)
+	}
+
+	return blocks, len(blocks) == r.maxBatchBlocks
+}
+
+func (r *Relayer) queueBlock(block coretypes.RollupBlock) error {
+	size, err := r.encodedBlockSize(block)
+	if err != nil {
+		return err
+	}
+
+	r.nextHeight = block.Header.Number.Uint64() + 1
+	r.hasNextHeight = true
+
+	r.queueLock.Lock()
+	r.queue = append(r.queue, queuedBlock{block: block, size: size})
+	r.listener.ObserveQueuedBlocks(len(r.queue))
+	r.queueLock.Unlock()
+
+	return nil
+}
+
+func (r *Relayer) queueFull() bool {
+	r.queueLock.Lock()
+	defer r.queueLock.Unlock()
+
+	return len(r.queue) >= r.maxQueuedBlocks
+}
+
+func (r *Relayer) encodedBlockSize(block coretypes.RollupBlock) (int, error) {
+	var data []byte
+	var err error
+	if r.fullBlocks {
+		data, err = rlp.EncodeToBytes(block)
+	} else {
+		data, err = rlp.EncodeToBytes(ethtypes.NewBlockWithHeader(block.Header))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Queues the block of a new header, first backfilling the heights missed
+// since the last queued one. If the backfill doesn't complete, the header is
+// dropped and the backfill continues on the next one. The same goes for a
+// full queue, which is how DA submission backpressure reaches the listener.
+func (r *Relayer) queueBlocksUpTo(ctx context.Context, header *ethtypes.Header) {
+	height := header.Number.Uint64()
+	if r.hasNextHeight && height < r.nextHeight {
+		r.logger.Warn("Rollup block height already queued, skipping", "number", height)
+		return
+	}
+
+	if r.hasNextHeight && height > r.nextHeight {
+		r.logger.Info("Backfilling missed rollup blocks", "from", r.nextHeight, "to", height-1)
+
+		backfillEnd := min(height, r.nextHeight+BACKFILL_MAX_BLOCKS)
+		for r.nextHeight < backfillEnd {
+			if r.queueFull() {
+				r.logger.Warn("Block queue full, pausing backfill", "number", r.nextHeight)
+				return
+			}
+
+			block, err := r.fetchBlockByNumber(ctx, r.nextHeight)
+			if err != nil {
+				r.logger.Error("Error backfilling rollup block", "number", r.nextHeight, "err", err)
+				return
+			}
+
+			if err := r.queueBlock(block); err != nil {
+				r.logger.Error("Error queueing backfilled rollup block", "number", r.nextHeight, "err", err)
+				return
+			}
+			r.listener.OnBlockBackfilled()
+		}
+
+		if r.nextHeight < height {
+			return
+		}
+	}
+
+	if r.queueFull() {
+		r.logger.Warn("Block queue full, dropping header until it drains", "number", height)
+		return
+	}
 
+	block, err := r.fetchBlock(ctx, header)
+	if err != nil {
+		r.logger.Error("Error fetching rollup block", "number", height, "err", err)
+		return
+	}
+
+	if err := r.queueBlock(block); err != nil {
+		r.logger.Error("Error queueing rollup block", "number", height, "err", err)
+	}
+}
+
+func (r *Relayer) handleBlocks(ctx context.Context, blocks []coretypes.RollupBlock) error {
 	blockNumbers := make([]uint64, len(blocks))
 	for i, block := range blocks {
-		blockNumbers[i] = block.Number().Uint64()
+		blockNumbers[i] = block.Header.Number.Uint64()
 	}
-	r.logger.Info("Submitting blocks to NEAR", "numbers", blockNumbers)
+	r.logger.Info("Submitting blocks to DA", "numbers", blockNumbers)
 
-	encodedBlocks, err := rlp.EncodeToBytes(blocks)
+	encodedBlocks, err := r.encodeBlocks(blocks)
 	if err != nil {
 		r.logger.Error("Error RLP encoding block", "err", err.Error())
-		return err
+		return fmt.Errorf("%w: %w", BatchRejectedError, err)
 	}
 
-	out, err := r.submitEncodedBlocks(encodedBlocks)
+	if len(encodedBlocks) > r.maxBlobSize {
+		r.logger.Error("Encoded blocks exceed the max blob size", "numbers", blockNumbers, "size", len(encodedBlocks), "maxBlobSize", r.maxBlobSize)
+		return fmt.Errorf("%w: %w: %d > %d bytes", BatchRejectedError, BlobSizeExceededError, len(encodedBlocks), r.maxBlobSize)
+	}
+
+	submission, err := r.submitEncodedBlocks(ctx, encodedBlocks)
 	if err != nil {
 		r.logger.Error("Error submitting encoded blocks", "err", err)
 		r.listener.OnDaSubmissionFailed()
@@ -112,44 +469,140 @@ func (r *Relayer) handleBlocks(blocks []*ethtypes.Block, ticker *time.Ticker) er
 		return err
 	}
 
-	r.logger.Info(string(out))
+	r.logger.Info("Submitted blocks to DA", "numbers", blockNumbers, "transactionId", submission.TransactionId, "commitment", submission.Commitment)
+	r.listener.OnBytesSubmitted(len(encodedBlocks))
+
+	err = r.tracker.Track(r.rollupId, blockNumbers[0], blockNumbers[len(blockNumbers)-1], submission)
+	if err != nil {
+		r.logger.Error("Error tracking submitted blocks", "err", err)
+	}
 
 	return nil
 }
 
-func (r *Relayer) submitEncodedBlocks(encodedBlocks []byte) ([]byte, error) {
+// Encodes blocks in the configured format and compresses them, falling back
+// to the uncompressed payload if it's smaller
+func (r *Relayer) encodeBlocks(blocks []coretypes.RollupBlock) ([]byte, error) {
+	var data []byte
+	var err error
+	if r.fullBlocks {
+		data, err = coretypes.EncodeRollupBlocks(blocks)
+	} else {
+		data, err = coretypes.EncodeLegacyRollupBlocks(blocks)
+	}
+	if err != nil || r.compression == coretypes.ROLLUP_BLOCKS_COMPRESSION_NONE {
+		return data, err
+	}
+
+	compressed, err := coretypes.CompressRollupBlocks(data, r.compression)
+	if err != nil {
+		return nil, err
+	}
+
+	r.listener.ObserveCompressionRatio(float64(len(data)) / float64(len(compressed)))
+
+	if len(compressed) >= len(data) {
+		return data, nil
+	}
+
+	return compressed, nil
+}
+
+// Gets the block to submit for a header, which is either the header alone or
+// the full block, optionally with its receipts
+func (r *Relayer) fetchBlock(ctx context.Context, header *ethtypes.Header) (coretypes.RollupBlock, error) {
+	if !r.fullBlocks {
+		return coretypes.RollupBlock{Header: header}, nil
+	}
+
+	block, err := r.rpcClient.BlockByHash(ctx, header.Hash())
+	if err != nil {
+		return coretypes.RollupBlock{}, err
+	}
+
+	return r.withReceipts(ctx, block)
+}
+
+func (r *Relayer) fetchBlockByNumber(ctx context.Context, height uint64) (coretypes.RollupBlock, error) {
+	number := new(big.Int).SetUint64(height)
+
+	if !r.fullBlocks {
+		header, err := r.rpcClient.HeaderByNumber(ctx, number)
+		if err != nil {
+			return coretypes.RollupBlock{}, err
+		}
+
+		return coretypes.RollupBlock{Header: header}, nil
+	}
+
+	block, err := r.rpcClient.BlockByNumber(ctx, number)
+	if err != nil {
+		return coretypes.RollupBlock{}, err
+	}
+
+	return r.withReceipts(ctx, block)
+}
+
+func (r *Relayer) withReceipts(ctx context.Context, block *ethtypes.Block) (coretypes.RollupBlock, error) {
+	if !r.includeReceipts {
+		return coretypes.NewRollupBlock(block, nil), nil
+	}
+
+	receipts := make([]*ethtypes.Receipt, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		receipt, err := r.rpcClient.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return coretypes.RollupBlock{}, err
+		}
+
+		receipts[i] = receipt
+	}
+
+	return coretypes.NewRollupBlock(block, receipts), nil
+}
+
+func (r *Relayer) submitEncodedBlocks(ctx context.Context, encodedBlocks []byte) (da.Submission, error) {
 	startTime := time.Now()
 	for i := 0; i < SUBMIT_BLOCK_RETRIES; i++ {
-		out, err := r.nearClient.ForceSubmit(encodedBlocks)
+		submission, err := r.daSubmitter.Submit(ctx, encodedBlocks)
 		if err == nil {
 			r.listener.OnDaSubmitted(time.Since(startTime))
 			r.listener.OnRetriesRequired(i)
 
-			return out, nil
+			return submission, nil
 		}
 
-		r.logger.Error("Error submitting blocks to NEAR, resubmitting", "err", err)
+		r.logger.Error("Error submitting blocks to DA, resubmitting", "err", err)
 
-		if strings.Contains(err.Error(), "InvalidNonce") {
+		switch {
+		case errors.Is(err, da.InvalidNonceError):
 			r.logger.Info("Invalid nonce, resubmitting", "err", err)
 			r.listener.OnInvalidNonce()
-		} else if strings.Contains(err.Error(), "Expired") {
+		case errors.Is(err, da.ExpiredTxError):
 			r.logger.Info("Expired, resubmitting", "err", err)
 			r.listener.OnExpiredTx()
-		} else if strings.Contains(err.Error(), "Timeout") {
+		case errors.Is(err, da.TimeoutError):
 			r.logger.Info("Timeout, resubmitting", "err", err)
 			r.listener.OnTimeoutTx()
-		} else {
-			return nil, errors.New("unknown error while submitting block to NEAR")
+		case errors.Is(err, da.UnavailableError):
+			r.logger.Info("DA backend unavailable, resubmitting", "err", err)
+		default:
+			// Kept queued and retried later, as an unknown error may as well
+			// be an outage
+			return da.Submission{}, fmt.Errorf("%w: unknown error while submitting blocks to DA: %w", da.UnavailableError, err)
 		}
 
-		time.Sleep(SUBMIT_BLOCK_RETRY_TIMEOUT)
+		select {
+		case <-time.After(SUBMIT_BLOCK_RETRY_TIMEOUT):
+		case <-ctx.Done():
+			return da.Submission{}, ctx.Err()
+		}
 	}
 
-	return nil, errors.New("failed to submit blocks to NEAR after retries")
+	return da.Submission{}, errors.New("failed to submit blocks to DA after retries")
 }
 
-func (r *Relayer) listenToBlocks(ctx context.Context, blockBatchC chan []*ethtypes.Block, ticker *time.Ticker) {
+func (r *Relayer) listenToBlocks(ctx context.Context) {
 	headers := make(chan *ethtypes.Header)
 
 	sub, err := r.rpcClient.SubscribeNewHead(ctx, headers)
@@ -158,8 +611,6 @@ func (r *Relayer) listenToBlocks(ctx context.Context, blockBatchC chan []*ethtyp
 	}
 	defer sub.Unsubscribe()
 
-	var blocks []*ethtypes.Block
-
 	for {
 		select {
 		case err := <-sub.Err():
@@ -171,15 +622,7 @@ func (r *Relayer) listenToBlocks(ctx context.Context, blockBatchC chan []*ethtyp
 			r.logger.Info("Received rollup block header", "number", header.Number.Uint64())
 			r.listener.OnBlockReceived()
 
-			blockWithNoTransactions := ethtypes.NewBlockWithHeader(header)
-			blocks = append(blocks, blockWithNoTransactions)
-
-		case <-ticker.C:
-			if len(blocks) > 0 {
-				blockBatchC <- blocks
-				blocks = nil
-				ticker.Stop()
-			}
+			r.queueBlocksUpTo(ctx, header)
 
 		case <-ctx.Done():
 			return
@@ -0,0 +1,342 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Nuffle-Labs/nffl/core/safeclient"
+	safeclientmocks "github.com/Nuffle-Labs/nffl/core/safeclient/mocks"
+	coretypes "github.com/Nuffle-Labs/nffl/core/types"
+	"github.com/Nuffle-Labs/nffl/relayer/da"
+)
+
+const TEST_ROLLUP_ID = 1
+
+// Records the heights of every submission attempt, failing with errs in order
+type mockDaSubmitter struct {
+	lock     sync.Mutex
+	errs     []error
+	attempts [][]uint64
+}
+
+func (s *mockDaSubmitter) Submit(ctx context.Context, data []byte) (da.Submission, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	blocks, err := coretypes.DecodeRollupBlocks(data)
+	if err != nil {
+		return da.Submission{}, err
+	}
+
+	heights := make([]uint64, len(blocks))
+	for i, block := range blocks {
+		heights[i] = block.Header.Number.Uint64()
+	}
+	s.attempts = append(s.attempts, heights)
+
+	if len(s.errs) > 0 {
+		err, s.errs = s.errs[0], s.errs[1:]
+		if err != nil {
+			return da.Submission{}, err
+		}
+	}
+
+	return da.Submission{}, nil
+}
+
+func (s *mockDaSubmitter) CheckFinality(ctx context.Context, submission da.Submission) (bool, error) {
+	return true, nil
+}
+
+func newTestRelayer(t *testing.T, submitter da.DaSubmitter) *Relayer {
+	store, err := da.NewBatchStore(filepath.Join(t.TempDir(), "batches.json"))
+	assert.NoError(t, err)
+
+	logger := logging.NewNoopLogger()
+	tracker := da.NewSubmissionTracker(store, 0, logger)
+	tracker.AddRollup(TEST_ROLLUP_ID, submitter)
+
+	return &Relayer{
+		rollupId:        TEST_ROLLUP_ID,
+		logger:          logger,
+		listener:        &SelectiveListener{},
+		maxBlobSize:     DEFAULT_MAX_BLOB_SIZE,
+		maxBatchBlocks:  2,
+		maxQueuedBlocks: MAX_QUEUED_BLOCKS,
+		daSubmitter:     submitter,
+		tracker:         tracker,
+	}
+}
+
+func newTestHeader(height uint64) *ethtypes.Header {
+	return &ethtypes.Header{Number: new(big.Int).SetUint64(height)}
+}
+
+func queueTestBlocks(t *testing.T, relayer *Relayer, from, to uint64) {
+	for height := from; height <= to; height++ {
+		assert.NoError(t, relayer.queueBlock(coretypes.RollupBlock{Header: newTestHeader(height)}))
+	}
+}
+
+func TestSubmitsBatchesInHeightOrder(t *testing.T) {
+	submitter := &mockDaSubmitter{}
+	relayer := newTestRelayer(t, submitter)
+	queueTestBlocks(t, relayer, 1, 5)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	}
+
+	assert.Equal(t, [][]uint64{{1, 2}, {3, 4}, {5}}, submitter.attempts)
+	assert.Empty(t, relayer.queue)
+}
+
+func TestFailedBatchBlocksLaterBlocks(t *testing.T) {
+	submitter := &mockDaSubmitter{errs: []error{errors.New("rejected"), errors.New("rejected")}}
+	relayer := newTestRelayer(t, submitter)
+	queueTestBlocks(t, relayer, 1, 2)
+
+	assert.Error(t, relayer.submitQueuedBlocks(context.Background()))
+
+	// Blocks queued meanwhile wait for the failed batch
+	queueTestBlocks(t, relayer, 3, 4)
+	assert.Error(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Len(t, relayer.queue, 4)
+
+	// Retried first once the DA backend accepts it
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	}
+
+	assert.Equal(t, [][]uint64{{1, 2}, {1, 2}, {1, 2}, {3, 4}}, submitter.attempts)
+	assert.Empty(t, relayer.queue)
+}
+
+func TestRetryWaitsForMinBatchInterval(t *testing.T) {
+	submitter := &mockDaSubmitter{errs: []error{errors.New("rejected")}}
+	relayer := newTestRelayer(t, submitter)
+	relayer.minBatchInterval = DEFAULT_MIN_BATCH_INTERVAL
+	queueTestBlocks(t, relayer, 1, 2)
+
+	assert.Error(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Len(t, submitter.attempts, 1)
+
+	relayer.retryTime = relayer.retryTime.Add(-relayer.minBatchInterval)
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Equal(t, [][]uint64{{1, 2}, {1, 2}}, submitter.attempts)
+}
+
+func TestSkipsRepeatedlyRejectedBatch(t *testing.T) {
+	submitter := &mockDaSubmitter{}
+	relayer := newTestRelayer(t, submitter)
+	// Fails encoding
+	relayer.compression = "unknown"
+
+	skipped := 0
+	relayer.listener = &SelectiveListener{OnBatchSkippedCb: func() { skipped++ }}
+	queueTestBlocks(t, relayer, 1, 3)
+
+	for i := 0; i < MAX_BATCH_REJECTIONS-1; i++ {
+		assert.ErrorIs(t, relayer.submitQueuedBlocks(context.Background()), BatchRejectedError)
+	}
+	assert.Equal(t, 0, skipped)
+
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Equal(t, 1, skipped)
+	assert.Len(t, relayer.queue, 1)
+
+	relayer.compression = coretypes.ROLLUP_BLOCKS_COMPRESSION_NONE
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Equal(t, [][]uint64{{3}}, submitter.attempts)
+	assert.Empty(t, relayer.queue)
+}
+
+func TestUnknownSubmitErrorsNeverSkipBatch(t *testing.T) {
+	errs := make([]error, MAX_BATCH_REJECTIONS+1)
+	for i := range errs {
+		errs[i] = errors.New("rejected")
+	}
+
+	submitter := &mockDaSubmitter{errs: errs}
+	relayer := newTestRelayer(t, submitter)
+	cursorStore, err := safeclient.NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json"))
+	assert.NoError(t, err)
+	relayer.cursorStore = cursorStore
+	queueTestBlocks(t, relayer, 1, 2)
+
+	for i := 0; i < MAX_BATCH_REJECTIONS+1; i++ {
+		assert.ErrorIs(t, relayer.submitQueuedBlocks(context.Background()), da.UnavailableError)
+	}
+
+	assert.Len(t, submitter.attempts, MAX_BATCH_REJECTIONS+1)
+	assert.Len(t, relayer.queue, 2)
+	assert.Equal(t, 0, relayer.batchRejections)
+
+	_, ok, err := cursorStore.FetchCursor(getCursorKey(TEST_ROLLUP_ID))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestTransientErrorsNeverSkipBatch(t *testing.T) {
+	errs := make([]error, MAX_BATCH_REJECTIONS+1)
+	for i := range errs {
+		errs[i] = da.UnavailableError
+	}
+
+	submitter := &mockDaSubmitter{errs: errs}
+	relayer := newTestRelayer(t, submitter)
+	queueTestBlocks(t, relayer, 1, 2)
+
+	// Canceled so that retries don't wait
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < MAX_BATCH_REJECTIONS+1; i++ {
+		assert.Error(t, relayer.submitQueuedBlocks(ctx))
+	}
+
+	assert.Len(t, relayer.queue, 2)
+	assert.Equal(t, 0, relayer.batchRejections)
+}
+
+func TestFullQueueDefersBlocksToBackfill(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rpcClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+
+	relayer := newTestRelayer(t, &mockDaSubmitter{})
+	relayer.rpcClient = rpcClient
+	relayer.maxQueuedBlocks = 2
+
+	ctx := context.Background()
+	relayer.queueBlocksUpTo(ctx, newTestHeader(1))
+	relayer.queueBlocksUpTo(ctx, newTestHeader(2))
+
+	// Dropped while the queue is full
+	relayer.queueBlocksUpTo(ctx, newTestHeader(3))
+	assert.Len(t, relayer.queue, 2)
+	assert.Equal(t, uint64(3), relayer.nextHeight)
+
+	assert.NoError(t, relayer.submitQueuedBlocks(ctx))
+	assert.Empty(t, relayer.queue)
+
+	rpcClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(3)).Return(newTestHeader(3), nil)
+	relayer.queueBlocksUpTo(ctx, newTestHeader(4))
+
+	heights := make([]uint64, len(relayer.queue))
+	for i, queued := range relayer.queue {
+		heights[i] = queued.block.Header.Number.Uint64()
+	}
+	assert.Equal(t, []uint64{3, 4}, heights)
+}
+
+func batchHeights(blocks []coretypes.RollupBlock) []uint64 {
+	heights := make([]uint64, len(blocks))
+	for i, block := range blocks {
+		heights[i] = block.Header.Number.Uint64()
+	}
+
+	return heights
+}
+
+func queueOversizeBlock(t *testing.T, relayer *Relayer, height uint64) {
+	header := newTestHeader(height)
+	header.Extra = make([]byte, 2*relayer.maxBlobSize)
+
+	assert.NoError(t, relayer.queueBlock(coretypes.RollupBlock{Header: header}))
+}
+
+func TestNextBatchRespectsLimits(t *testing.T) {
+	relayer := newTestRelayer(t, &mockDaSubmitter{})
+	relayer.maxBatchBlocks = 3
+	queueTestBlocks(t, relayer, 1, 2)
+
+	blocks, full := relayer.nextBatch()
+	assert.Equal(t, []uint64{1, 2}, batchHeights(blocks))
+	assert.False(t, full)
+
+	queueTestBlocks(t, relayer, 3, 5)
+	blocks, full = relayer.nextBatch()
+	assert.Equal(t, []uint64{1, 2, 3}, batchHeights(blocks))
+	assert.True(t, full)
+
+	// Only two blocks fit in the blob
+	relayer.maxBlobSize = BATCH_SIZE_OVERHEAD + relayer.queue[0].size + relayer.queue[1].size
+	blocks, full = relayer.nextBatch()
+	assert.Equal(t, []uint64{1, 2}, batchHeights(blocks))
+	assert.True(t, full)
+}
+
+func TestOversizeBlockBatchedAlone(t *testing.T) {
+	relayer := newTestRelayer(t, &mockDaSubmitter{})
+	relayer.maxBlobSize = 1000
+	queueOversizeBlock(t, relayer, 1)
+	queueTestBlocks(t, relayer, 2, 2)
+
+	blocks, full := relayer.nextBatch()
+	assert.Equal(t, []uint64{1}, batchHeights(blocks))
+	assert.True(t, full)
+}
+
+func TestOversizeBlockSubmittedIfCompressedFits(t *testing.T) {
+	submitter := &mockDaSubmitter{}
+	relayer := newTestRelayer(t, submitter)
+	relayer.maxBlobSize = 1000
+	relayer.compression = coretypes.ROLLUP_BLOCKS_COMPRESSION_ZSTD
+	queueOversizeBlock(t, relayer, 1)
+
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Equal(t, [][]uint64{{1}}, submitter.attempts)
+	assert.Empty(t, relayer.queue)
+}
+
+func TestOversizeBlockSkipped(t *testing.T) {
+	submitter := &mockDaSubmitter{}
+	relayer := newTestRelayer(t, submitter)
+	relayer.maxBlobSize = 1000
+
+	skipped := 0
+	relayer.listener = &SelectiveListener{OnBatchSkippedCb: func() { skipped++ }}
+	queueOversizeBlock(t, relayer, 1)
+	queueTestBlocks(t, relayer, 2, 2)
+
+	// Never submitted, and skipped without further attempts
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Empty(t, submitter.attempts)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, 0, relayer.batchRejections)
+
+	assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	assert.Equal(t, [][]uint64{{2}}, submitter.attempts)
+}
+
+func TestResubmitsFailedBatchesFirst(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rpcClient := safeclientmocks.NewMockSafeClient(mockCtrl)
+
+	submitter := &mockDaSubmitter{}
+	relayer := newTestRelayer(t, submitter)
+	relayer.rpcClient = rpcClient
+	queueTestBlocks(t, relayer, 3, 3)
+
+	relayer.resubmit(1, 2)
+	rpcClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(1)).Return(newTestHeader(1), nil)
+	rpcClient.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(2)).Return(newTestHeader(2), nil)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, relayer.submitQueuedBlocks(context.Background()))
+	}
+
+	assert.Equal(t, [][]uint64{{1, 2}, {3}}, submitter.attempts)
+	assert.Empty(t, relayer.resubmissions)
+	assert.Empty(t, relayer.queue)
+}